@@ -0,0 +1,131 @@
+// Command collector runs only the data collector of the relay monitor, publishing what it collects to Kafka for a remote analyzer
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/builder"
+	"github.com/ralexstokes/relay-monitor/pkg/config"
+	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/logging"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+var configFile = flag.String("config", "collector.example.yaml", "path to config file")
+
+type Config struct {
+	Network struct {
+		Name string `yaml:"name"`
+	} `yaml:"network"`
+	Consensus struct {
+		Endpoint string `yaml:"endpoint"`
+	} `yaml:"consensus"`
+	Relays    []*builder.Config `yaml:"relays"`
+	Collector *data.Config      `yaml:"collector"`
+	// Queue of events from the collector to the Kafka producer
+	Queue   *data.QueueConfig `yaml:"queue"`
+	Kafka   *data.KafkaConfig `yaml:"kafka"`
+	Logging *logging.Config   `yaml:"logging"`
+}
+
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file: %v", err)
+	}
+
+	// NOTE: allow secrets like relay credentials to be provided via the environment
+	data = config.ExpandEnv(data)
+
+	config := &Config{}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("could not load config: %v", err)
+	}
+	if config.Kafka == nil {
+		return nil, fmt.Errorf("a kafka topic to publish events to is required")
+	}
+	if config.Collector != nil && config.Collector.ForwardRegistrations {
+		return nil, fmt.Errorf("registrations can not be forwarded without the API of the monitor")
+	}
+	return config, nil
+}
+
+func run(ctx context.Context, config *Config, zapLogger *zap.Logger) error {
+	logger := zapLogger.Sugar()
+
+	var relays []*builder.Client
+	for _, relayConfig := range config.Relays {
+		relay, err := builder.NewClientFromConfig(relayConfig)
+		if err != nil {
+			logger.Warnf("could not instantiate relay at %s: %v", relayConfig.Endpoint, err)
+			continue
+		}
+		relays = append(relays, relay)
+	}
+	if len(relays) == 0 {
+		return fmt.Errorf("could not parse any relays, please check configuration")
+	}
+
+	consensusClient, err := consensus.NewClient(ctx, config.Consensus.Endpoint, zapLogger, nil)
+	if err != nil {
+		return fmt.Errorf("could not instantiate consensus client: %v", err)
+	}
+	clock := consensus.NewClock(consensusClient.GenesisTime, consensusClient.SecondsPerSlot, consensusClient.SlotsPerEpoch)
+	currentSlot := clock.CurrentSlot(time.Now().Unix())
+	err = consensusClient.LoadCurrentContext(ctx, currentSlot, clock.EpochForSlot(currentSlot))
+	if err != nil {
+		logger.Warn("could not load the current context from the consensus client")
+	}
+
+	events, err := data.NewQueue(config.Queue, zapLogger)
+	if err != nil {
+		return fmt.Errorf("could not create event queue: %v", err)
+	}
+	producer, err := data.NewKafkaProducer(config.Kafka, zapLogger)
+	if err != nil {
+		return fmt.Errorf("could not instantiate kafka producer: %v", err)
+	}
+	// NOTE: without a store the collector only reports what it gathers from the relays and the consensus client
+	collector := data.NewCollector(config.Collector, zapLogger, relays, clock, consensusClient, nil, events, nil)
+
+	go events.Run(ctx)
+	go producer.Run(ctx, events.Events())
+	logger.Infof("collecting from %d relays", len(relays))
+	return collector.Run(ctx)
+}
+
+func main() {
+	flag.Parse()
+
+	config, err := loadConfig(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	zapLogger, err := logging.New(config.Logging)
+	if err != nil {
+		log.Fatalf("could not build logger: %v", err)
+	}
+	defer func() {
+		err := zapLogger.Sync()
+		if err != nil {
+			log.Fatalf("could not flush log: %v", err)
+		}
+	}()
+
+	logger := zapLogger.Sugar()
+
+	logger.Infof("starting relay collector for %s network", config.Network.Name)
+	err = run(context.Background(), config, zapLogger)
+	if err != nil {
+		logger.Fatalf("could not run relay collector: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ralexstokes/relay-monitor/pkg/aggregator"
+	"github.com/ralexstokes/relay-monitor/pkg/config"
+	"github.com/ralexstokes/relay-monitor/pkg/logging"
+	"gopkg.in/yaml.v3"
+)
+
+var configFile = flag.String("config", "aggregator.example.yaml", "path to config file")
+
+func loadConfig(path string) (*aggregator.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file: %v", err)
+	}
+
+	data = config.ExpandEnv(data)
+
+	config := &aggregator.Config{}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("could not load config: %v", err)
+	}
+	return config, nil
+}
+
+func main() {
+	flag.Parse()
+
+	config, err := loadConfig(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	zapLogger, err := logging.New(config.Logging)
+	if err != nil {
+		log.Fatalf("could not build logger: %v", err)
+	}
+	defer func() {
+		err := zapLogger.Sync()
+		if err != nil {
+			log.Fatalf("could not flush log: %v", err)
+		}
+	}()
+
+	logger := zapLogger.Sugar()
+
+	a, err := aggregator.New(config, zapLogger)
+	if err != nil {
+		logger.Fatalf("could not start relay aggregator: %v", err)
+	}
+	err = a.Run(context.Background())
+	if err != nil {
+		logger.Fatalf("aggregator stopped: %v", err)
+	}
+}
@@ -3,26 +3,82 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/ralexstokes/relay-monitor/pkg/config"
+	"github.com/ralexstokes/relay-monitor/pkg/logging"
 	"github.com/ralexstokes/relay-monitor/pkg/monitor"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 	"gopkg.in/yaml.v3"
 )
 
 var configFile = flag.String("config", "config.example.yaml", "path to config file")
 
+func loadConfig(path string) (*monitor.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file: %v", err)
+	}
+
+	// NOTE: allow secrets like relay credentials to be provided via the environment
+	data = config.ExpandEnv(data)
+
+	config := &monitor.Config{}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("could not load config: %v", err)
+	}
+	return config, nil
+}
+
+// `reloadOnHangup` reloads the config file into the monitor whenever the process receives `SIGHUP`
+func reloadOnHangup(ctx context.Context, logger *zap.SugaredLogger, m *monitor.Monitor) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	for range hangups {
+		logger.Infof("reloading config file %s", *configFile)
+		config, err := loadConfig(*configFile)
+		if err != nil {
+			logger.Warnf("could not reload config: %v", err)
+			continue
+		}
+		err = m.Reload(ctx, config)
+		if err != nil {
+			logger.Warnf("could not apply reloaded config: %v", err)
+		}
+	}
+}
+
 func main() {
 	flag.Parse()
 
-	loggingConfig := zap.NewDevelopmentConfig()
-	loggingConfig.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	if flag.Arg(0) == "recommend" {
+		err := recommend(flag.Args()[1:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if flag.Arg(0) == "reanalyze" {
+		err := reanalyze(flag.Args()[1:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
-	zapLogger, err := loggingConfig.Build()
+	config, err := loadConfig(*configFile)
 	if err != nil {
-		log.Fatalf("could not open log file: %v", err)
+		log.Fatal(err)
+	}
+
+	zapLogger, err := logging.New(config.Logging)
+	if err != nil {
+		log.Fatalf("could not build logger: %v", err)
 	}
 	defer func() {
 		err := zapLogger.Sync()
@@ -33,17 +89,6 @@ func main() {
 
 	logger := zapLogger.Sugar()
 
-	data, err := os.ReadFile(*configFile)
-	if err != nil {
-		logger.Fatalf("could not read config file: %v", err)
-	}
-
-	config := &monitor.Config{}
-	err = yaml.Unmarshal(data, config)
-	if err != nil {
-		logger.Fatalf("could not load config: %v", err)
-	}
-
 	ctx := context.Background()
 	logger.Infof("starting relay monitor for %s network", config.Network.Name)
 	m, err := monitor.New(ctx, config, zapLogger)
@@ -51,5 +96,7 @@ func main() {
 		logger.Fatalf("could not start relay monitor: %v", err)
 	}
 
+	go reloadOnHangup(ctx, logger, m)
+
 	m.Run(ctx)
 }
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/ralexstokes/relay-monitor/pkg/client"
+)
+
+// `reanalyze` validates the bids stored by a running monitor for a span of slots again with its current rules,
+// in batches of slots so each request completes within the request timeout of the monitor
+func reanalyze(args []string) error {
+	flags := flag.NewFlagSet("reanalyze", flag.ExitOnError)
+	endpoint := flags.String("monitor", "http://localhost:8080", "endpoint of the API of the relay monitor")
+	fromSlot := flags.Uint64("from-slot", 0, "first slot of the bids to reanalyze")
+	toSlot := flags.Uint64("to-slot", 0, "last slot of the bids to reanalyze")
+	batch := flags.Uint64("batch", 32, "number of slots reanalyzed per request")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *toSlot < *fromSlot {
+		return fmt.Errorf("invalid span: to slot %d is before from slot %d", *toSlot, *fromSlot)
+	}
+	if *batch == 0 {
+		return fmt.Errorf("batch must be at least one slot")
+	}
+
+	c := client.New(*endpoint)
+	analyzed, changed, failed := 0, 0, 0
+	ruleset := ""
+	for start := *fromSlot; start <= *toSlot; start += *batch {
+		end := start + *batch - 1
+		if end > *toSlot || end < start {
+			end = *toSlot
+		}
+		batchStart, batchEnd := start, end
+		response, err := c.Reanalyze(context.Background(), &client.SpanQuery{Start: &batchStart, End: &batchEnd})
+		if err != nil {
+			return fmt.Errorf("could not reanalyze slots %d to %d: %v", start, end, err)
+		}
+		fmt.Printf("slots %d to %d: analyzed %d bids, %d changed, %d failed\n", start, end, response.Analyzed, response.Changed, response.Failed)
+		ruleset = response.Ruleset
+		analyzed += response.Analyzed
+		changed += response.Changed
+		failed += response.Failed
+		if end == *toSlot {
+			break
+		}
+	}
+	fmt.Printf("reanalyzed %d bids of slots %d to %d with ruleset %s: %d changed, %d failed\n", analyzed, *fromSlot, *toSlot, ruleset, changed, failed)
+	return nil
+}
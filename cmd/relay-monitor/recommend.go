@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/ralexstokes/relay-monitor/pkg/client"
+)
+
+// `recommend` prints the `-relays` flag of mev-boost with the relays recommended by a running monitor
+func recommend(args []string) error {
+	flags := flag.NewFlagSet("recommend", flag.ExitOnError)
+	endpoint := flags.String("monitor", "http://localhost:8080", "endpoint of the API of the relay monitor")
+	minScore := flags.Float64("min-score", 0, "minimum overall score, the configured minimum of the monitor if unset")
+	minLatencyScore := flags.Float64("min-latency-score", 0, "minimum latency score, the configured minimum of the monitor if unset")
+	window := flags.Uint64("window", 0, "number of recent slots to score, the configured window of the monitor if unset")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	query := &client.RecommendQuery{}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "min-score":
+			query.MinScore = minScore
+		case "min-latency-score":
+			query.MinLatencyScore = minLatencyScore
+		case "window":
+			query.Window = window
+		}
+	})
+
+	response, err := client.New(*endpoint).Recommend(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("could not get recommended relays: %v", err)
+	}
+	if len(response.Recommended) == 0 {
+		return fmt.Errorf("no relay reaches a score of %g and a latency score of %g over slots %d to %d", response.MinScore, response.MinLatencyScore, response.Span.Start, response.Span.End)
+	}
+	fmt.Println(response.Relays)
+	return nil
+}
@@ -0,0 +1,268 @@
+// Package aggregator combines the findings of relay monitors observing relays from different regions
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/ralexstokes/relay-monitor/pkg/api"
+	"github.com/ralexstokes/relay-monitor/pkg/client"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+const GetFaultsEndpoint = "/aggregator/v1/faults"
+
+// `RegionFaults` is what a single region observed of a relay
+type RegionFaults struct {
+	// Number of slots the region received a bid from the relay
+	Bids uint `json:"bids"`
+	// Number of slots the region received a bid from some relay but not from the relay
+	NoBids uint `json:"no_bids"`
+	// Number of bids with each category of fault
+	Faults map[string]uint `json:"faults"`
+}
+
+// `RelayFaults` reconciles the observations of a relay across regions
+type RelayFaults struct {
+	// Number of slots any region received a bid from the relay
+	Bids uint `json:"bids"`
+	// Number of slots some region received a bid from some relay and no region received a bid from the relay
+	NoBids uint `json:"no_bids"`
+	// Number of slots any region recorded each category of fault for the relay
+	Faults  map[string]uint          `json:"faults"`
+	Regions map[string]*RegionFaults `json:"regions"`
+}
+
+// `FaultsResponse` is the combined findings of every monitor over the span of slots they all cover
+type FaultsResponse struct {
+	Span    api.SlotSpan                     `json:"span"`
+	Regions []string                         `json:"regions"`
+	Data    map[types.PublicKey]*RelayFaults `json:"data"`
+}
+
+// `reconcile` combines the bid analyses of each region over the inclusive slot range
+func reconcile(analyses map[string]map[types.PublicKey][]types.BidAnalysis, startSlot, endSlot types.Slot) map[types.PublicKey]*RelayFaults {
+	// slots with a bid from some relay in each region and in any region
+	regionSlots := make(map[string]map[types.Slot]struct{})
+	slots := make(map[types.Slot]struct{})
+	// slots with a bid from each relay in each region and in any region
+	regionRelaySlots := make(map[string]map[types.PublicKey]map[types.Slot]struct{})
+	relaySlots := make(map[types.PublicKey]map[types.Slot]struct{})
+	// slots each category of fault was recorded in for each relay in any region
+	faultSlots := make(map[types.PublicKey]map[string]map[types.Slot]struct{})
+
+	record := make(map[types.PublicKey]*RelayFaults)
+	for region, relays := range analyses {
+		regionSlots[region] = make(map[types.Slot]struct{})
+		regionRelaySlots[region] = make(map[types.PublicKey]map[types.Slot]struct{})
+		for relay, relayAnalyses := range relays {
+			if _, ok := record[relay]; !ok {
+				record[relay] = &RelayFaults{
+					Faults:  make(map[string]uint),
+					Regions: make(map[string]*RegionFaults),
+				}
+				relaySlots[relay] = make(map[types.Slot]struct{})
+				faultSlots[relay] = make(map[string]map[types.Slot]struct{})
+			}
+			regionFaults := &RegionFaults{
+				Faults: make(map[string]uint),
+			}
+			record[relay].Regions[region] = regionFaults
+			regionRelaySlots[region][relay] = make(map[types.Slot]struct{})
+
+			for _, analysis := range relayAnalyses {
+				slot := analysis.Context.Slot
+				if slot < startSlot || slot > endSlot {
+					continue
+				}
+				regionSlots[region][slot] = struct{}{}
+				slots[slot] = struct{}{}
+				regionRelaySlots[region][relay][slot] = struct{}{}
+				relaySlots[relay][slot] = struct{}{}
+				if analysis.Category == "" {
+					continue
+				}
+				regionFaults.Faults[analysis.Category] += 1
+				if _, ok := faultSlots[relay][analysis.Category]; !ok {
+					faultSlots[relay][analysis.Category] = make(map[types.Slot]struct{})
+				}
+				faultSlots[relay][analysis.Category][slot] = struct{}{}
+			}
+		}
+	}
+
+	for relay, relayFaults := range record {
+		relayFaults.Bids = uint(len(relaySlots[relay]))
+		// NOTE: a bid is only missing if no region received it
+		relayFaults.NoBids = uint(len(slots)) - relayFaults.Bids
+		for category, categorySlots := range faultSlots[relay] {
+			relayFaults.Faults[category] = uint(len(categorySlots))
+		}
+		for region, regionFaults := range relayFaults.Regions {
+			regionFaults.Bids = uint(len(regionRelaySlots[region][relay]))
+			regionFaults.NoBids = uint(len(regionSlots[region])) - regionFaults.Bids
+		}
+	}
+	return record
+}
+
+// `Aggregator` serves the combined findings of several relay monitors
+type Aggregator struct {
+	config *Config
+	logger *zap.Logger
+
+	clients map[string]*client.Client
+}
+
+func New(config *Config, zapLogger *zap.Logger) (*Aggregator, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	clients := make(map[string]*client.Client)
+	for _, monitor := range config.Monitors {
+		clients[monitor.Region] = client.New(monitor.Endpoint)
+	}
+	return &Aggregator{
+		config:  config,
+		logger:  zapLogger,
+		clients: clients,
+	}, nil
+}
+
+// `spanQuery` forwards the span and tags of a request to the monitors
+func spanQuery(q url.Values) (*client.SpanQuery, error) {
+	span := &client.SpanQuery{
+		Tags: q["tag"],
+	}
+	for name, bound := range map[string]**uint64{"start": &span.Start, "end": &span.End, "window": &span.Window} {
+		value := q.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", name, err)
+		}
+		*bound = &parsed
+	}
+	return span, nil
+}
+
+// `GetFaults` pulls the bid analyses of every monitor over `span` and reconciles them over the slots every monitor covers
+func (a *Aggregator) GetFaults(ctx context.Context, span *client.SpanQuery) (*FaultsResponse, error) {
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	responses := make(map[string]*api.BidAnalysesResponse)
+	errs := make(map[string]error)
+	for region, c := range a.clients {
+		region, c := region, c
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := c.GetFaultRecords(ctx, span)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				errs[region] = err
+				return
+			}
+			responses[region] = response
+		}()
+	}
+	wg.Wait()
+	for region, err := range errs {
+		// NOTE: without every region a bid missed by the others could be counted as missing
+		return nil, fmt.Errorf("could not fetch fault records from monitor in region %s: %v", region, err)
+	}
+
+	var combined *api.SlotSpan
+	regions := make([]string, 0, len(responses))
+	analyses := make(map[string]map[types.PublicKey][]types.BidAnalysis)
+	for region, response := range responses {
+		regions = append(regions, region)
+		analyses[region] = response.Data
+		// NOTE: monitors resolve default bounds with their own clocks so only the slots they all cover are reconciled
+		if combined == nil {
+			combined = &api.SlotSpan{Start: response.Span.Start, End: response.Span.End}
+			continue
+		}
+		if response.Span.Start > combined.Start {
+			combined.Start = response.Span.Start
+		}
+		if response.Span.End < combined.End {
+			combined.End = response.Span.End
+		}
+	}
+	sort.Strings(regions)
+	if combined.End < combined.Start {
+		return nil, fmt.Errorf("monitors do not cover a common span of slots")
+	}
+	return &FaultsResponse{
+		Span:    *combined,
+		Regions: regions,
+		Data:    reconcile(analyses, combined.Start, combined.End),
+	}, nil
+}
+
+func (a *Aggregator) handleFaultsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := a.logger.Sugar()
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not supported", http.StatusMethodNotAllowed)
+		return
+	}
+	span, err := spanQuery(r.URL.Query())
+	if err != nil {
+		logger.Errorw("error parsing query param for faults request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	faults, err := a.GetFaults(r.Context(), span)
+	if err != nil {
+		logger.Errorw("could not aggregate faults", "error", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(faults)
+	if err != nil {
+		logger.Errorw("could not encode aggregated faults", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (a *Aggregator) Run(ctx context.Context) error {
+	logger := a.logger.Sugar()
+	host := fmt.Sprintf("%s:%d", a.config.Host, a.config.Port)
+	logger.Infof("aggregator listening on %s for %d monitors", host, len(a.clients))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(GetFaultsEndpoint, a.handleFaultsRequest)
+	server := &http.Server{
+		Addr:    host,
+		Handler: mux,
+	}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	err := server.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,51 @@
+package aggregator
+
+import (
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func analysis(relay types.PublicKey, slot types.Slot, category string) types.BidAnalysis {
+	return types.BidAnalysis{
+		Context: types.BidContext{
+			Slot:           slot,
+			RelayPublicKey: relay,
+		},
+		Category: category,
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	a := types.PublicKey{0x01}
+	b := types.PublicKey{0x02}
+	analyses := map[string]map[types.PublicKey][]types.BidAnalysis{
+		"eu": {
+			a: {analysis(a, 10, ""), analysis(a, 11, "consensus_invalid")},
+			b: {analysis(b, 10, ""), analysis(b, 12, "")},
+		},
+		"us": {
+			// `a` missed slot 12 only in the other region
+			a: {analysis(a, 11, "consensus_invalid"), analysis(a, 12, "")},
+			b: {analysis(b, 10, ""), analysis(b, 11, ""), analysis(b, 13, "")},
+		},
+	}
+	record := reconcile(analyses, 10, 12)
+
+	// slots 10, 11 and 12 had bids, slot 13 is outside the span
+	if record[a].Bids != 3 || record[a].NoBids != 0 {
+		t.Errorf("expected relay a to have bids in every slot, got %+v", record[a])
+	}
+	if record[a].Faults["consensus_invalid"] != 1 {
+		t.Errorf("expected a fault recorded by both regions to count once, got %+v", record[a].Faults)
+	}
+	if record[a].Regions["eu"].NoBids != 1 || record[a].Regions["us"].NoBids != 1 {
+		t.Errorf("expected relay a to miss a slot in each region, got eu %+v and us %+v", record[a].Regions["eu"], record[a].Regions["us"])
+	}
+	if record[b].Bids != 3 || record[b].NoBids != 0 {
+		t.Errorf("expected relay b to have bids in every slot, got %+v", record[b])
+	}
+	if record[b].Regions["us"].Bids != 2 || record[b].Regions["us"].NoBids != 1 {
+		t.Errorf("expected relay b to miss slot 12 in region us, got %+v", record[b].Regions["us"])
+	}
+}
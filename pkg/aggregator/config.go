@@ -0,0 +1,39 @@
+package aggregator
+
+import (
+	"fmt"
+
+	"github.com/ralexstokes/relay-monitor/pkg/logging"
+)
+
+// `MonitorConfig` is a relay monitor the aggregator pulls findings from
+type MonitorConfig struct {
+	// Region the monitor observes relays from, e.g. `eu`, naming it in combined reports
+	Region string `yaml:"region"`
+	// Endpoint of the monitor's API, e.g. `http://localhost:8080`
+	Endpoint string `yaml:"endpoint"`
+}
+
+type Config struct {
+	Host     string          `yaml:"host"`
+	Port     uint16          `yaml:"port"`
+	Monitors []MonitorConfig `yaml:"monitors"`
+	Logging  *logging.Config `yaml:"logging"`
+}
+
+func (c *Config) Validate() error {
+	if len(c.Monitors) == 0 {
+		return fmt.Errorf("no monitors to aggregate")
+	}
+	regions := make(map[string]struct{})
+	for _, monitor := range c.Monitors {
+		if monitor.Region == "" || monitor.Endpoint == "" {
+			return fmt.Errorf("monitor %+v requires a region and an endpoint", monitor)
+		}
+		if _, ok := regions[monitor.Region]; ok {
+			return fmt.Errorf("region %s is given for more than one monitor", monitor.Region)
+		}
+		regions[monitor.Region] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Package alerts notifies operators of the faults recorded by the monitor
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/email"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+// `RelayFaults` are the faults of a relay within a batch
+type RelayFaults struct {
+	RelayPublicKey types.PublicKey
+	Faults         []types.BidAnalysis
+}
+
+// `Batch` is the data available to the alert templates
+type Batch struct {
+	Network string
+	Start   time.Time
+	End     time.Time
+	Faults  []types.BidAnalysis
+	// The faults grouped by relay, in order of the first fault of each relay
+	Relays []RelayFaults
+}
+
+func newBatch(network string, start, end time.Time, faults []types.BidAnalysis) *Batch {
+	batch := &Batch{
+		Network: network,
+		Start:   start,
+		End:     end,
+		Faults:  faults,
+	}
+	indices := make(map[types.PublicKey]int)
+	for _, fault := range faults {
+		relay := fault.Context.RelayPublicKey
+		i, ok := indices[relay]
+		if !ok {
+			i = len(batch.Relays)
+			indices[relay] = i
+			batch.Relays = append(batch.Relays, RelayFaults{RelayPublicKey: relay})
+		}
+		batch.Relays[i].Faults = append(batch.Relays[i].Faults, fault)
+	}
+	return batch
+}
+
+// `Notifier` batches the faults from the analyzer within each window and emails them as a single alert
+type Notifier struct {
+	config  *Config
+	logger  *zap.Logger
+	network string
+
+	analyzer *analysis.Analyzer
+	subject  *template.Template
+	body     *template.Template
+}
+
+func New(config *Config, zapLogger *zap.Logger, network string, analyzer *analysis.Analyzer) (*Notifier, error) {
+	if config.Email == nil {
+		return nil, fmt.Errorf("an email config is required for alerts")
+	}
+	err := config.Email.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid email config: %v", err)
+	}
+	subject, err := template.New("subject").Parse(config.subjectTemplate())
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject template: %v", err)
+	}
+	body, err := template.New("body").Parse(config.bodyTemplate())
+	if err != nil {
+		return nil, fmt.Errorf("invalid body template: %v", err)
+	}
+	return &Notifier{
+		config:   config,
+		logger:   zapLogger,
+		network:  network,
+		analyzer: analyzer,
+		subject:  subject,
+		body:     body,
+	}, nil
+}
+
+func (n *Notifier) render(batch *Batch) (string, string, error) {
+	var subject, body strings.Builder
+	err := n.subject.Execute(&subject, batch)
+	if err != nil {
+		return "", "", err
+	}
+	err = n.body.Execute(&body, batch)
+	if err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
+
+func (n *Notifier) notify(batch *Batch) {
+	logger := n.logger.Sugar()
+
+	subject, body, err := n.render(batch)
+	if err != nil {
+		logger.Warnf("could not render alert: %v", err)
+		return
+	}
+	err = email.Send(n.config.Email, subject, body)
+	if err != nil {
+		logger.Warnf("could not email alert for %d faults: %v", len(batch.Faults), err)
+	}
+}
+
+func (n *Notifier) Run(ctx context.Context) {
+	feed, cancel := n.analyzer.SubscribeBidAnalyses()
+	defer cancel()
+
+	ticker := time.NewTicker(n.config.window())
+	defer ticker.Stop()
+
+	start := time.Now()
+	var faults []types.BidAnalysis
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case analysis, ok := <-feed:
+			if !ok {
+				return
+			}
+			if analysis.Category == "" {
+				continue
+			}
+			faults = append(faults, analysis)
+		case end := <-ticker.C:
+			if len(faults) > 0 {
+				go n.notify(newBatch(n.network, start, end, faults))
+				faults = nil
+			}
+			start = end
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package alerts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/email"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestRenderBatch(t *testing.T) {
+	config := &Config{
+		Email: &email.Config{
+			Server: "smtp.example.com:587",
+			From:   "monitor@example.com",
+			To:     []string{"ops@example.com"},
+		},
+	}
+	notifier, err := New(config, nil, "mainnet", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	relayA := types.PublicKey{0x01}
+	relayB := types.PublicKey{0x02}
+	fault := func(slot types.Slot, relay types.PublicKey, reason string) types.BidAnalysis {
+		return types.BidAnalysis{
+			Context:  types.BidContext{Slot: slot, RelayPublicKey: relay},
+			Category: analysis.CategoryConsensusInvalid,
+			Reason:   reason,
+		}
+	}
+	start := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	batch := newBatch("mainnet", start, start.Add(5*time.Minute), []types.BidAnalysis{
+		fault(10, relayA, "invalid signature"),
+		fault(11, relayB, ""),
+		fault(12, relayA, "invalid parent hash"),
+	})
+	if len(batch.Relays) != 2 || len(batch.Relays[0].Faults) != 2 || batch.Relays[0].RelayPublicKey != relayA {
+		t.Fatalf("unexpected grouping of faults: %+v", batch.Relays)
+	}
+
+	subject, body, err := notifier.render(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "Relay monitor on mainnet: 3 faults on 2 relays" {
+		t.Fatalf("unexpected subject: %s", subject)
+	}
+	for _, expected := range []string{
+		"3 faults were recorded between 12:00:00 and 12:05:00 UTC on 2023-05-01.",
+		"- slot 10: consensus_invalid (invalid signature)",
+		"- slot 11: consensus_invalid\n",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Fatalf("expected alert body to contain %q:\n%s", expected, body)
+		}
+	}
+}
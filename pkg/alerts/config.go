@@ -0,0 +1,51 @@
+package alerts
+
+import (
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/email"
+)
+
+const (
+	DefaultWindow = 5 * time.Minute
+
+	DefaultSubjectTemplate = `Relay monitor on {{ .Network }}: {{ len .Faults }} faults on {{ len .Relays }} relays`
+	DefaultBodyTemplate    = `# Faults on {{ .Network }}
+
+{{ len .Faults }} faults were recorded between {{ .Start.UTC.Format "15:04:05" }} and {{ .End.UTC.Format "15:04:05" }} UTC on {{ .End.UTC.Format "2006-01-02" }}.
+{{ range .Relays }}
+## ` + "`{{ .RelayPublicKey }}`" + `
+{{ range .Faults }}
+- slot {{ .Context.Slot }}: {{ .Category }}{{ if .Reason }} ({{ .Reason }}){{ end }}{{ end }}
+{{ end }}`
+)
+
+type Config struct {
+	// Faults within this window are batched into a single alert
+	Window time.Duration `yaml:"window"`
+	// Go templates of the alert subject and body, given the batch of faults
+	Subject string        `yaml:"subject"`
+	Body    string        `yaml:"body"`
+	Email   *email.Config `yaml:"email"`
+}
+
+func (c *Config) window() time.Duration {
+	if c.Window == 0 {
+		return DefaultWindow
+	}
+	return c.Window
+}
+
+func (c *Config) subjectTemplate() string {
+	if c.Subject == "" {
+		return DefaultSubjectTemplate
+	}
+	return c.Subject
+}
+
+func (c *Config) bodyTemplate() string {
+	if c.Body == "" {
+		return DefaultBodyTemplate
+	}
+	return c.Body
+}
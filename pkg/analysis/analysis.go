@@ -1,12 +1,83 @@
 package analysis
 
+import (
+	"fmt"
+	"strings"
+)
+
 type InvalidBid struct {
 	Reason  string
 	Type    uint
 	Context map[string]interface{}
+	// Rule of bid validation the bid fails, if the fault is of a configurable rule
+	Rule string
+	// Severity of the fault, where faults of no configurable rule are critical
+	Severity string
+	// Slots the canonical block the bid was built on lags behind the parent of its slot, for a bid with a stale parent
+	HeadLag uint64
 }
 
 const (
 	InvalidBidConsensusType uint = iota
 	InvalidBidIgnoredPreferencesType
+	// The payload of an accepted bid does not match the header of the bid
+	InvalidBidMalformedPayloadType
+	// The payload of an accepted bid does not pay the proposer the value of the bid
+	InvalidBidPaymentType
+	// The canonical block of a winning bid delivered less value to the proposer than claimed by the bid
+	InvalidBidValueOverstatedType
+)
+
+// Categories of bid analysis as recorded in the store
+const (
+	CategoryConsensusInvalid   = "consensus_invalid"
+	CategoryIgnoredPreferences = "ignored_preferences"
+	CategoryMalformedPayload   = "malformed_payload"
+	CategoryPaymentInvalid     = "payment_invalid"
+	CategoryValueOverstated    = "value_overstated"
+	CategoryUnknown            = "unknown"
 )
+
+// Categories of analysis which can be disabled for a relay with `disabled_checks`
+var disableableCategories = []string{CategoryConsensusInvalid, CategoryIgnoredPreferences}
+
+// `ValidateDisabledChecks` checks that each of `checks` names a category of analysis which can be disabled
+func ValidateDisabledChecks(checks []string) error {
+	for _, check := range checks {
+		known := false
+		for _, category := range disableableCategories {
+			if check == category {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown check %s, expected one of %s", check, strings.Join(disableableCategories, ", "))
+		}
+	}
+	return nil
+}
+
+func (b *InvalidBid) severity() string {
+	if b.Severity == "" {
+		return SeverityCritical
+	}
+	return b.Severity
+}
+
+func (b *InvalidBid) Category() string {
+	switch b.Type {
+	case InvalidBidConsensusType:
+		return CategoryConsensusInvalid
+	case InvalidBidIgnoredPreferencesType:
+		return CategoryIgnoredPreferences
+	case InvalidBidMalformedPayloadType:
+		return CategoryMalformedPayload
+	case InvalidBidPaymentType:
+		return CategoryPaymentInvalid
+	case InvalidBidValueOverstatedType:
+		return CategoryValueOverstated
+	default:
+		return CategoryUnknown
+	}
+}
@@ -3,14 +3,20 @@ package analysis
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/holiman/uint256"
 	"github.com/ralexstokes/relay-monitor/pkg/builder"
 	"github.com/ralexstokes/relay-monitor/pkg/consensus"
 	"github.com/ralexstokes/relay-monitor/pkg/crypto"
 	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/execution"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
 	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/tracing"
 	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -19,51 +25,251 @@ const (
 )
 
 type Analyzer struct {
+	config *Config
 	logger *zap.Logger
 
 	events <-chan data.Event
 
 	store           store.Storer
 	consensusClient *consensus.Client
-	clock           *consensus.Clock
+	// additional consensus clients which must agree on expected values before a consensus fault is recorded
+	quorumClients []*consensus.Client
+	clock         *consensus.Clock
+	// optional execution client to verify payments, the value delivered by the blocks of winning bids and censorship against execution state
+	executionClient *execution.Client
+
+	// relay -> metadata of the relay, where fault stats are kept per epoch in `faultsByEpoch`
+	faults FaultRecord
+	// relay -> epoch -> faults of the relay in the epoch
+	faultsByEpoch map[types.PublicKey]map[types.Epoch]*FaultStats
+	faultsLock    sync.Mutex
+
+	registrationCoverage     RegistrationCoverageRecord
+	registrationCoverageLock sync.Mutex
+
+	// slot -> bidPresence
+	bidPresence     map[types.Slot]*bidPresence
+	bidPresenceLock sync.Mutex
+
+	// latest sample of the bid of each context, to detect cancellations between samples
+	bidSamples     map[types.BidContext]types.BidSample
+	bidSamplesLock sync.Mutex
+
+	// slot -> relays which reported delivery of a payload
+	deliveredPayloads     map[types.Slot]map[types.PublicKey]struct{}
+	deliveredPayloadsLock sync.Mutex
+
+	// verifier of the signatures of bids, batching the verifications of concurrent workers
+	verifier *crypto.BatchVerifier
+	// configuration of each rule of bid validation
+	rules ruleSet
+	// identifier of `rules` stamped on each bid analysis
+	ruleset string
+	// relay -> categories of analysis disabled by policy
+	disabledChecks map[types.PublicKey]map[string]struct{}
+	// relay -> client of the relay, to exercise `getPayload` with observed acceptances
+	relays map[types.PublicKey]*builder.Client
+
+	// subscribers to the feed of bid analyses
+	subscriptions      map[uint64]chan types.BidAnalysis
+	nextSubscriptionID uint64
+	subscriptionsLock  sync.Mutex
+
+	// slot -> proposer of the slot and relays expected to serve it
+	upcomingSlots     map[types.Slot]types.UpcomingSlot
+	upcomingSlotsLock sync.Mutex
+
+	censorshipWatchList map[types.Address]struct{}
+	censorship          *CensorshipReport
+	censorshipLock      sync.Mutex
+	// transactions observed in the mempool of the execution client, `nil` unless delivered blocks are compared against it
+	mempool *mempool
+
+	// queues of the workers processing events, if events are processed concurrently
+	workers []chan data.Event
+}
+
+func NewAnalyzer(config *Config, logger *zap.Logger, relays []*builder.Client, events <-chan data.Event, store store.Storer, consensusClient *consensus.Client, quorumClients []*consensus.Client, clock *consensus.Clock, executionClient *execution.Client) *Analyzer {
+	censorshipWatchList := make(map[types.Address]struct{})
+	for _, address := range config.censorship().WatchList {
+		censorshipWatchList[address] = struct{}{}
+	}
+	analyzer := &Analyzer{
+		config:               config,
+		logger:               logger,
+		events:               events,
+		store:                store,
+		consensusClient:      consensusClient,
+		quorumClients:        quorumClients,
+		clock:                clock,
+		executionClient:      executionClient,
+		faults:               make(FaultRecord),
+		faultsByEpoch:        make(map[types.PublicKey]map[types.Epoch]*FaultStats),
+		registrationCoverage: make(RegistrationCoverageRecord),
+		bidPresence:          make(map[types.Slot]*bidPresence),
+		bidSamples:           make(map[types.BidContext]types.BidSample),
+		deliveredPayloads:    make(map[types.Slot]map[types.PublicKey]struct{}),
+		subscriptions:        make(map[uint64]chan types.BidAnalysis),
+		upcomingSlots:        make(map[types.Slot]types.UpcomingSlot),
+		censorshipWatchList:  censorshipWatchList,
+		verifier:             crypto.NewBatchVerifier(),
+		rules:                newRuleSet(config.rules()),
+		censorship: &CensorshipReport{
+			Network: &CensorshipStats{},
+			Relays:  make(map[types.PublicKey]*CensorshipStats),
+		},
+	}
+	if config.censorship().Mempool && executionClient != nil {
+		analyzer.mempool = newMempool()
+	}
+	analyzer.ruleset = config.ruleset(analyzer.rules)
+	analyzer.SetRelays(relays)
+	return analyzer
+}
 
-	faults     FaultRecord
-	faultsLock sync.Mutex
+// `Ruleset` returns the identifier of the rules the analyzer validates bids with
+func (a *Analyzer) Ruleset() string {
+	return a.ruleset
 }
 
-func NewAnalyzer(logger *zap.Logger, relays []*builder.Client, events <-chan data.Event, store store.Storer, consensusClient *consensus.Client, clock *consensus.Clock) *Analyzer {
+// `SetRelays` replaces the relays the analyzer records faults for.
+// Faults recorded so far are kept for relays in `relays` and dropped for any other relay.
+func (a *Analyzer) SetRelays(relays []*builder.Client) {
+	a.faultsLock.Lock()
+	defer a.faultsLock.Unlock()
+
 	faults := make(FaultRecord)
+	faultsByEpoch := make(map[types.PublicKey]map[types.Epoch]*FaultStats)
+	disabledChecks := make(map[types.PublicKey]map[string]struct{})
+	clients := make(map[types.PublicKey]*builder.Client)
 	for _, relay := range relays {
+		clients[relay.PublicKey] = relay
+		if checks := relay.DisabledChecks(); len(checks) > 0 {
+			categories := make(map[string]struct{})
+			for _, category := range checks {
+				categories[category] = struct{}{}
+			}
+			disabledChecks[relay.PublicKey] = categories
+		}
+		meta := &Meta{
+			Endpoint: relay.Hostname(),
+		}
+		if metadata := relay.Metadata(); metadata != nil {
+			meta.RelayMetadata = *metadata
+		}
 		faults[relay.PublicKey] = &Faults{
-			Stats: &FaultStats{},
-			Meta: &Meta{
-				Endpoint: relay.Hostname(),
-			},
+			Meta: meta,
+		}
+		if existing, ok := a.faultsByEpoch[relay.PublicKey]; ok {
+			faultsByEpoch[relay.PublicKey] = existing
+		} else {
+			faultsByEpoch[relay.PublicKey] = make(map[types.Epoch]*FaultStats)
 		}
 	}
-	return &Analyzer{
-		logger:          logger,
-		events:          events,
-		store:           store,
-		consensusClient: consensusClient,
-		clock:           clock,
-		faults:          faults,
-	}
+	a.faults = faults
+	a.faultsByEpoch = faultsByEpoch
+	a.disabledChecks = disabledChecks
+	a.relays = clients
 }
 
+// `GetFaults` returns the faults of each relay in the inclusive epoch range
 func (a *Analyzer) GetFaults(start, end types.Epoch) FaultRecord {
 	a.faultsLock.Lock()
 	defer a.faultsLock.Unlock()
 
 	faults := make(FaultRecord)
 	for relay, summary := range a.faults {
-		summary := *summary
-		faults[relay] = &summary
+		meta := *summary.Meta
+		faults[relay] = &Faults{
+			Stats: sumFaultStats(a.faultsByEpoch[relay], start, end),
+			Meta:  &meta,
+		}
+		faults[relay].Stats.StaleHead = a.config.staleParents().isStale(faults[relay].Stats)
 	}
 
 	return faults
 }
 
+// `faultStats` returns the fault stats of the relay for the epoch of `slot` or `nil` if the relay is not monitored
+// NOTE: must be called with `faultsLock` held
+func (a *Analyzer) faultStats(relay types.PublicKey, slot types.Slot) *FaultStats {
+	faultsByEpoch, ok := a.faultsByEpoch[relay]
+	if !ok {
+		return nil
+	}
+	epoch := a.clock.EpochForSlot(slot)
+	stats, ok := faultsByEpoch[epoch]
+	if !ok {
+		stats = &FaultStats{}
+		faultsByEpoch[epoch] = stats
+	}
+	return stats
+}
+
+func (a *Analyzer) GetRegistrationCoverage() RegistrationCoverageRecord {
+	a.registrationCoverageLock.Lock()
+	defer a.registrationCoverageLock.Unlock()
+
+	coverage := make(RegistrationCoverageRecord)
+	for relay, summary := range a.registrationCoverage {
+		summary := *summary
+		coverage[relay] = &summary
+	}
+
+	return coverage
+}
+
+// `gasLimitDelta` is the most the gas limit of a block may differ from the gas limit of its parent, as a builder following
+// `geth` adjusts it
+func gasLimitDelta(parentGasLimit uint64) uint64 {
+	if parentGasLimit < GasLimitBoundDivisor {
+		return 0
+	}
+	return parentGasLimit/GasLimitBoundDivisor - 1
+}
+
+// `expectedGasLimit` follows the gas limit calculation of `geth`, moving from the parent's gas limit
+// towards the preference of the proposer by at most the bound allowed by the protocol
+func expectedGasLimit(parentGasLimit, gasLimitPreference uint64) uint64 {
+	delta := gasLimitDelta(parentGasLimit)
+	if parentGasLimit < gasLimitPreference {
+		limit := parentGasLimit + delta
+		if limit > gasLimitPreference {
+			return gasLimitPreference
+		}
+		return limit
+	}
+	if parentGasLimit > gasLimitPreference {
+		limit := parentGasLimit - delta
+		if limit < gasLimitPreference {
+			return gasLimitPreference
+		}
+		return limit
+	}
+	return parentGasLimit
+}
+
+func absDiff(x, y uint64) uint64 {
+	if x > y {
+		return x - y
+	}
+	return y - x
+}
+
+// `movesTowardPreference` reports whether `gasLimit` respects the preference of the proposer given the parent's gas limit:
+// either it is the preference or it is strictly closer to the preference than the parent's gas limit while within the
+// bound from the parent's gas limit, extended by `tolerance`, so relays converging on the preference over several blocks
+// by less than the full step of `expectedGasLimit` are not faulted
+func movesTowardPreference(parentGasLimit, gasLimitPreference, gasLimit, tolerance uint64) bool {
+	if gasLimit == gasLimitPreference {
+		return true
+	}
+	if absDiff(gasLimit, parentGasLimit) > gasLimitDelta(parentGasLimit)+tolerance {
+		return false
+	}
+	return absDiff(gasLimit, gasLimitPreference) < absDiff(parentGasLimit, gasLimitPreference)
+}
+
 func (a *Analyzer) validateGasLimit(ctx context.Context, gasLimit uint64, gasLimitPreference uint64, blockNumber uint64) (bool, error) {
 	if gasLimit == gasLimitPreference {
 		return true, nil
@@ -74,14 +280,7 @@ func (a *Analyzer) validateGasLimit(ctx context.Context, gasLimit uint64, gasLim
 		return false, err
 	}
 
-	var expectedBound uint64
-	if gasLimitPreference > gasLimit {
-		expectedBound = parentGasLimit + (parentGasLimit / GasLimitBoundDivisor)
-	} else {
-		expectedBound = parentGasLimit - (parentGasLimit / GasLimitBoundDivisor)
-	}
-
-	return gasLimit == expectedBound, nil
+	return movesTowardPreference(parentGasLimit, gasLimitPreference, gasLimit, a.rules.tolerance(RuleGasLimit)), nil
 }
 
 // borrowed from `flashbots/go-boost-utils`
@@ -95,104 +294,220 @@ func reverse(src []byte) []byte {
 	return dst
 }
 
-func (a *Analyzer) validateBid(ctx context.Context, bidCtx *types.BidContext, bid *types.Bid) (*InvalidBid, error) {
+// `validateBid` runs each category of checks against the bid unless the category is disabled for the relay.
+// Any categories skipped by policy are also returned.
+func (a *Analyzer) validateBid(ctx context.Context, bidCtx *types.BidContext, bid *types.Bid) (*InvalidBid, []string, error) {
 	if bid == nil {
+		return nil, nil, nil
+	}
+
+	checks := []struct {
+		category string
+		validate func(context.Context, *types.BidContext, *types.Bid) (*InvalidBid, error)
+	}{
+		{CategoryConsensusInvalid, a.validateBidConsensus},
+		{CategoryIgnoredPreferences, a.validateBidPreferences},
+	}
+
+	a.faultsLock.Lock()
+	disabledChecks := a.disabledChecks[bidCtx.RelayPublicKey]
+	a.faultsLock.Unlock()
+	var skipped []string
+	for _, check := range checks {
+		if _, ok := disabledChecks[check.category]; ok {
+			skipped = append(skipped, check.category)
+			continue
+		}
+		checkCtx, span := tracing.Tracer().Start(ctx, "validate."+check.category)
+		result, err := check.validate(checkCtx, bidCtx, bid)
+		if result != nil {
+			span.SetAttributes(attribute.String("reason", result.Reason))
+		}
+		span.End()
+		if err != nil || result != nil {
+			return result, skipped, err
+		}
+	}
+	return nil, skipped, nil
+}
+
+func (a *Analyzer) validateBidPreferences(ctx context.Context, bidCtx *types.BidContext, bid *types.Bid) (*InvalidBid, error) {
+	if !a.rules.enabled(RuleGasLimit) {
 		return nil, nil
 	}
 
-	if bidCtx.RelayPublicKey != bid.Message.Pubkey {
-		return &InvalidBid{
-			Reason: "incorrect public key from relay",
-		}, nil
+	registration, err := store.GetLatestValidatorRegistration(ctx, a.store, &bidCtx.ProposerPublicKey)
+	if err != nil {
+		return nil, err
 	}
+	if registration == nil {
+		return nil, nil
+	}
+
+	header := bid.Message.Header
+	gasLimitPreference := registration.Message.GasLimit
 
-	validSignature, err := crypto.VerifySignature(bid.Message, a.consensusClient.SignatureDomainForBuilder(), bid.Message.Pubkey[:], bid.Signature[:])
+	// NOTE: need transaction set for possibility of payment transaction
+	// so we defer analysis of fee recipient until we have the full payload
+
+	valid, err := a.validateGasLimit(ctx, header.GasLimit, gasLimitPreference, header.BlockNumber)
 	if err != nil {
 		return nil, err
 	}
+	if !valid {
+		return a.rules.fault(RuleGasLimit, InvalidBidIgnoredPreferencesType, "invalid gas limit"), nil
+	}
+	return nil, nil
+}
 
-	if !validSignature {
+func (a *Analyzer) validateBidConsensus(ctx context.Context, bidCtx *types.BidContext, bid *types.Bid) (*InvalidBid, error) {
+	if bidCtx.RelayPublicKey != bid.Message.Pubkey {
 		return &InvalidBid{
-			Reason: "invalid signature",
+			Reason: "incorrect public key from relay",
 		}, nil
 	}
 
+	if a.rules.enabled(RuleSignature) {
+		validSignature, err := a.verifier.Verify(ctx, &crypto.SignedMessage{
+			Message:   bid.Message,
+			Domain:    a.consensusClient.SignatureDomainForBuilder(),
+			PublicKey: bid.Message.Pubkey[:],
+			Signature: bid.Signature[:],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !validSignature {
+			return a.rules.fault(RuleSignature, InvalidBidConsensusType, "invalid signature"), nil
+		}
+	}
+
 	header := bid.Message.Header
 
 	if bidCtx.ParentHash != header.ParentHash {
+		if lag := a.staleParentLag(ctx, bidCtx, bid); lag > 0 {
+			return &InvalidBid{
+				Reason:  ReasonStaleParent,
+				HeadLag: lag,
+			}, nil
+		}
 		return &InvalidBid{
 			Reason: "invalid parent hash",
 		}, nil
 	}
 
-	registration, err := store.GetLatestValidatorRegistration(ctx, a.store, &bidCtx.ProposerPublicKey)
-	if err != nil {
-		return nil, err
+	if a.rules.enabled(RuleRandao) {
+		expectedRandomness, err := a.consensusClient.GetRandomnessForProposal(ctx, bidCtx.Slot)
+		if err != nil {
+			return nil, err
+		}
+		if expectedRandomness != header.Random {
+			confirmed := a.confirmWithQuorum(ctx, "random value", func(ctx context.Context, client *consensus.Client) (bool, error) {
+				randomness, err := client.GetRandomnessForProposal(ctx, bidCtx.Slot)
+				return randomness == expectedRandomness, err
+			})
+			if confirmed {
+				return a.rules.fault(RuleRandao, InvalidBidConsensusType, "invalid random value"), nil
+			}
+		}
 	}
-	if registration != nil {
-		gasLimitPreference := registration.Message.GasLimit
-
-		// NOTE: need transaction set for possibility of payment transaction
-		// so we defer analysis of fee recipient until we have the full payload
 
-		valid, err := a.validateGasLimit(ctx, header.GasLimit, gasLimitPreference, header.BlockNumber)
+	if a.rules.enabled(RuleBlockNumber) {
+		expectedBlockNumber, err := a.consensusClient.GetBlockNumberForProposal(ctx, bidCtx.Slot)
 		if err != nil {
 			return nil, err
 		}
-		if !valid {
-			return &InvalidBid{
-				Reason: "invalid gas limit",
-				Type:   InvalidBidIgnoredPreferencesType,
-			}, nil
+		if expectedBlockNumber != header.BlockNumber {
+			confirmed := a.confirmWithQuorum(ctx, "block number", func(ctx context.Context, client *consensus.Client) (bool, error) {
+				blockNumber, err := client.GetBlockNumberForProposal(ctx, bidCtx.Slot)
+				return blockNumber == expectedBlockNumber, err
+			})
+			if confirmed {
+				return a.rules.fault(RuleBlockNumber, InvalidBidConsensusType, "invalid block number"), nil
+			}
 		}
 	}
 
-	expectedRandomness, err := a.consensusClient.GetRandomnessForProposal(bidCtx.Slot)
-	if err != nil {
-		return nil, err
-	}
-	if expectedRandomness != header.Random {
+	if header.GasUsed > header.GasLimit {
 		return &InvalidBid{
-			Reason: "invalid random value",
+			Reason: "gas used is higher than gas limit",
 		}, nil
 	}
 
-	expectedBlockNumber, err := a.consensusClient.GetBlockNumberForProposal(bidCtx.Slot)
-	if err != nil {
-		return nil, err
-	}
-	if expectedBlockNumber != header.BlockNumber {
-		return &InvalidBid{
-			Reason: "invalid block number",
-		}, nil
+	if a.rules.enabled(RuleTimestamp) {
+		expectedTimestamp := uint64(a.clock.SlotInSeconds(bidCtx.Slot))
+		if absDiff(expectedTimestamp, header.Timestamp) > a.rules.tolerance(RuleTimestamp) {
+			return a.rules.fault(RuleTimestamp, InvalidBidConsensusType, "invalid timestamp"), nil
+		}
 	}
 
-	if header.GasUsed > header.GasLimit {
-		return &InvalidBid{
-			Reason: "gas used is higher than gas limit",
-		}, nil
+	if a.rules.enabled(RuleBaseFee) {
+		expectedBaseFee, err := a.consensusClient.GetBaseFeeForProposal(ctx, bidCtx.Slot)
+		if err != nil {
+			return nil, err
+		}
+		baseFee := uint256.NewInt(0)
+		baseFee.SetBytes(reverse(header.BaseFeePerGas[:]))
+		tolerance := a.rules.tolerance(RuleBaseFee)
+		if !withinTolerance(expectedBaseFee, baseFee, tolerance) {
+			confirmed := a.confirmWithQuorum(ctx, "base fee", func(ctx context.Context, client *consensus.Client) (bool, error) {
+				otherBaseFee, err := client.GetBaseFeeForProposal(ctx, bidCtx.Slot)
+				if err != nil {
+					return false, err
+				}
+				return withinTolerance(otherBaseFee, baseFee, tolerance), nil
+			})
+			if confirmed {
+				return a.rules.fault(RuleBaseFee, InvalidBidConsensusType, "invalid base fee"), nil
+			}
+		}
 	}
 
-	expectedTimestamp := a.clock.SlotInSeconds(bidCtx.Slot)
-	if expectedTimestamp != int64(header.Timestamp) {
-		return &InvalidBid{
-			Reason: "invalid timestamp",
-		}, nil
+	return nil, nil
+}
+
+// `withinTolerance` reports whether `x` and `y` differ by at most `tolerance`
+func withinTolerance(x, y *types.Uint256, tolerance uint64) bool {
+	diff := new(uint256.Int)
+	if x.Gt(y) {
+		diff.Sub(x, y)
+	} else {
+		diff.Sub(y, x)
 	}
+	return diff.LtUint64(tolerance + 1)
+}
 
-	expectedBaseFee, err := a.consensusClient.GetBaseFeeForProposal(bidCtx.Slot)
-	if err != nil {
-		return nil, err
+func (a *Analyzer) newBidSample(event *data.BidEvent) *types.BidSample {
+	slotStart := time.Unix(a.clock.SlotInSeconds(event.Context.Slot), 0)
+	sample := &types.BidSample{
+		Context:  *event.Context,
+		Sample:   event.Sample,
+		OffsetMs: event.ReceivedAt.Sub(slotStart).Milliseconds(),
 	}
-	baseFee := uint256.NewInt(0)
-	baseFee.SetBytes(reverse(header.BaseFeePerGas[:]))
-	if !expectedBaseFee.Eq(baseFee) {
-		return &InvalidBid{
-			Reason: "invalid base fee",
-		}, nil
+	if event.Bid != nil {
+		value := event.Bid.Message.Value
+		blockHash := event.Bid.Message.Header.BlockHash
+		sample.Value = &value
+		sample.BlockHash = &blockHash
 	}
+	return sample
+}
 
-	return nil, nil
+// `newBidAnalysis` returns the analysis of the bid with `bidCtx` given the result of its validation
+func (a *Analyzer) newBidAnalysis(bidCtx *types.BidContext, result *InvalidBid, skipped []string) *types.BidAnalysis {
+	analysis := &types.BidAnalysis{
+		Context:         *bidCtx,
+		SkippedByPolicy: skipped,
+		Ruleset:         a.ruleset,
+	}
+	if result != nil {
+		analysis.Category = result.Category()
+		analysis.Reason = result.Reason
+		analysis.Rule = result.Rule
+		analysis.Severity = result.severity()
+	}
+	return analysis
 }
 
 func (a *Analyzer) processBid(ctx context.Context, event *data.BidEvent) {
@@ -201,38 +516,107 @@ func (a *Analyzer) processBid(ctx context.Context, event *data.BidEvent) {
 	bidCtx := event.Context
 	bid := event.Bid
 
-	err := a.store.PutBid(ctx, bidCtx, bid)
+	ctx = trace.ContextWithRemoteSpanContext(ctx, event.SpanContext)
+	ctx, span := tracing.Tracer().Start(ctx, "processBid")
+	defer span.End()
+
+	storeCtx, storeSpan := tracing.Tracer().Start(ctx, "store.putBid")
+	err := a.store.PutBid(storeCtx, bidCtx, bid)
+	storeSpan.End()
 	if err != nil {
 		logger.Warnf("could not store bid: %+v", event)
+		metrics.StoreErrors.WithLabelValues("put_bid").Inc()
 		return
 	}
 
-	result, err := a.validateBid(ctx, bidCtx, bid)
+	err = a.store.PutBidLatency(ctx, bidCtx, event.Latency)
+	if err != nil {
+		logger.Warnf("could not store bid latency: %+v", event)
+		metrics.StoreErrors.WithLabelValues("put_bid_latency").Inc()
+	}
+	sample := a.newBidSample(event)
+	err = a.store.PutBidSample(ctx, sample)
+	if err != nil {
+		logger.Warnf("could not store bid sample: %+v", event)
+		metrics.StoreErrors.WithLabelValues("put_bid_sample").Inc()
+	}
+	a.processBidSample(ctx, sample)
+
+	analysisStart := time.Now()
+	result, skipped, err := a.validateBid(ctx, bidCtx, bid)
+	metrics.AnalysisLatency.Observe(time.Since(analysisStart).Seconds())
 	if err != nil {
 		logger.Warnf("could not validate bid with error %+v: %+v, %+v", err, bidCtx, bid)
 		return
 	}
 
-	// TODO scope faults by coordinate
-	// TODO persist analysis results
+	if bid != nil {
+		analysis := a.newBidAnalysis(bidCtx, result, skipped)
+		storeCtx, storeSpan := tracing.Tracer().Start(ctx, "store.putBidAnalysis")
+		err = a.store.PutBidAnalysis(storeCtx, analysis)
+		storeSpan.End()
+		if err != nil {
+			logger.Warnf("could not store bid analysis: %+v", analysis)
+			metrics.StoreErrors.WithLabelValues("put_bid_analysis").Inc()
+		}
+		a.publishBidAnalysis(analysis)
+	}
+
+	isLate := false
+	// NOTE: later samples are requested later into the slot by the monitor so only the first sample is assessed for lateness
+	if bid != nil && event.Sample == 0 {
+		slotStart := time.Unix(a.clock.SlotInSeconds(bidCtx.Slot), 0)
+		isLate = event.ReceivedAt.Sub(slotStart) > a.config.lateBidDeadline()
+	}
+	missingBids := a.recordBidPresence(bidCtx, bid != nil)
+
 	relayID := bidCtx.RelayPublicKey
 	a.faultsLock.Lock()
-	faults := a.faults[relayID]
+	faults := a.faultStats(relayID, bidCtx.Slot)
+	if faults == nil {
+		// NOTE: the relay was removed while the bid was in flight
+		a.faultsLock.Unlock()
+		return
+	}
 	if bid != nil {
-		faults.Stats.TotalBids += 1
+		metrics.BidsCollected.WithLabelValues(relayID.String()).Inc()
+		faults.TotalBids += 1
+		if len(skipped) > 0 {
+			faults.SkippedByPolicyBids += 1
+		}
+	}
+	if isLate {
+		faults.LateBids += 1
+		metrics.BidFaults.WithLabelValues(relayID.String(), metrics.LateBidCategory).Inc()
+	}
+	for _, relay := range missingBids {
+		if relayFaults := a.faultStats(relay, bidCtx.Slot); relayFaults != nil {
+			relayFaults.NoBids += 1
+			metrics.RelayFaults.WithLabelValues(relay.String(), metrics.NoBidsFault).Inc()
+		}
 	}
 	if result != nil {
 		switch result.Type {
 		case InvalidBidConsensusType:
-			faults.Stats.ConsensusInvalidBids += 1
+			faults.ConsensusInvalidBids += 1
+			if result.Reason == ReasonStaleParent {
+				faults.StaleParentBids += 1
+				faults.HeadLagSlots += uint(result.HeadLag)
+			}
 		case InvalidBidIgnoredPreferencesType:
-			faults.Stats.IgnoredPreferencesBids += 1
+			faults.IgnoredPreferencesBids += 1
 		default:
+			a.faultsLock.Unlock()
 			logger.Warnf("could not interpret bid analysis result: %+v, %+v", event, result)
 			return
 		}
+		metrics.BidFaults.WithLabelValues(relayID.String(), result.Category()).Inc()
+		metrics.BidFaultReasons.WithLabelValues(relayID.String(), result.Category(), result.Reason).Inc()
 	}
 	a.faultsLock.Unlock()
+	if isLate {
+		logger.Debugf("late bid: %+v, %+v", event.ReceivedAt, event)
+	}
 	if result != nil {
 		logger.Debugf("invalid bid: %+v, %+v", result, event)
 	} else {
@@ -240,6 +624,17 @@ func (a *Analyzer) processBid(ctx context.Context, event *data.BidEvent) {
 	}
 }
 
+// `processBidProvenance` stores the raw response of a `getHeader` request
+func (a *Analyzer) processBidProvenance(ctx context.Context, event data.BidProvenanceEvent) {
+	logger := a.logger.Sugar()
+
+	err := a.store.PutBidProvenance(ctx, event.Provenance)
+	if err != nil {
+		logger.Warnw("could not store bid provenance", "error", err, "context", event.Provenance.Context)
+		metrics.StoreErrors.WithLabelValues("put_bid_provenance").Inc()
+	}
+}
+
 // Process incoming validator registrations
 // This data has already been validated by the sender of the event
 func (a *Analyzer) processValidatorRegistration(ctx context.Context, event data.ValidatorRegistrationEvent) {
@@ -251,11 +646,14 @@ func (a *Analyzer) processValidatorRegistration(ctx context.Context, event data.
 		err := a.store.PutValidatorRegistration(ctx, &registration)
 		if err != nil {
 			logger.Warnf("could not store validator registration: %+v", registration)
+			metrics.StoreErrors.WithLabelValues("put_validator_registration").Inc()
 			return
 		}
 	}
 }
 
+// Process incoming auction transcripts
+// The acceptance has already been authenticated by the sender of the event
 func (a *Analyzer) processAuctionTranscript(ctx context.Context, event data.AuctionTranscriptEvent) {
 	logger := a.logger.Sugar()
 
@@ -267,90 +665,218 @@ func (a *Analyzer) processAuctionTranscript(ctx context.Context, event data.Auct
 	signedBlindedBeaconBlock := &transcript.Acceptance
 	blindedBeaconBlock := signedBlindedBeaconBlock.Message
 
-	// Verify signature first, to avoid doing unnecessary work in the event this is a "bad" transcript
 	proposerPublicKey, err := a.consensusClient.GetPublicKeyForIndex(ctx, blindedBeaconBlock.ProposerIndex)
 	if err != nil {
 		logger.Warnw("could not find public key for validator index", "error", err)
 		return
 	}
 
-	domain := a.consensusClient.SignatureDomain(blindedBeaconBlock.Slot)
-	valid, err := crypto.VerifySignature(signedBlindedBeaconBlock.Message, domain, proposerPublicKey[:], signedBlindedBeaconBlock.Signature[:])
-	if err != nil {
-		logger.Warnw("error verifying signature from proposer; could not determine authenticity of transcript", "error", err, "bid", bid, "acceptance", signedBlindedBeaconBlock)
-		return
-	}
-	if !valid {
-		logger.Warnw("signature from proposer was invalid; could not determine authenticity of transcript", "error", err, "bid", bid, "acceptance", signedBlindedBeaconBlock)
-		return
-	}
-
 	bidCtx := &types.BidContext{
 		Slot:              blindedBeaconBlock.Slot,
 		ParentHash:        bid.Header.ParentHash,
 		ProposerPublicKey: *proposerPublicKey,
 		RelayPublicKey:    bid.Pubkey,
 	}
-	existingBid, err := a.store.GetBid(ctx, bidCtx)
+	err = verifyTranscriptHeader(transcript)
 	if err != nil {
-		logger.Warnw("could not find existing bid, will continue full analysis", "context", bidCtx)
+		logger.Warnw("transcript is inconsistent; rejecting invalid transcript", "error", err, "context", bidCtx)
+		metrics.InvalidTranscripts.WithLabelValues(metrics.HeaderMismatchReason).Inc()
+		return
+	}
 
-		// TODO: process bid as well as rest of transcript
+	existingBid, err := a.store.GetBid(ctx, bidCtx)
+	if err != nil {
+		logger.Warnw("could not find existing bid, will continue full analysis", "error", err, "context", bidCtx)
 	}
 
-	if existingBid != nil && *existingBid != transcript.Bid {
-		logger.Warnw("provided bid from transcript did not match existing bid, will continue full analysis", "context", bidCtx)
+	// The monitor only samples the auction, so the accepted bid is analyzed if it was not observed;
+	// signatures over bids are deterministic so a different signature indicates a different bid
+	if existingBid == nil || existingBid.Signature != transcript.Bid.Signature {
+		logger.Debugw("bid from transcript was not observed, analyzing accepted bid", "context", bidCtx)
 
-		// TODO: process bid as well as rest of transcript
+		result, _, err := a.validateBid(ctx, bidCtx, &transcript.Bid)
+		if err != nil {
+			logger.Warnw("could not validate bid from transcript", "error", err, "context", bidCtx)
+		} else {
+			a.recordTranscriptAnalysis(ctx, bidCtx, result)
+		}
+		if existingBid == nil {
+			err = a.store.PutBid(ctx, bidCtx, &transcript.Bid)
+			if err != nil {
+				logger.Warnf("could not store bid from transcript: %+v", event)
+				metrics.StoreErrors.WithLabelValues("put_bid").Inc()
+			}
+		}
 	}
 
-	// TODO also store bid if missing?
 	err = a.store.PutAcceptance(ctx, bidCtx, signedBlindedBeaconBlock)
 	if err != nil {
 		logger.Warnf("could not store bid acceptance data: %+v", event)
+		metrics.StoreErrors.WithLabelValues("put_acceptance").Inc()
 		return
 	}
 
-	// verify later w/ full payload:
-	// (claimed) Value, including fee recipient
-	// expectedFeeRecipient := registration.Message.FeeRecipient
-	// if expectedFeeRecipient != header.FeeRecipient {
-	// 	return &InvalidBid{
-	// 		Reason: "invalid fee recipient",
-	// 		Type:   InvalidBidIgnoredPreferencesType,
-	// 		Context: map[string]interface{}{
-	// 			"expected fee recipient":  expectedFeeRecipient,
-	// 			"fee recipient in header": header.FeeRecipient,
-	// 		},
-	// 	}, nil
-	// }
-
-	// BlockHash
-	// StateRoot
-	// ReceiptsRoot
-	// LogsBloom
-	// TransactionsRoot
-
-	// TODO save analysis results
+	// NOTE: the payload of the accepted bid is verified once its block is canonical, see `recordWinningBids`
+}
 
+func (a *Analyzer) processRegistrationCoverage(ctx context.Context, event data.RegistrationCoverageEvent) {
+	logger := a.logger.Sugar()
+
+	logger.Debugw("received registration coverage", "relay", event.Relay, "epoch", event.Epoch, "monitorRegistrations", event.MonitorRegistrations, "relayRegistrations", event.RelayRegistrations)
+
+	var coverage float64
+	if event.MonitorRegistrations != 0 {
+		coverage = float64(event.RelayRegistrations) / float64(event.MonitorRegistrations)
+	}
+	metrics.RegistrationCoverage.WithLabelValues(event.Relay.String()).Set(coverage)
+
+	a.registrationCoverageLock.Lock()
+	defer a.registrationCoverageLock.Unlock()
+
+	a.registrationCoverage[event.Relay] = &RegistrationCoverage{
+		Epoch:                event.Epoch,
+		MonitorRegistrations: event.MonitorRegistrations,
+		RelayRegistrations:   event.RelayRegistrations,
+		Coverage:             coverage,
+	}
 }
 
-func (a *Analyzer) Run(ctx context.Context) error {
+func (a *Analyzer) processRegistrationPropagation(event data.RegistrationPropagationEvent) {
+	logger := a.logger.Sugar()
+
+	if event.Ignored > 0 {
+		logger.Warnw("relay ignored forwarded validator registrations", "relay", event.Relay, "forwarded", event.Forwarded, "ignored", event.Ignored)
+	}
+
+	a.faultsLock.Lock()
+	defer a.faultsLock.Unlock()
+
+	// NOTE: registrations are not tied to a slot so they are attributed to the slot the propagation was checked in
+	faults := a.faultStats(event.Relay, a.clock.CurrentSlot(time.Now().Unix()))
+	if faults == nil {
+		return
+	}
+	faults.RegistrationsIgnored += event.Ignored
+	metrics.RelayFaults.WithLabelValues(event.Relay.String(), metrics.RegistrationIgnoredFault).Add(float64(event.Ignored))
+}
+
+func (a *Analyzer) processRelayStatus(ctx context.Context, event data.RelayStatusEvent) {
+	logger := a.logger.Sugar()
+
+	err := a.store.PutRelayStatus(ctx, &event.Relay, event.Slot, event.Up)
+	if err != nil {
+		logger.Warnw("could not store relay status", "error", err, "relayPublicKey", event.Relay)
+		metrics.StoreErrors.WithLabelValues("put_relay_status").Inc()
+	}
+}
+
+// Compare the value of the bid observed by the monitor against the value the relay reports
+// for the delivered payload of the same block
+func (a *Analyzer) processDeliveredPayload(ctx context.Context, event data.DeliveredPayloadEvent) {
+	logger := a.logger.Sugar()
+
+	trace := event.BidTrace
+	a.recordDeliveredPayload(event.Relay, trace.Slot)
+	a.processDeliveredBlock(ctx, event.Relay, trace.Slot, trace.BlockHash)
+
+	err := a.store.PutBuilderSubmission(ctx, &trace.BuilderPubkey, &event.Relay, trace.Slot)
+	if err != nil {
+		logger.Warnw("could not store builder submission", "error", err, "bidTrace", trace)
+		metrics.StoreErrors.WithLabelValues("put_builder_submission").Inc()
+	}
+	err = a.store.PutDeliveredPayload(ctx, &types.DeliveredPayload{
+		RelayPublicKey: event.Relay,
+		BidTrace:       *trace,
+	})
+	if err != nil {
+		logger.Warnw("could not store delivered payload", "error", err, "bidTrace", trace)
+		metrics.StoreErrors.WithLabelValues("put_delivered_payload").Inc()
+	}
+
+	bidCtx := &types.BidContext{
+		Slot:              trace.Slot,
+		ParentHash:        trace.ParentHash,
+		ProposerPublicKey: trace.ProposerPubkey,
+		RelayPublicKey:    event.Relay,
+	}
+	bid, err := a.store.GetBid(ctx, bidCtx)
+	if err != nil || bid == nil {
+		logger.Debugw("could not find observed bid for delivered payload", "context", bidCtx, "bidTrace", trace)
+		return
+	}
+
+	// NOTE: the monitor only samples the auction so the observed bid may not be the one delivered;
+	// values are only comparable for the same block
+	if bid.Message.Header.BlockHash != trace.BlockHash {
+		return
+	}
+
+	if bid.Message.Value == trace.Value {
+		return
+	}
+
+	logger.Warnw("value of delivered payload diverges from observed bid", "context", bidCtx, "bidValue", bid.Message.Value.String(), "deliveredValue", trace.Value.String())
+
+	a.faultsLock.Lock()
+	defer a.faultsLock.Unlock()
+
+	faults := a.faultStats(event.Relay, trace.Slot)
+	if faults == nil {
+		return
+	}
+	faults.BidValueDivergences += 1
+	metrics.RelayFaults.WithLabelValues(event.Relay.String(), metrics.BidValueDivergenceFault).Inc()
+}
+
+func (a *Analyzer) processEvent(ctx context.Context, event data.Event) {
 	logger := a.logger.Sugar()
 
+	switch event := event.Payload.(type) {
+	case *data.BidEvent:
+		a.processBid(ctx, event)
+	case data.BidProvenanceEvent:
+		a.processBidProvenance(ctx, event)
+	case data.ValidatorRegistrationEvent:
+		a.processValidatorRegistration(ctx, event)
+	case data.AuctionTranscriptEvent:
+		a.processAuctionTranscript(ctx, event)
+	case data.RegistrationCoverageEvent:
+		a.processRegistrationCoverage(ctx, event)
+	case data.RegistrationPropagationEvent:
+		a.processRegistrationPropagation(event)
+	case data.DeliveredPayloadEvent:
+		a.processDeliveredPayload(ctx, event)
+	case data.UpcomingSlotEvent:
+		a.processUpcomingSlot(event, a.clock.CurrentSlot(time.Now().Unix()))
+	case data.BlockEvent:
+		a.processCanonicalBlock(ctx, event)
+	case data.RelayStatusEvent:
+		a.processRelayStatus(ctx, event)
+	default:
+		logger.Warnf("unknown event type %T for event %+v!", event, event)
+	}
+}
+
+func (a *Analyzer) Run(ctx context.Context) error {
+	a.startWorkers(ctx)
+
+	slots := a.clock.TickSlots(ctx)
 	for {
 		select {
-		case event := <-a.events:
-			switch event := event.Payload.(type) {
-			case *data.BidEvent:
-				a.processBid(ctx, event)
-			case data.ValidatorRegistrationEvent:
-				a.processValidatorRegistration(ctx, event)
-			case data.AuctionTranscriptEvent:
-				a.processAuctionTranscript(ctx, event)
-			default:
-				logger.Warnf("unknown event type %T for event %+v!", event, event)
+		case slot := <-slots:
+			if a.mempool != nil {
+				go a.observeMempool(ctx)
+			}
+			if slot >= missedSlotAttributionDelay {
+				a.attributeMissedSlot(ctx, slot-missedSlotAttributionDelay)
+				a.recordWinningBids(ctx, slot-missedSlotAttributionDelay)
+				if a.config.payloadChecks() {
+					go a.checkPayloads(ctx, slot-missedSlotAttributionDelay)
+				}
 			}
+		case event := <-a.events:
+			metrics.EventChannelDepth.Set(float64(len(a.events)))
+			a.dispatchEvent(ctx, event)
 		case <-ctx.Done():
 			return nil
 		}
@@ -0,0 +1,283 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	"github.com/protolambda/ztyp/view"
+	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+func TestExpectedGasLimit(t *testing.T) {
+	for _, tc := range []struct {
+		parentGasLimit     uint64
+		gasLimitPreference uint64
+		gasLimit           uint64
+	}{
+		// at preference
+		{parentGasLimit: 30_000_000, gasLimitPreference: 30_000_000, gasLimit: 30_000_000},
+		// bounded increase towards preference
+		{parentGasLimit: 30_000_000, gasLimitPreference: 35_000_000, gasLimit: 30_029_295},
+		// bounded decrease towards preference
+		{parentGasLimit: 30_000_000, gasLimitPreference: 25_000_000, gasLimit: 29_970_705},
+		// preference within the bound
+		{parentGasLimit: 30_000_000, gasLimitPreference: 30_010_000, gasLimit: 30_010_000},
+		{parentGasLimit: 30_000_000, gasLimitPreference: 29_990_000, gasLimit: 29_990_000},
+	} {
+		gasLimit := expectedGasLimit(tc.parentGasLimit, tc.gasLimitPreference)
+		if gasLimit != tc.gasLimit {
+			t.Fatalf("wrong gas limit for parent %d and preference %d: %d but expected %d", tc.parentGasLimit, tc.gasLimitPreference, gasLimit, tc.gasLimit)
+		}
+	}
+}
+
+func TestMovesTowardPreference(t *testing.T) {
+	for _, tc := range []struct {
+		parentGasLimit     uint64
+		gasLimitPreference uint64
+		gasLimit           uint64
+		valid              bool
+	}{
+		// at preference, even beyond the bound
+		{parentGasLimit: 30_000_000, gasLimitPreference: 35_000_000, gasLimit: 35_000_000, valid: true},
+		// full step towards preference
+		{parentGasLimit: 30_000_000, gasLimitPreference: 35_000_000, gasLimit: 30_029_295, valid: true},
+		{parentGasLimit: 30_000_000, gasLimitPreference: 25_000_000, gasLimit: 29_970_705, valid: true},
+		// partial step towards preference
+		{parentGasLimit: 30_000_000, gasLimitPreference: 35_000_000, gasLimit: 30_000_001, valid: true},
+		{parentGasLimit: 30_000_000, gasLimitPreference: 25_000_000, gasLimit: 29_990_000, valid: true},
+		// beyond the bound towards preference
+		{parentGasLimit: 30_000_000, gasLimitPreference: 35_000_000, gasLimit: 30_029_296, valid: false},
+		{parentGasLimit: 30_000_000, gasLimitPreference: 25_000_000, gasLimit: 29_970_704, valid: false},
+		// no movement towards preference
+		{parentGasLimit: 30_000_000, gasLimitPreference: 35_000_000, gasLimit: 30_000_000, valid: false},
+		// away from preference
+		{parentGasLimit: 30_000_000, gasLimitPreference: 35_000_000, gasLimit: 29_999_999, valid: false},
+		{parentGasLimit: 30_000_000, gasLimitPreference: 25_000_000, gasLimit: 30_000_001, valid: false},
+		// away from a preference the parent is at
+		{parentGasLimit: 30_000_000, gasLimitPreference: 30_000_000, gasLimit: 30_000_001, valid: false},
+		// overshooting the preference while closer to it
+		{parentGasLimit: 30_000_000, gasLimitPreference: 30_010_000, gasLimit: 30_015_000, valid: true},
+		// overshooting the preference by more than the parent is from it
+		{parentGasLimit: 30_000_000, gasLimitPreference: 30_010_000, gasLimit: 30_020_000, valid: false},
+	} {
+		valid := movesTowardPreference(tc.parentGasLimit, tc.gasLimitPreference, tc.gasLimit, 0)
+		if valid != tc.valid {
+			t.Fatalf("wrong validity for gas limit %d with parent %d and preference %d: %t but expected %t", tc.gasLimit, tc.parentGasLimit, tc.gasLimitPreference, valid, tc.valid)
+		}
+	}
+}
+
+func TestMovesTowardPreferenceConvergence(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		parentGasLimit     uint64
+		gasLimitPreference uint64
+		// gas limit of each block given the gas limit of its parent
+		step func(parentGasLimit, gasLimitPreference uint64) uint64
+	}{
+		{
+			name:               "full steps up",
+			parentGasLimit:     30_000_000,
+			gasLimitPreference: 30_500_000,
+			step:               expectedGasLimit,
+		},
+		{
+			name:               "full steps down",
+			parentGasLimit:     30_000_000,
+			gasLimitPreference: 29_500_000,
+			step:               expectedGasLimit,
+		},
+		{
+			name:               "half steps up",
+			parentGasLimit:     30_000_000,
+			gasLimitPreference: 30_200_000,
+			step: func(parentGasLimit, gasLimitPreference uint64) uint64 {
+				full := expectedGasLimit(parentGasLimit, gasLimitPreference)
+				return parentGasLimit + (full-parentGasLimit+1)/2
+			},
+		},
+		{
+			name:               "half steps down",
+			parentGasLimit:     30_000_000,
+			gasLimitPreference: 29_800_000,
+			step: func(parentGasLimit, gasLimitPreference uint64) uint64 {
+				full := expectedGasLimit(parentGasLimit, gasLimitPreference)
+				return parentGasLimit - (parentGasLimit-full+1)/2
+			},
+		},
+	} {
+		gasLimit := tc.parentGasLimit
+		blocks := 0
+		for gasLimit != tc.gasLimitPreference {
+			next := tc.step(gasLimit, tc.gasLimitPreference)
+			if !movesTowardPreference(gasLimit, tc.gasLimitPreference, next, 0) {
+				t.Fatalf("%s: gas limit %d after %d blocks with parent %d was faulted", tc.name, next, blocks, gasLimit)
+			}
+			gasLimit = next
+			blocks += 1
+			if blocks > 1000 {
+				t.Fatalf("%s: gas limit did not converge on the preference", tc.name)
+			}
+		}
+		if blocks < 2 {
+			t.Fatalf("%s: expected convergence over several blocks but took %d", tc.name, blocks)
+		}
+	}
+}
+
+// `newTestBeaconNode` serves the genesis, the spec and the blocks of the given slots as a consensus client would
+func newTestBeaconNode(t *testing.T, blocks map[types.Slot]*bellatrix.SignedBeaconBlock) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch {
+		case r.URL.Path == "/eth/v1/beacon/genesis":
+			response = map[string]any{"data": map[string]string{
+				"genesis_time":            "0",
+				"genesis_validators_root": types.Root{}.String(),
+				"genesis_fork_version":    "0x00000000",
+			}}
+		case r.URL.Path == "/eth/v1/config/spec":
+			response = map[string]any{"data": map[string]string{
+				"SLOTS_PER_EPOCH":  "32",
+				"SECONDS_PER_SLOT": "12",
+			}}
+		case strings.HasPrefix(r.URL.Path, "/eth/v2/beacon/blocks/"):
+			slot, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/eth/v2/beacon/blocks/"), 10, 64)
+			block, ok := blocks[slot]
+			if err != nil || !ok {
+				http.NotFound(w, r)
+				return
+			}
+			response = map[string]any{"version": "bellatrix", "data": block}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProcessBidIgnoredPreferences(t *testing.T) {
+	parent := &bellatrix.SignedBeaconBlock{}
+	parent.Message.Slot = 99
+	parent.Message.Body.ExecutionPayload.BlockNumber = view.Uint64View(1000)
+	parent.Message.Body.ExecutionPayload.GasLimit = view.Uint64View(30_000_000)
+	beaconNode := newTestBeaconNode(t, map[types.Slot]*bellatrix.SignedBeaconBlock{99: parent})
+
+	ctx := context.Background()
+	consensusClient, err := consensus.NewClient(ctx, beaconNode.URL, zap.NewNop(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = consensusClient.FetchBlock(ctx, 99)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposer := types.PublicKey{0x02}
+	memoryStore := store.NewMemoryStore()
+	err = memoryStore.PutValidatorRegistration(ctx, &types.SignedValidatorRegistration{
+		Message: &boostTypes.RegisterValidatorRequestMessage{Pubkey: proposer, GasLimit: 36_000_000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := consensus.NewClock(0, 12, 32)
+	a := NewAnalyzer(&Config{}, zap.NewNop(), nil, nil, memoryStore, consensusClient, nil, clock, nil)
+	relay := types.PublicKey{0x01}
+	a.faults[relay] = &Faults{Meta: &Meta{Endpoint: "relay.example.com"}}
+	a.faultsByEpoch[relay] = make(map[types.Epoch]*FaultStats)
+	// NOTE: only the preferences of the proposer are checked
+	a.disabledChecks[relay] = map[string]struct{}{CategoryConsensusInvalid: {}}
+
+	for i, gasLimit := range []uint64{
+		// towards the preference within the bound from the parent
+		30_029_295,
+		// away from the preference
+		29_970_705,
+		// beyond the bound from the parent
+		31_000_000,
+	} {
+		slot := types.Slot(100 + i)
+		a.processBid(ctx, &data.BidEvent{
+			Context: &types.BidContext{Slot: slot, RelayPublicKey: relay, ProposerPublicKey: proposer},
+			Bid: &types.Bid{
+				Message: &boostTypes.BuilderBid{
+					Header: &boostTypes.ExecutionPayloadHeader{BlockNumber: 1001, GasLimit: gasLimit},
+				},
+			},
+			ReceivedAt: time.Unix(clock.SlotInSeconds(slot), 0),
+		})
+	}
+
+	stats := a.GetFaults(0, 10)[relay].Stats
+	if stats.TotalBids != 3 {
+		t.Fatalf("expected 3 bids but got %d", stats.TotalBids)
+	}
+	if stats.IgnoredPreferencesBids != 2 {
+		t.Fatalf("expected 2 bids ignoring the gas limit preference but got %d", stats.IgnoredPreferencesBids)
+	}
+}
+
+func TestValidateDisabledChecks(t *testing.T) {
+	if err := ValidateDisabledChecks(nil); err != nil {
+		t.Errorf("expected no disabled checks to be valid but got %v", err)
+	}
+	if err := ValidateDisabledChecks([]string{CategoryConsensusInvalid, CategoryIgnoredPreferences}); err != nil {
+		t.Errorf("expected known checks to be valid but got %v", err)
+	}
+	if err := ValidateDisabledChecks([]string{CategoryConsensusInvalid, "consensus-invalid"}); err == nil {
+		t.Error("expected misspelled check to be rejected")
+	}
+	if err := ValidateDisabledChecks([]string{CategoryPaymentInvalid}); err == nil {
+		t.Error("expected check of accepted bids to be rejected as it can not be disabled")
+	}
+}
+
+// `BenchmarkProcessBid` measures the bookkeeping of the analyzer for each bid,
+// with validation disabled as it depends on a consensus client
+func BenchmarkProcessBid(b *testing.B) {
+	clock := consensus.NewClock(0, 12, 32)
+	a := NewAnalyzer(&Config{}, zap.NewNop(), nil, nil, store.NewMemoryStore(), nil, nil, clock, nil)
+	relay := types.PublicKey{0x01}
+	a.faults[relay] = &Faults{Meta: &Meta{Endpoint: "relay.example.com"}}
+	a.faultsByEpoch[relay] = make(map[types.Epoch]*FaultStats)
+	a.disabledChecks[relay] = map[string]struct{}{
+		CategoryConsensusInvalid:   {},
+		CategoryIgnoredPreferences: {},
+	}
+	bid := &types.Bid{
+		Message: &boostTypes.BuilderBid{
+			Header: &boostTypes.ExecutionPayloadHeader{GasLimit: 30_000_000},
+		},
+	}
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		slot := types.Slot(i)
+		a.processBid(ctx, &data.BidEvent{
+			Context:    &types.BidContext{Slot: slot, RelayPublicKey: relay},
+			Bid:        bid,
+			Latency:    100 * time.Millisecond,
+			ReceivedAt: time.Unix(clock.SlotInSeconds(slot), 0),
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `recordWinningBids` records the accepted bids of `slot` whose block is the canonical block of the slot,
+// verifying the canonical payload against each of them.
+// Missed slots are left to `attributeMissedSlot`.
+func (a *Analyzer) recordWinningBids(ctx context.Context, slot types.Slot) {
+	logger := a.logger.Sugar()
+
+	acceptances, err := a.store.GetAcceptances(ctx, slot)
+	if err != nil {
+		logger.Warnw("could not get acceptances to record winning bids", "error", err, "slot", slot)
+		return
+	}
+	if len(acceptances) == 0 {
+		return
+	}
+
+	missed, err := a.consensusClient.IsSlotMissed(ctx, slot)
+	if err != nil {
+		logger.Warnw("could not determine if slot was missed", "error", err, "slot", slot)
+		return
+	}
+	if missed {
+		return
+	}
+	block, err := a.consensusClient.GetBlock(ctx, slot)
+	if err != nil {
+		logger.Warnw("could not get canonical block to record winning bids", "error", err, "slot", slot)
+		return
+	}
+	canonicalHash := types.Hash(block.Message.Body.ExecutionPayload.BlockHash)
+	payload := toExecutionPayload(&block.Message.Body.ExecutionPayload)
+
+	for _, acceptance := range acceptances {
+		acceptance := acceptance
+		if acceptance.SignedBlindedBeaconBlock.Message.Body.ExecutionPayloadHeader.BlockHash != canonicalHash {
+			continue
+		}
+		a.verifyAcceptedPayload(ctx, &acceptance, payload)
+
+		winningBid := &types.WinningBid{
+			Context: acceptance.Context,
+		}
+		bid, err := a.store.GetBid(ctx, &acceptance.Context)
+		if err != nil {
+			logger.Warnw("could not get bid to record winning bid", "error", err, "context", acceptance.Context)
+		} else if bid != nil {
+			winningBid.Value = bid.Message.Value
+			a.verifyBlockValue(ctx, &acceptance.Context, payload, bid)
+		}
+		err = a.store.PutWinningBid(ctx, winningBid)
+		if err != nil {
+			logger.Warnw("could not store winning bid", "error", err, "context", acceptance.Context)
+			metrics.StoreErrors.WithLabelValues("put_winning_bid").Inc()
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package analysis
+
+import "github.com/ralexstokes/relay-monitor/pkg/types"
+
+// Number of slots to keep bid presence for, to accomodate late arriving events
+const bidPresenceRetentionSlots = 64
+
+// `bidPresence` tracks which relays did and did not offer a bid in a given slot
+type bidPresence struct {
+	bidders map[types.PublicKey]struct{}
+	// relays without a bid, mapped to whether a `no_bid` fault has been recorded
+	absent map[types.PublicKey]bool
+}
+
+// `recordBidPresence` notes whether the relay in `bidCtx` offered a bid and returns
+// the relays that have not yet been charged for lacking a bid in a slot where some other relay offered one
+func (a *Analyzer) recordBidPresence(bidCtx *types.BidContext, hasBid bool) []types.PublicKey {
+	a.bidPresenceLock.Lock()
+	defer a.bidPresenceLock.Unlock()
+
+	slot := bidCtx.Slot
+	presence, ok := a.bidPresence[slot]
+	if !ok {
+		presence = &bidPresence{
+			bidders: make(map[types.PublicKey]struct{}),
+			absent:  make(map[types.PublicKey]bool),
+		}
+		a.bidPresence[slot] = presence
+		a.pruneBidPresence(slot)
+	}
+
+	relay := bidCtx.RelayPublicKey
+	if hasBid {
+		presence.bidders[relay] = struct{}{}
+	} else if _, ok := presence.absent[relay]; !ok {
+		presence.absent[relay] = false
+	}
+
+	if len(presence.bidders) == 0 {
+		return nil
+	}
+
+	var missing []types.PublicKey
+	for relay, charged := range presence.absent {
+		if !charged {
+			presence.absent[relay] = true
+			missing = append(missing, relay)
+		}
+	}
+	return missing
+}
+
+func (a *Analyzer) pruneBidPresence(currentSlot types.Slot) {
+	if currentSlot < bidPresenceRetentionSlots {
+		return
+	}
+	boundary := currentSlot - bidPresenceRetentionSlots
+	for slot := range a.bidPresence {
+		if slot < boundary {
+			delete(a.bidPresence, slot)
+		}
+	}
+}
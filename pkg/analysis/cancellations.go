@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// Number of slots to keep the latest sample of each relay's bid for, to accomodate late arriving samples
+const bidSampleRetentionSlots = 64
+
+// `isCancellation` reports whether `sample` cancels the bid of the `previous` sample of the same relay in the slot,
+// i.e. the relay withdrew its bid or offered a lower value
+func isCancellation(previous, sample *types.BidSample) bool {
+	if previous.Value == nil {
+		return false
+	}
+	if sample.Value == nil {
+		return true
+	}
+	return sample.Value.Cmp(previous.Value) < 0
+}
+
+// `recordBidSample` notes `sample` as the latest sample for its context and returns the sample it follows, if any
+// NOTE: samples without a bid are not kept so that later bids are compared against the last bid offered
+func (a *Analyzer) recordBidSample(sample *types.BidSample) *types.BidSample {
+	a.bidSamplesLock.Lock()
+	defer a.bidSamplesLock.Unlock()
+
+	previous, ok := a.bidSamples[sample.Context]
+	if ok && sample.Sample <= previous.Sample {
+		// NOTE: an earlier sample arriving late is not compared against later ones
+		return nil
+	}
+	if sample.Value != nil {
+		a.bidSamples[sample.Context] = *sample
+	}
+	if !ok {
+		a.pruneBidSamples(sample.Context.Slot)
+		return nil
+	}
+	return &previous
+}
+
+func (a *Analyzer) pruneBidSamples(currentSlot types.Slot) {
+	if currentSlot < bidSampleRetentionSlots {
+		return
+	}
+	boundary := currentSlot - bidSampleRetentionSlots
+	for bidCtx := range a.bidSamples {
+		if bidCtx.Slot < boundary {
+			delete(a.bidSamples, bidCtx)
+		}
+	}
+}
+
+// `processBidSample` records a cancellation if `sample` withdraws or lowers the bid of the relay's previous sample in the slot
+func (a *Analyzer) processBidSample(ctx context.Context, sample *types.BidSample) {
+	logger := a.logger.Sugar()
+
+	previous := a.recordBidSample(sample)
+	if previous == nil || previous.Value == nil {
+		return
+	}
+	cancelled := isCancellation(previous, sample)
+
+	relay := sample.Context.RelayPublicKey
+	a.faultsLock.Lock()
+	faults := a.faultStats(relay, sample.Context.Slot)
+	if faults == nil {
+		a.faultsLock.Unlock()
+		return
+	}
+	faults.ResampledBids += 1
+	if cancelled {
+		faults.Cancellations += 1
+	}
+	a.faultsLock.Unlock()
+
+	if !cancelled {
+		return
+	}
+	metrics.RelayFaults.WithLabelValues(relay.String(), metrics.CancellationFault).Inc()
+	cancellation := &types.BidCancellation{
+		Context:       sample.Context,
+		Sample:        sample.Sample,
+		OffsetMs:      sample.OffsetMs,
+		PreviousValue: *previous.Value,
+		Value:         sample.Value,
+	}
+	logger.Debugw("relay cancelled bid", "cancellation", cancellation)
+	err := a.store.PutBidCancellation(ctx, cancellation)
+	if err != nil {
+		logger.Warnw("could not store bid cancellation", "error", err, "cancellation", cancellation)
+		metrics.StoreErrors.WithLabelValues("put_bid_cancellation").Inc()
+	}
+}
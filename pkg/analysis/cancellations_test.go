@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestIsCancellation(t *testing.T) {
+	low := types.U256Str{1}
+	high := types.U256Str{2}
+	for _, tc := range []struct {
+		previous  *types.U256Str
+		value     *types.U256Str
+		cancelled bool
+	}{
+		{previous: &low, value: &high, cancelled: false},
+		{previous: &low, value: &low, cancelled: false},
+		{previous: &high, value: &low, cancelled: true},
+		{previous: &high, value: nil, cancelled: true},
+		{previous: nil, value: nil, cancelled: false},
+		{previous: nil, value: &low, cancelled: false},
+	} {
+		previous := &types.BidSample{Sample: 0, Value: tc.previous}
+		sample := &types.BidSample{Sample: 1, Value: tc.value}
+		if cancelled := isCancellation(previous, sample); cancelled != tc.cancelled {
+			t.Errorf("expected cancellation %t from %v to %v, got %t", tc.cancelled, tc.previous, tc.value, cancelled)
+		}
+	}
+}
+
+func TestRecordBidSample(t *testing.T) {
+	a := &Analyzer{bidSamples: make(map[types.BidContext]types.BidSample)}
+	bidCtx := types.BidContext{Slot: 100}
+	value := types.U256Str{1}
+
+	if previous := a.recordBidSample(&types.BidSample{Context: bidCtx, Sample: 0, Value: &value}); previous != nil {
+		t.Fatal("expected no previous sample for first sample")
+	}
+	if previous := a.recordBidSample(&types.BidSample{Context: bidCtx, Sample: 2, Value: &value}); previous == nil || previous.Sample != 0 {
+		t.Fatalf("expected sample 2 to follow sample 0, got %+v", previous)
+	}
+	if previous := a.recordBidSample(&types.BidSample{Context: bidCtx, Sample: 1, Value: &value}); previous != nil {
+		t.Fatal("expected late sample to be ignored")
+	}
+}
+
+func TestRecordBidSampleWithoutBid(t *testing.T) {
+	a := &Analyzer{bidSamples: make(map[types.BidContext]types.BidSample)}
+	bidCtx := types.BidContext{Slot: 100}
+	high := types.U256Str{5}
+	low := types.U256Str{3}
+
+	if previous := a.recordBidSample(&types.BidSample{Context: bidCtx, Sample: 0, Value: &high}); previous != nil {
+		t.Fatal("expected no previous sample for first sample")
+	}
+	sample := &types.BidSample{Context: bidCtx, Sample: 1}
+	previous := a.recordBidSample(sample)
+	if previous == nil || !isCancellation(previous, sample) {
+		t.Fatalf("expected withdrawn bid to cancel bid of sample 0, got %+v", previous)
+	}
+	// NOTE: the lower bid is compared against the last bid offered rather than the sample without a bid
+	sample = &types.BidSample{Context: bidCtx, Sample: 2, Value: &low}
+	previous = a.recordBidSample(sample)
+	if previous == nil || previous.Sample != 0 || !isCancellation(previous, sample) {
+		t.Fatalf("expected lower bid to cancel bid of sample 0, got %+v", previous)
+	}
+}
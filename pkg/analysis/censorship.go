@@ -0,0 +1,231 @@
+package analysis
+
+import (
+	"context"
+	"time"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	// Minimum number of delivered blocks before a relay can be flagged as censoring
+	DefaultCensorshipMinBlocks = 32
+	// A relay is flagged as censoring if its inclusion rate of watched transactions
+	// falls below this fraction of the rate observed across all canonical blocks
+	DefaultCensorshipThreshold = 0.5
+)
+
+type CensorshipConfig struct {
+	// Addresses whose transactions (as sender or recipient) are tracked
+	WatchList []types.Address `yaml:"watch_list"`
+	MinBlocks uint            `yaml:"min_blocks"`
+	Threshold float64         `yaml:"threshold"`
+	// Compare delivered blocks against the mempool of the execution client, counting pending transactions which paid
+	// for inclusion but were left out as `censored_transactions` faults
+	Mempool bool `yaml:"mempool"`
+	// How long a transaction must have been pending before a slot to count as left out of its block, `DefaultMempoolMinAge` if unset
+	MempoolMinAge time.Duration `yaml:"mempool_min_age"`
+}
+
+func (c *CensorshipConfig) mempoolMinAge() time.Duration {
+	if c == nil || c.MempoolMinAge == 0 {
+		return DefaultMempoolMinAge
+	}
+	return c.MempoolMinAge
+}
+
+func (c *Config) censorship() *CensorshipConfig {
+	config := &CensorshipConfig{
+		MinBlocks: DefaultCensorshipMinBlocks,
+		Threshold: DefaultCensorshipThreshold,
+	}
+	if c == nil || c.Censorship == nil {
+		return config
+	}
+	config.WatchList = c.Censorship.WatchList
+	config.Mempool = c.Censorship.Mempool
+	config.MempoolMinAge = c.Censorship.MempoolMinAge
+	if c.Censorship.MinBlocks != 0 {
+		config.MinBlocks = c.Censorship.MinBlocks
+	}
+	if c.Censorship.Threshold != 0 {
+		config.Threshold = c.Censorship.Threshold
+	}
+	return config
+}
+
+type CensorshipStats struct {
+	Blocks                        uint    `json:"blocks"`
+	BlocksWithWatchedTransactions uint    `json:"blocks_with_watched_transactions"`
+	InclusionRate                 float64 `json:"inclusion_rate"`
+	// Only set for relays
+	Censoring bool `json:"censoring"`
+}
+
+type CensorshipReport struct {
+	// Statistics across all canonical blocks observed by the monitor
+	Network *CensorshipStats                     `json:"network"`
+	Relays  map[types.PublicKey]*CensorshipStats `json:"relays"`
+}
+
+func (s *CensorshipStats) record(hasWatchedTransaction bool) {
+	s.Blocks += 1
+	if hasWatchedTransaction {
+		s.BlocksWithWatchedTransactions += 1
+	}
+	s.InclusionRate = float64(s.BlocksWithWatchedTransactions) / float64(s.Blocks)
+}
+
+// `containsWatchedTransaction` reports whether any transaction in the payload of the block with `blockNumber` is sent from or to
+// an address in the watch list or, given an execution client, emits a log of or about such an address
+func (a *Analyzer) containsWatchedTransaction(ctx context.Context, transactions common.PayloadTransactions, blockNumber uint64) (bool, error) {
+	for _, encodedTransaction := range transactions {
+		var transaction gethTypes.Transaction
+		err := transaction.UnmarshalBinary(encodedTransaction)
+		if err != nil {
+			return false, err
+		}
+		if to := transaction.To(); to != nil {
+			if _, ok := a.censorshipWatchList[types.Address(*to)]; ok {
+				return true, nil
+			}
+		}
+		signer := gethTypes.LatestSignerForChainID(transaction.ChainId())
+		from, err := gethTypes.Sender(signer, &transaction)
+		if err != nil {
+			return false, err
+		}
+		if _, ok := a.censorshipWatchList[types.Address(from)]; ok {
+			return true, nil
+		}
+	}
+	if a.executionClient == nil || len(transactions) == 0 {
+		return false, nil
+	}
+	receipts, err := a.executionClient.GetBlockReceipts(ctx, blockNumber)
+	if err != nil {
+		return false, err
+	}
+	return a.containsWatchedLog(receipts), nil
+}
+
+// `containsWatchedLog` reports whether any log of `receipts` is emitted by an address in the watch list or has such an address
+// as a topic, like the parties of a token transfer
+func (a *Analyzer) containsWatchedLog(receipts []*gethTypes.Receipt) bool {
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			if _, ok := a.censorshipWatchList[types.Address(log.Address)]; ok {
+				return true
+			}
+			for _, topic := range log.Topics {
+				if !isAddressTopic(topic) {
+					continue
+				}
+				if _, ok := a.censorshipWatchList[types.Address(gethCommon.BytesToAddress(topic.Bytes()))]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// `isAddressTopic` reports whether `topic` could be an indexed address, which is left-padded with zeros
+func isAddressTopic(topic gethCommon.Hash) bool {
+	for _, b := range topic[:gethCommon.HashLength-gethCommon.AddressLength] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// `processCanonicalBlock` updates the network-wide baseline of blocks including watched transactions
+func (a *Analyzer) processCanonicalBlock(ctx context.Context, event data.BlockEvent) {
+	logger := a.logger.Sugar()
+
+	if len(a.censorshipWatchList) == 0 {
+		return
+	}
+
+	block, err := a.consensusClient.GetBlock(ctx, event.Slot)
+	if err != nil {
+		logger.Warnw("could not get canonical block for censorship analysis", "error", err, "slot", event.Slot)
+		return
+	}
+	payload := block.Message.Body.ExecutionPayload
+	found, err := a.containsWatchedTransaction(ctx, payload.Transactions, uint64(payload.BlockNumber))
+	if err != nil {
+		logger.Warnw("could not inspect transactions of canonical block", "error", err, "slot", event.Slot)
+		return
+	}
+
+	a.censorshipLock.Lock()
+	defer a.censorshipLock.Unlock()
+
+	a.censorship.Network.record(found)
+}
+
+// `processDeliveredBlock` inspects the canonical block at `slot` if the relay delivered its payload, for transactions
+// of the watch list and for transactions of the mempool it left out
+func (a *Analyzer) processDeliveredBlock(ctx context.Context, relay types.PublicKey, slot types.Slot, blockHash types.Hash) {
+	logger := a.logger.Sugar()
+
+	if len(a.censorshipWatchList) == 0 && a.mempool == nil {
+		return
+	}
+
+	block, err := a.consensusClient.GetBlock(ctx, slot)
+	if err != nil {
+		logger.Warnw("could not get canonical block for censorship analysis", "error", err, "slot", slot)
+		return
+	}
+	payload := block.Message.Body.ExecutionPayload
+	if types.Hash(payload.BlockHash) != blockHash {
+		// relay delivered a payload that did not become canonical
+		return
+	}
+	if a.mempool != nil {
+		a.recordCensoredTransactions(ctx, relay, slot, &payload)
+	}
+	if len(a.censorshipWatchList) == 0 {
+		return
+	}
+	found, err := a.containsWatchedTransaction(ctx, payload.Transactions, uint64(payload.BlockNumber))
+	if err != nil {
+		logger.Warnw("could not inspect transactions of delivered block", "error", err, "slot", slot, "relay", relay)
+		return
+	}
+
+	a.censorshipLock.Lock()
+	defer a.censorshipLock.Unlock()
+
+	stats, ok := a.censorship.Relays[relay]
+	if !ok {
+		stats = &CensorshipStats{}
+		a.censorship.Relays[relay] = stats
+	}
+	stats.record(found)
+}
+
+func (a *Analyzer) GetCensorshipReport() *CensorshipReport {
+	a.censorshipLock.Lock()
+	defer a.censorshipLock.Unlock()
+
+	config := a.config.censorship()
+	network := *a.censorship.Network
+	report := &CensorshipReport{
+		Network: &network,
+		Relays:  make(map[types.PublicKey]*CensorshipStats),
+	}
+	for relay, stats := range a.censorship.Relays {
+		stats := *stats
+		stats.Censoring = stats.Blocks >= config.MinBlocks && stats.InclusionRate < config.Threshold*network.InclusionRate
+		report.Relays[relay] = &stats
+	}
+	return report
+}
@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ralexstokes/relay-monitor/pkg/execution"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestContainsWatchedLog(t *testing.T) {
+	watched := types.Address{1}
+	a := &Analyzer{
+		censorshipWatchList: map[types.Address]struct{}{watched: {}},
+	}
+	transferTopic := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	receipt := func(logs ...*gethTypes.Log) []*gethTypes.Receipt {
+		return []*gethTypes.Receipt{{Logs: logs}}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		receipts []*gethTypes.Receipt
+		found    bool
+	}{
+		{name: "no logs", receipts: receipt(), found: false},
+		{name: "emitted by watched address", receipts: receipt(&gethTypes.Log{Address: common.Address(watched)}), found: true},
+		{name: "transfer to watched address", receipts: receipt(&gethTypes.Log{
+			Address: common.Address{2},
+			Topics:  []common.Hash{transferTopic, common.BytesToHash(common.Address{3}.Bytes()), common.BytesToHash(common.Address(watched).Bytes())},
+		}), found: true},
+		{name: "transfer between other addresses", receipts: receipt(&gethTypes.Log{
+			Address: common.Address{2},
+			Topics:  []common.Hash{transferTopic, common.BytesToHash(common.Address{3}.Bytes()), common.BytesToHash(common.Address{4}.Bytes())},
+		}), found: false},
+	} {
+		if found := a.containsWatchedLog(tc.receipts); found != tc.found {
+			t.Errorf("%s: expected found %t but got %t", tc.name, tc.found, found)
+		}
+	}
+}
+
+func TestPaidForInclusion(t *testing.T) {
+	baseFee := big.NewInt(10)
+	transaction := func(feeCap, tipCap int64, gas uint64) *gethTypes.Transaction {
+		return gethTypes.NewTx(&gethTypes.DynamicFeeTx{GasFeeCap: big.NewInt(feeCap), GasTipCap: big.NewInt(tipCap), Gas: gas})
+	}
+
+	for _, tc := range []struct {
+		name        string
+		transaction *gethTypes.Transaction
+		minTip      *big.Int
+		gasRoom     uint64
+		paid        bool
+	}{
+		{name: "outbids included transactions", transaction: transaction(20, 5, 21000), minTip: big.NewInt(2), gasRoom: 30000, paid: true},
+		{name: "below base fee", transaction: transaction(9, 5, 21000), minTip: big.NewInt(2), gasRoom: 30000, paid: false},
+		{name: "tip capped by fee cap", transaction: transaction(11, 5, 21000), minTip: big.NewInt(2), gasRoom: 30000, paid: false},
+		{name: "no room left in block", transaction: transaction(20, 5, 21000), minTip: big.NewInt(2), gasRoom: 20000, paid: false},
+		{name: "empty block", transaction: transaction(20, 1, 21000), minTip: nil, gasRoom: 30000, paid: true},
+		{name: "no tip for empty block", transaction: transaction(10, 0, 21000), minTip: nil, gasRoom: 30000, paid: false},
+	} {
+		if paid := paidForInclusion(tc.transaction, baseFee, tc.minTip, tc.gasRoom); paid != tc.paid {
+			t.Errorf("%s: expected paid %t but got %t", tc.name, tc.paid, paid)
+		}
+	}
+}
+
+func TestMempoolPendingAt(t *testing.T) {
+	m := newMempool()
+	start := time.Unix(1000, 0)
+	old := execution.PendingTransaction{Transaction: gethTypes.NewTx(&gethTypes.DynamicFeeTx{Nonce: 1, GasFeeCap: big.NewInt(1)})}
+	recent := execution.PendingTransaction{Transaction: gethTypes.NewTx(&gethTypes.DynamicFeeTx{Nonce: 2, GasFeeCap: big.NewInt(2)})}
+	dropped := execution.PendingTransaction{Transaction: gethTypes.NewTx(&gethTypes.DynamicFeeTx{Nonce: 3, GasFeeCap: big.NewInt(3)})}
+
+	m.observe([]execution.PendingTransaction{old, dropped}, start)
+	m.observe([]execution.PendingTransaction{old, recent}, start.Add(12*time.Second))
+
+	// the slot starting at the second observation, with transactions pending for at least one slot
+	pending := m.pendingAt(start.Add(12*time.Second-DefaultMempoolMinAge), start.Add(12*time.Second))
+	if len(pending) != 1 || pending[0].Transaction.Hash() != old.Transaction.Hash() {
+		t.Fatalf("expected only the transaction pending since the first observation but got %d transactions", len(pending))
+	}
+
+	m.observe(nil, start.Add(12*time.Second+mempoolRetention+time.Second))
+	if len(m.pending) != 0 {
+		t.Fatalf("expected transactions not seen within the retention to be dropped but kept %d", len(m.pending))
+	}
+}
@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const DefaultLateBidDeadline = 3 * time.Second
+
+type Config struct {
+	// Offset into the slot after which a bid is considered late
+	LateBidDeadline time.Duration `yaml:"late_bid_deadline"`
+	// Number of workers processing events concurrently, where events of the same bid context are processed in order by the same worker
+	Workers uint `yaml:"workers"`
+	// Whether to request the payload of each observed acceptance from its relay after the slot,
+	// recording a relay which fails to return it as an unavailable payload
+	PayloadChecks bool `yaml:"payload_checks"`
+	// Tracking of transactions from a watch list in delivered payloads
+	Censorship *CensorshipConfig `yaml:"censorship"`
+	// Flagging of relays which consistently build on stale parents
+	StaleParents *StaleParentConfig `yaml:"stale_parents"`
+	// Rule name -> configuration of the rule, for validation rules whose defaults do not suit the network
+	Rules map[string]*RuleConfig `yaml:"rules"`
+	// Identifier of the rules stamped on each bid analysis, derived from the configuration of `Rules` if unset,
+	// e.g. to version analyses after a fix of the validation itself
+	Ruleset string `yaml:"ruleset"`
+}
+
+func (c *Config) ruleset(rules ruleSet) string {
+	if c == nil || c.Ruleset == "" {
+		return rules.id()
+	}
+	return c.Ruleset
+}
+
+func (c *Config) rules() map[string]*RuleConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Rules
+}
+
+func (c *Config) Validate() error {
+	names := make([]string, 0, len(c.rules()))
+	for name := range c.rules() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		err := c.Rules[name].Validate(name)
+		if err != nil {
+			return fmt.Errorf("invalid rules: %v", err)
+		}
+	}
+	return nil
+}
+
+func (c *Config) lateBidDeadline() time.Duration {
+	if c == nil || c.LateBidDeadline == 0 {
+		return DefaultLateBidDeadline
+	}
+	return c.LateBidDeadline
+}
+
+func (c *Config) payloadChecks() bool {
+	return c != nil && c.PayloadChecks
+}
+
+func (c *Config) workers() uint {
+	if c == nil || c.Workers == 0 {
+		return 1
+	}
+	return c.Workers
+}
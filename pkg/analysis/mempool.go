@@ -0,0 +1,207 @@
+package analysis
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/ralexstokes/relay-monitor/pkg/execution"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	// How long a transaction must have been pending before a slot to count as left out of the block of the slot
+	DefaultMempoolMinAge = 12 * time.Second
+	// How long a transaction is kept after it was last seen in the mempool, so late deliveries of payloads can be checked
+	mempoolRetention = 10 * time.Minute
+	// Most transactions of the mempool checked against each delivered block, bounding the queries of sender nonces
+	maxMempoolCandidates = 64
+)
+
+// `pendingTransaction` is a transaction observed in the mempool of the execution client
+type pendingTransaction struct {
+	execution.PendingTransaction
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// `mempool` keeps the transactions observed in the mempool of the execution client over recent slots
+type mempool struct {
+	lock    sync.Mutex
+	pending map[gethCommon.Hash]*pendingTransaction
+}
+
+func newMempool() *mempool {
+	return &mempool{
+		pending: make(map[gethCommon.Hash]*pendingTransaction),
+	}
+}
+
+// `observe` records `transactions` as pending at `now`, dropping transactions not seen within the retention
+func (m *mempool) observe(transactions []execution.PendingTransaction, now time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, transaction := range transactions {
+		hash := transaction.Transaction.Hash()
+		if pending, ok := m.pending[hash]; ok {
+			pending.lastSeen = now
+			continue
+		}
+		m.pending[hash] = &pendingTransaction{
+			PendingTransaction: transaction,
+			firstSeen:          now,
+			lastSeen:           now,
+		}
+	}
+	for hash, pending := range m.pending {
+		if now.Sub(pending.lastSeen) > mempoolRetention {
+			delete(m.pending, hash)
+		}
+	}
+}
+
+// `pendingAt` returns the transactions first seen before `seenBefore` and still pending at `pendingAt`,
+// ordered by decreasing fee cap
+func (m *mempool) pendingAt(seenBefore, pendingAt time.Time) []*pendingTransaction {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var transactions []*pendingTransaction
+	for _, pending := range m.pending {
+		if pending.firstSeen.After(seenBefore) || pending.lastSeen.Before(pendingAt) {
+			continue
+		}
+		transactions = append(transactions, pending)
+	}
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].Transaction.GasFeeCapCmp(transactions[j].Transaction) > 0
+	})
+	return transactions
+}
+
+// `paidForInclusion` reports whether `transaction` paid enough to be included in a block with `baseFee` and `gasRoom` gas left
+// unused, where `minTip` is the lowest effective tip of the transactions included in the block or `nil` if the block is empty
+func paidForInclusion(transaction *gethTypes.Transaction, baseFee, minTip *big.Int, gasRoom uint64) bool {
+	if transaction.GasFeeCapIntCmp(baseFee) < 0 || transaction.Gas() > gasRoom {
+		return false
+	}
+	tip := transaction.EffectiveGasTipValue(baseFee)
+	if minTip == nil {
+		return tip.Sign() > 0
+	}
+	return tip.Cmp(minTip) >= 0
+}
+
+// `senderNonce` identifies a transaction by its sender and nonce, which a replacement of the transaction shares
+type senderNonce struct {
+	from  types.Address
+	nonce uint64
+}
+
+// `findCensoredTransactions` returns the transactions pending in the mempool since at least `DefaultMempoolMinAge` before `slot`
+// which paid enough to be included in `payload`, could be executed after its parent and were left out of it
+func (a *Analyzer) findCensoredTransactions(ctx context.Context, slot types.Slot, payload *common.ExecutionPayload) ([]*pendingTransaction, error) {
+	baseFee := (*uint256.Int)(&payload.BaseFeePerGas).ToBig()
+	included := make(map[gethCommon.Hash]struct{}, len(payload.Transactions))
+	includedNonces := make(map[senderNonce]struct{}, len(payload.Transactions))
+	var minTip *big.Int
+	for _, encodedTransaction := range payload.Transactions {
+		var transaction gethTypes.Transaction
+		err := transaction.UnmarshalBinary(encodedTransaction)
+		if err != nil {
+			return nil, err
+		}
+		included[transaction.Hash()] = struct{}{}
+		signer := gethTypes.LatestSignerForChainID(transaction.ChainId())
+		from, err := gethTypes.Sender(signer, &transaction)
+		if err != nil {
+			return nil, err
+		}
+		includedNonces[senderNonce{from: types.Address(from), nonce: transaction.Nonce()}] = struct{}{}
+		tip := transaction.EffectiveGasTipValue(baseFee)
+		if minTip == nil || tip.Cmp(minTip) < 0 {
+			minTip = tip
+		}
+	}
+	gasRoom := uint64(payload.GasLimit) - uint64(payload.GasUsed)
+
+	slotStart := time.Unix(a.clock.SlotInSeconds(slot), 0)
+	var censored []*pendingTransaction
+	checked := 0
+	for _, pending := range a.mempool.pendingAt(slotStart.Add(-a.config.censorship().mempoolMinAge()), slotStart) {
+		if checked == maxMempoolCandidates {
+			break
+		}
+		transaction := pending.Transaction
+		if _, ok := included[transaction.Hash()]; ok {
+			continue
+		}
+		if _, ok := includedNonces[senderNonce{from: pending.From, nonce: transaction.Nonce()}]; ok {
+			// a replacement of the transaction was included
+			continue
+		}
+		if !paidForInclusion(transaction, baseFee, minTip, gasRoom) {
+			continue
+		}
+		checked += 1
+		// NOTE: only transactions executable after the parent block could have been included
+		nonce, err := a.executionClient.GetNonce(ctx, pending.From, uint64(payload.BlockNumber)-1)
+		if err != nil {
+			return nil, err
+		}
+		if nonce == transaction.Nonce() {
+			censored = append(censored, pending)
+		}
+	}
+	return censored, nil
+}
+
+// `recordCensoredTransactions` counts the transactions left out of the canonical block the relay delivered for `slot`
+// in the censorship faults of the relay
+func (a *Analyzer) recordCensoredTransactions(ctx context.Context, relay types.PublicKey, slot types.Slot, payload *common.ExecutionPayload) {
+	logger := a.logger.Sugar()
+
+	censored, err := a.findCensoredTransactions(ctx, slot, payload)
+	if err != nil {
+		logger.Warnw("could not compare delivered block against the mempool", "error", err, "slot", slot, "relay", relay)
+		return
+	}
+	if len(censored) == 0 {
+		return
+	}
+	hashes := make([]string, 0, len(censored))
+	for _, pending := range censored {
+		hashes = append(hashes, pending.Transaction.Hash().String())
+	}
+	logger.Warnw("delivered block left out pending transactions which paid for inclusion", "slot", slot, "relay", relay, "transactions", hashes)
+
+	a.faultsLock.Lock()
+	defer a.faultsLock.Unlock()
+
+	faults := a.faultStats(relay, slot)
+	if faults == nil {
+		return
+	}
+	faults.CensoredTransactions += uint(len(censored))
+	metrics.RelayFaults.WithLabelValues(relay.String(), metrics.CensoredTransactionFault).Add(float64(len(censored)))
+}
+
+// `observeMempool` records the pending transactions of the mempool of the execution client
+func (a *Analyzer) observeMempool(ctx context.Context) {
+	logger := a.logger.Sugar()
+
+	transactions, err := a.executionClient.GetPendingTransactions(ctx)
+	if err != nil {
+		logger.Warnw("could not observe mempool", "error", err)
+		return
+	}
+	a.mempool.observe(transactions, time.Now())
+}
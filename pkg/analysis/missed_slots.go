@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	// Number of slots to wait before attributing a missed slot, so that relays have reported their delivered payloads
+	missedSlotAttributionDelay = 2
+	// Number of slots to keep delivered payload reports for
+	deliveredPayloadsRetentionSlots = 64
+)
+
+func (a *Analyzer) recordDeliveredPayload(relay types.PublicKey, slot types.Slot) {
+	a.deliveredPayloadsLock.Lock()
+	defer a.deliveredPayloadsLock.Unlock()
+
+	relays, ok := a.deliveredPayloads[slot]
+	if !ok {
+		relays = make(map[types.PublicKey]struct{})
+		a.deliveredPayloads[slot] = relays
+
+		if slot >= deliveredPayloadsRetentionSlots {
+			boundary := slot - deliveredPayloadsRetentionSlots
+			for otherSlot := range a.deliveredPayloads {
+				if otherSlot < boundary {
+					delete(a.deliveredPayloads, otherSlot)
+				}
+			}
+		}
+	}
+	relays[relay] = struct{}{}
+}
+
+func (a *Analyzer) hasDeliveredPayload(relay types.PublicKey, slot types.Slot) bool {
+	a.deliveredPayloadsLock.Lock()
+	defer a.deliveredPayloadsLock.Unlock()
+
+	_, ok := a.deliveredPayloads[slot][relay]
+	return ok
+}
+
+// `attributeMissedSlot` checks if the proposer of `slot`, registered with the monitor, missed their slot after accepting a bid
+// and attributes the missed slot to the relay of the accepted bid.
+// If the relay also did not report delivery of the payload, it is recorded as an unavailable payload,
+// unless payloads are checked directly with `checkPayloads`.
+func (a *Analyzer) attributeMissedSlot(ctx context.Context, slot types.Slot) {
+	logger := a.logger.Sugar()
+
+	proposer, err := a.consensusClient.GetProposerPublicKey(ctx, slot)
+	if err != nil {
+		logger.Debugw("could not get proposer to attribute missed slot", "error", err, "slot", slot)
+		return
+	}
+	registration, err := store.GetLatestValidatorRegistration(ctx, a.store, proposer)
+	if err != nil {
+		logger.Warnw("could not get registration to attribute missed slot", "error", err, "slot", slot)
+		return
+	}
+	if registration == nil {
+		return
+	}
+
+	missed, err := a.consensusClient.IsSlotMissed(ctx, slot)
+	if err != nil {
+		logger.Warnw("could not determine if slot was missed", "error", err, "slot", slot)
+		return
+	}
+	if !missed {
+		return
+	}
+
+	acceptances, err := a.store.GetAcceptances(ctx, slot)
+	if err != nil {
+		logger.Warnw("could not get acceptances to attribute missed slot", "error", err, "slot", slot)
+		return
+	}
+
+	a.faultsLock.Lock()
+	defer a.faultsLock.Unlock()
+
+	for _, acceptance := range acceptances {
+		if acceptance.Context.ProposerPublicKey != *proposer {
+			continue
+		}
+		relay := acceptance.Context.RelayPublicKey
+		faults := a.faultStats(relay, slot)
+		if faults == nil {
+			continue
+		}
+		faults.MissedSlots += 1
+		metrics.RelayFaults.WithLabelValues(relay.String(), metrics.MissedSlotFault).Inc()
+		if !a.hasDeliveredPayload(relay, slot) {
+			if a.config.payloadChecks() {
+				logger.Warnw("relay did not report delivery of payload for accepted bid in missed slot", "slot", slot, "relay", relay, "proposer", proposer)
+				continue
+			}
+			faults.UnavailablePayloads += 1
+			metrics.RelayFaults.WithLabelValues(relay.String(), metrics.UnavailablePayloadFault).Inc()
+			logger.Warnw("relay did not deliver payload for accepted bid in missed slot", "slot", slot, "relay", relay, "proposer", proposer)
+		} else {
+			logger.Warnw("slot missed after relay reported delivery of payload for accepted bid", "slot", slot, "relay", relay, "proposer", proposer)
+		}
+	}
+}
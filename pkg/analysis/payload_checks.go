@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `verifyPayload` checks that `payload` is the execution payload committed to by the header of `signedBlindedBeaconBlock`
+func verifyPayload(payload *types.ExecutionPayload, signedBlindedBeaconBlock *types.SignedBlindedBeaconBlock) error {
+	block := signedBlindedBeaconBlock.Message
+	if block == nil || block.Body == nil || block.Body.ExecutionPayloadHeader == nil {
+		return fmt.Errorf("acceptance has no execution payload header")
+	}
+	header := block.Body.ExecutionPayloadHeader
+	if payload.BlockHash != header.BlockHash {
+		return fmt.Errorf("block hash of payload %s does not match accepted header %s", payload.BlockHash, header.BlockHash)
+	}
+	if payload.BlockNumber != header.BlockNumber {
+		return fmt.Errorf("block number of payload %d does not match accepted header %d", payload.BlockNumber, header.BlockNumber)
+	}
+	if payload.StateRoot != header.StateRoot {
+		return fmt.Errorf("state root of payload does not match accepted header")
+	}
+	if payload.ReceiptsRoot != header.ReceiptsRoot {
+		return fmt.Errorf("receipts root of payload does not match accepted header")
+	}
+	if payload.LogsBloom != header.LogsBloom {
+		return fmt.Errorf("logs bloom of payload does not match accepted header")
+	}
+	payloadHeader, err := boostTypes.PayloadToPayloadHeader(payload)
+	if err != nil {
+		return err
+	}
+	if payloadHeader.TransactionsRoot != header.TransactionsRoot {
+		return fmt.Errorf("transactions root of payload does not match accepted header")
+	}
+	return nil
+}
+
+// `checkPayloads` exercises `getPayload` with each acceptance observed for `slot` and
+// records an unavailable payload for each relay which fails to return the payload of the accepted bid
+func (a *Analyzer) checkPayloads(ctx context.Context, slot types.Slot) {
+	logger := a.logger.Sugar()
+
+	acceptances, err := a.store.GetAcceptances(ctx, slot)
+	if err != nil {
+		logger.Warnw("could not get acceptances to check payloads", "error", err, "slot", slot)
+		return
+	}
+
+	for _, acceptance := range acceptances {
+		relayPublicKey := acceptance.Context.RelayPublicKey
+
+		a.faultsLock.Lock()
+		relay, ok := a.relays[relayPublicKey]
+		a.faultsLock.Unlock()
+		if !ok {
+			continue
+		}
+
+		payload, err := relay.GetPayload(&acceptance.SignedBlindedBeaconBlock)
+		if err == nil {
+			err = verifyPayload(payload, &acceptance.SignedBlindedBeaconBlock)
+		}
+		if err == nil {
+			continue
+		}
+
+		logger.Warnw("relay did not return payload for accepted bid", "error", err, "slot", slot, "relay", relayPublicKey, "proposer", acceptance.Context.ProposerPublicKey)
+
+		a.faultsLock.Lock()
+		faults := a.faultStats(relayPublicKey, slot)
+		if faults != nil {
+			faults.UnavailablePayloads += 1
+			metrics.RelayFaults.WithLabelValues(relayPublicKey.String(), metrics.UnavailablePayloadFault).Inc()
+		}
+		a.faultsLock.Unlock()
+	}
+}
@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestVerifyPayload(t *testing.T) {
+	header, err := boostTypes.PayloadToPayloadHeader(&types.ExecutionPayload{BlockNumber: 1000, BlockHash: types.Hash{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptance := &types.SignedBlindedBeaconBlock{
+		Message: &boostTypes.BlindedBeaconBlock{
+			Body: &boostTypes.BlindedBeaconBlockBody{ExecutionPayloadHeader: header},
+		},
+	}
+
+	for _, tc := range []struct {
+		payload types.ExecutionPayload
+		valid   bool
+	}{
+		{payload: types.ExecutionPayload{BlockNumber: 1000, BlockHash: types.Hash{1}}, valid: true},
+		{payload: types.ExecutionPayload{BlockNumber: 1000, BlockHash: types.Hash{2}}, valid: false},
+		{payload: types.ExecutionPayload{BlockNumber: 1001, BlockHash: types.Hash{1}}, valid: false},
+		{payload: types.ExecutionPayload{BlockNumber: 1000, BlockHash: types.Hash{1}, StateRoot: types.Root{1}}, valid: false},
+		{payload: types.ExecutionPayload{BlockNumber: 1000, BlockHash: types.Hash{1}, Transactions: []hexutil.Bytes{{1}}}, valid: false},
+	} {
+		err := verifyPayload(&tc.payload, acceptance)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("expected payload %+v to be valid %t, got error %v", tc.payload, tc.valid, err)
+		}
+	}
+
+	if err := verifyPayload(&types.ExecutionPayload{}, &types.SignedBlindedBeaconBlock{}); err == nil {
+		t.Error("expected error for acceptance without execution payload header")
+	}
+}
@@ -0,0 +1,27 @@
+package analysis
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+)
+
+// `confirmWithQuorum` reports whether every quorum client agrees with a value expected by the primary consensus client,
+// where `agrees` reports whether the given client computes the same value.
+// If no quorum clients are configured, the view of the primary client is accepted as is.
+func (a *Analyzer) confirmWithQuorum(ctx context.Context, description string, agrees func(context.Context, *consensus.Client) (bool, error)) bool {
+	logger := a.logger.Sugar()
+
+	for i, client := range a.quorumClients {
+		ok, err := agrees(ctx, client)
+		if err != nil {
+			logger.Warnw("could not confirm expected value with quorum consensus client", "error", err, "value", description, "client", i)
+			return false
+		}
+		if !ok {
+			logger.Warnw("quorum consensus client disagrees with expected value", "value", description, "client", i)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `Reanalysis` is the outcome of validating the stored bids of a span of slots again
+type Reanalysis struct {
+	// Identifier of the rules the bids were validated with
+	Ruleset string `json:"ruleset"`
+	// Number of bids analyzed again
+	Analyzed int `json:"analyzed"`
+	// Number of analyzed bids whose category or reason differs from their previous analysis
+	Changed int `json:"changed"`
+	// Number of bids which could not be validated, e.g. as the consensus client no longer has the state of their slot
+	Failed int `json:"failed"`
+}
+
+// `isChanged` reports whether `analysis` reaches a different verdict than the `previous` analysis of the bid, if any
+func isChanged(previous, analysis *types.BidAnalysis) bool {
+	if previous == nil {
+		return true
+	}
+	return previous.Category != analysis.Category || previous.Reason != analysis.Reason
+}
+
+// `Reanalyze` validates the bids stored for the inclusive slot range again with the current rules, storing an analysis
+// of each bid under the ruleset of the analyzer alongside the analyses by previous rulesets.
+// NOTE: fault stats count the faults found as bids are collected and do not reflect the new analyses
+func (a *Analyzer) Reanalyze(ctx context.Context, startSlot, endSlot types.Slot) (*Reanalysis, error) {
+	logger := a.logger.Sugar()
+
+	bids, err := a.store.GetBids(ctx, &types.BidQuery{StartSlot: startSlot, EndSlot: endSlot})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Reanalysis{Ruleset: a.ruleset}
+	for _, archivedBid := range bids {
+		err := ctx.Err()
+		if err != nil {
+			return result, err
+		}
+
+		bidCtx := archivedBid.Context
+		invalidBid, skipped, err := a.validateBid(ctx, &bidCtx, archivedBid.Bid)
+		if err != nil {
+			logger.Warnw("could not reanalyze bid", "error", err, "context", bidCtx)
+			result.Failed += 1
+			continue
+		}
+		analysis := a.newBidAnalysis(&bidCtx, invalidBid, skipped)
+		err = a.store.PutBidAnalysis(ctx, analysis)
+		if err != nil {
+			return result, err
+		}
+		result.Analyzed += 1
+		if isChanged(archivedBid.Analysis, analysis) {
+			result.Changed += 1
+		}
+	}
+	logger.Infow("reanalyzed bids", "start_slot", startSlot, "end_slot", endSlot, "ruleset", result.Ruleset, "analyzed", result.Analyzed, "changed", result.Changed, "failed", result.Failed)
+	return result, nil
+}
@@ -0,0 +1,15 @@
+package analysis
+
+import "github.com/ralexstokes/relay-monitor/pkg/types"
+
+type RegistrationCoverageRecord = map[types.PublicKey]*RegistrationCoverage
+
+type RegistrationCoverage struct {
+	Epoch types.Epoch `json:"epoch,string"`
+	// Number of distinct validators registered with the monitor
+	MonitorRegistrations uint `json:"monitor_registrations"`
+	// Number of those validators the relay reports a registration for
+	RelayRegistrations uint `json:"relay_registrations"`
+	// Fraction of validators registered with the monitor which are also known to the relay
+	Coverage float64 `json:"coverage"`
+}
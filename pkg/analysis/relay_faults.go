@@ -14,13 +14,98 @@ type FaultStats struct {
 
 	ConsensusInvalidBids   uint `json:"consensus_invalid_bids"`
 	IgnoredPreferencesBids uint `json:"ignored_preferences_bids"`
+	// Count of the consensus invalid bids built on a canonical block older than the parent of their slot
+	StaleParentBids uint `json:"stale_parent_bids"`
+	// Total slots the blocks stale parent bids were built on lag behind the parent of their slot
+	HeadLagSlots uint `json:"head_lag_slots"`
+	// Share of bids built on a stale parent and the average lag of those bids in slots, derived from the counts above
+	StaleParentRate float64 `json:"stale_parent_rate"`
+	AverageHeadLag  float64 `json:"average_head_lag"`
+	// Whether the relay consistently builds on stale parents, as configured with `analysis.stale_parents`
+	StaleHead bool `json:"stale_head"`
+	// Count of bids where some category of analysis was disabled for the relay by policy
+	SkippedByPolicyBids uint `json:"skipped_by_policy_bids"`
+
+	// Count of bids received after the configured deadline into the slot
+	LateBids uint `json:"late_bids"`
+	// Count of slots where the relay had no bid while some other relay offered one
+	NoBids uint `json:"no_bids"`
 
 	PaymentInvalidBids       uint `json:"payment_invalid_bids"`
 	MalformedPayloads        uint `json:"malformed_payloads"`
 	ConsensusInvalidPayloads uint `json:"consensus_invalid_payloads"`
 	UnavailablePayloads      uint `json:"unavailable_payloads"`
+	// Count of winning bids whose canonical block delivered materially less value to the proposer than the bid claimed
+	ValueOverstatedBids uint `json:"value_overstated_bids"`
+
+	// Count of validator registrations forwarded by the monitor which the relay failed to make available
+	RegistrationsIgnored uint `json:"registration_ignored"`
+
+	// Count of slots missed by a proposer registered with the monitor after accepting a bid from the relay
+	MissedSlots uint `json:"missed_slots"`
+
+	// Count of delivered payloads where the value reported by the relay's Data API
+	// differs from the value of the observed bid for the same block
+	BidValueDivergences uint `json:"bid_value_divergences"`
+
+	// Count of transactions pending in the mempool before a slot which paid enough to be included in the canonical block
+	// the relay delivered for the slot but were left out of it
+	CensoredTransactions uint `json:"censored_transactions"`
+
+	// Count of samples of a relay's bids in a slot following a sample with a bid, when sampling multiple times per slot
+	ResampledBids uint `json:"resampled_bids"`
+	// Count of resampled bids where the relay withdrew its bid or offered a lower value
+	Cancellations uint `json:"cancellations"`
+	// Share of resampled bids which were cancellations, derived from the counts above
+	CancellationRate float64 `json:"cancellation_rate"`
+}
+
+// `add` accumulates the counts of `other` into `s`
+func (s *FaultStats) add(other *FaultStats) {
+	s.TotalBids += other.TotalBids
+	s.ConsensusInvalidBids += other.ConsensusInvalidBids
+	s.IgnoredPreferencesBids += other.IgnoredPreferencesBids
+	s.StaleParentBids += other.StaleParentBids
+	s.HeadLagSlots += other.HeadLagSlots
+	s.SkippedByPolicyBids += other.SkippedByPolicyBids
+	s.LateBids += other.LateBids
+	s.NoBids += other.NoBids
+	s.PaymentInvalidBids += other.PaymentInvalidBids
+	s.MalformedPayloads += other.MalformedPayloads
+	s.ConsensusInvalidPayloads += other.ConsensusInvalidPayloads
+	s.UnavailablePayloads += other.UnavailablePayloads
+	s.ValueOverstatedBids += other.ValueOverstatedBids
+	s.RegistrationsIgnored += other.RegistrationsIgnored
+	s.MissedSlots += other.MissedSlots
+	s.BidValueDivergences += other.BidValueDivergences
+	s.CensoredTransactions += other.CensoredTransactions
+	s.ResampledBids += other.ResampledBids
+	s.Cancellations += other.Cancellations
+}
+
+// `sumFaultStats` totals the fault stats of each epoch in the inclusive epoch range
+func sumFaultStats(faultsByEpoch map[types.Epoch]*FaultStats, start, end types.Epoch) *FaultStats {
+	total := &FaultStats{}
+	for epoch, stats := range faultsByEpoch {
+		if epoch < start || epoch > end {
+			continue
+		}
+		total.add(stats)
+	}
+	if total.ResampledBids > 0 {
+		total.CancellationRate = float64(total.Cancellations) / float64(total.ResampledBids)
+	}
+	if total.TotalBids > 0 {
+		total.StaleParentRate = float64(total.StaleParentBids) / float64(total.TotalBids)
+	}
+	if total.StaleParentBids > 0 {
+		total.AverageHeadLag = float64(total.HeadLagSlots) / float64(total.StaleParentBids)
+	}
+	return total
 }
 
 type Meta struct {
 	Endpoint string `json:"endpoint"`
+	// Configured description of the operator of the relay
+	types.RelayMetadata
 }
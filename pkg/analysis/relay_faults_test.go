@@ -0,0 +1,57 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestSumFaultStats(t *testing.T) {
+	faultsByEpoch := map[types.Epoch]*FaultStats{
+		9:  {TotalBids: 1, LateBids: 1},
+		10: {TotalBids: 32, ConsensusInvalidBids: 2, NoBids: 1},
+		11: {TotalBids: 30, MissedSlots: 1, UnavailablePayloads: 1},
+		12: {TotalBids: 32, BidValueDivergences: 1, ResampledBids: 4, Cancellations: 1},
+	}
+
+	stats := sumFaultStats(faultsByEpoch, 10, 11)
+	expected := FaultStats{
+		TotalBids:            62,
+		ConsensusInvalidBids: 2,
+		NoBids:               1,
+		MissedSlots:          1,
+		UnavailablePayloads:  1,
+	}
+	if *stats != expected {
+		t.Fatalf("expected %+v, got %+v", expected, *stats)
+	}
+
+	stats = sumFaultStats(faultsByEpoch, 12, 12)
+	if stats.CancellationRate != 0.25 {
+		t.Fatalf("expected cancellation rate 0.25, got %f", stats.CancellationRate)
+	}
+
+	stats = sumFaultStats(faultsByEpoch, 20, 30)
+	if *stats != (FaultStats{}) {
+		t.Fatalf("expected no faults outside of recorded epochs, got %+v", *stats)
+	}
+}
+
+func TestStaleHead(t *testing.T) {
+	faultsByEpoch := map[types.Epoch]*FaultStats{
+		10: {TotalBids: 20, ConsensusInvalidBids: 2, StaleParentBids: 2, HeadLagSlots: 6},
+		11: {TotalBids: 20, ConsensusInvalidBids: 3, StaleParentBids: 2, HeadLagSlots: 2},
+	}
+	config := (&Config{}).staleParents()
+
+	stats := sumFaultStats(faultsByEpoch, 10, 11)
+	if stats.StaleParentRate != 0.1 || stats.AverageHeadLag != 2 {
+		t.Fatalf("expected stale parent rate 0.1 and average head lag 2, got %f and %f", stats.StaleParentRate, stats.AverageHeadLag)
+	}
+	if !config.isStale(stats) {
+		t.Error("expected relay building on stale parents to be flagged")
+	}
+	if stats := sumFaultStats(faultsByEpoch, 10, 10); config.isStale(stats) {
+		t.Errorf("expected relay with fewer than %d bids not to be flagged", config.MinBids)
+	}
+}
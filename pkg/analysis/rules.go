@@ -0,0 +1,155 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Rules of bid validation which can be configured
+const (
+	// The bid is signed by the relay's public key
+	RuleSignature = "signature"
+	// The bid has the `prev_randao` of its parent
+	RuleRandao = "randao"
+	// The bid builds on the block number of its parent
+	RuleBlockNumber = "block_number"
+	// The bid has the timestamp of its slot
+	RuleTimestamp = "timestamp"
+	// The bid has the base fee following from its parent
+	RuleBaseFee = "base_fee"
+	// The bid respects the gas limit preference of the proposer
+	RuleGasLimit = "gas_limit"
+	// The payload of an accepted bid pays the fee recipient of the proposer
+	RuleFeeRecipient = "fee_recipient"
+	// The canonical block of a winning bid delivers the value of the bid to the fee recipient of the proposer
+	RuleBlockValue = "block_value"
+)
+
+// Version of the validation of bids, bumped with each change to the checks so analyses by different checks
+// are given different rulesets
+const ValidationVersion = 2
+
+// Severities of the faults of a rule, which weigh the faults in the category-weighted score
+const (
+	SeverityCritical = "critical"
+	SeverityMajor    = "major"
+	SeverityMinor    = "minor"
+)
+
+var ruleNames = []string{
+	RuleSignature,
+	RuleRandao,
+	RuleBlockNumber,
+	RuleTimestamp,
+	RuleBaseFee,
+	RuleGasLimit,
+	RuleFeeRecipient,
+	RuleBlockValue,
+}
+
+// rules which accept a `tolerance`
+var tolerantRules = map[string]struct{}{
+	RuleTimestamp:  {},
+	RuleBaseFee:    {},
+	RuleGasLimit:   {},
+	RuleBlockValue: {},
+}
+
+// `RuleConfig` tunes a rule of bid validation, where unset values take their defaults
+type RuleConfig struct {
+	// Whether the rule is checked, `true` by default
+	Enabled *bool `yaml:"enabled"`
+	// Severity of the faults of the rule: `critical` (default), `major` or `minor`
+	Severity string `yaml:"severity"`
+	// Deviation from the expected value accepted by the rule: seconds for `timestamp`, wei for `base_fee`
+	// and `block_value`, and gas beyond the per-block bound for `gas_limit`
+	Tolerance uint64 `yaml:"tolerance"`
+}
+
+func isRule(name string) bool {
+	for _, rule := range ruleNames {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *RuleConfig) Validate(name string) error {
+	if !isRule(name) {
+		return fmt.Errorf("unknown validation rule %s", name)
+	}
+	if c == nil {
+		return nil
+	}
+	switch c.Severity {
+	case "", SeverityCritical, SeverityMajor, SeverityMinor:
+	default:
+		return fmt.Errorf("unknown severity %s of validation rule %s", c.Severity, name)
+	}
+	if _, ok := tolerantRules[name]; !ok && c.Tolerance != 0 {
+		return fmt.Errorf("validation rule %s does not accept a tolerance", name)
+	}
+	return nil
+}
+
+// `rule` is the resolved configuration of a rule of bid validation
+// NOTE: the zero value is an enabled rule, so rules missing from a `ruleSet` are checked
+type rule struct {
+	disabled  bool
+	severity  string
+	tolerance uint64
+}
+
+// `ruleSet` resolves the configuration of each rule of bid validation against the defaults
+type ruleSet map[string]rule
+
+func newRuleSet(configs map[string]*RuleConfig) ruleSet {
+	rules := make(ruleSet)
+	for _, name := range ruleNames {
+		r := rule{
+			severity: SeverityCritical,
+		}
+		if config, ok := configs[name]; ok && config != nil {
+			if config.Enabled != nil {
+				r.disabled = !*config.Enabled
+			}
+			if config.Severity != "" {
+				r.severity = config.Severity
+			}
+			r.tolerance = config.Tolerance
+		}
+		rules[name] = r
+	}
+	return rules
+}
+
+// `id` identifies the resolved configuration of the rules, so analyses by the same rules share an identifier
+func (s ruleSet) id() string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d;", ValidationVersion)
+	for _, name := range ruleNames {
+		r := s[name]
+		fmt.Fprintf(hash, "%s:%t:%s:%d;", name, r.disabled, r.severity, r.tolerance)
+	}
+	return "rules-" + hex.EncodeToString(hash.Sum(nil))[:12]
+}
+
+func (s ruleSet) enabled(name string) bool {
+	return !s[name].disabled
+}
+
+func (s ruleSet) tolerance(name string) uint64 {
+	return s[name].tolerance
+}
+
+// `fault` returns a fault of the rule with `name` for the given reason
+func (s ruleSet) fault(name string, faultType uint, reason string) *InvalidBid {
+	return &InvalidBid{
+		Reason:   reason,
+		Type:     faultType,
+		Rule:     name,
+		Severity: s[name].severity,
+	}
+}
@@ -0,0 +1,70 @@
+package analysis
+
+import "testing"
+
+func TestRuleSet(t *testing.T) {
+	disabled := false
+	rules := newRuleSet(map[string]*RuleConfig{
+		RuleRandao:    {Enabled: &disabled},
+		RuleGasLimit:  {Severity: SeverityMinor, Tolerance: 100},
+		RuleTimestamp: nil,
+	})
+	if rules.enabled(RuleRandao) {
+		t.Fatal("expected randao rule to be disabled")
+	}
+	if !rules.enabled(RuleSignature) || !rules.enabled(RuleTimestamp) || !rules.enabled(RuleGasLimit) {
+		t.Fatal("expected rules to be enabled by default")
+	}
+	if rules.tolerance(RuleGasLimit) != 100 || rules.tolerance(RuleTimestamp) != 0 {
+		t.Fatal("wrong tolerance of rules")
+	}
+	fault := rules.fault(RuleGasLimit, InvalidBidIgnoredPreferencesType, "invalid gas limit")
+	if fault.Rule != RuleGasLimit || fault.severity() != SeverityMinor {
+		t.Fatalf("wrong fault of gas limit rule: %+v", fault)
+	}
+	fault = rules.fault(RuleSignature, InvalidBidConsensusType, "invalid signature")
+	if fault.severity() != SeverityCritical {
+		t.Fatalf("expected faults to be critical by default, got %s", fault.severity())
+	}
+
+	// NOTE: rules missing from the set, e.g. of an analyzer built without a config, are enabled
+	if !(ruleSet(nil)).enabled(RuleSignature) {
+		t.Fatal("expected rules missing from the set to be enabled")
+	}
+}
+
+func TestRuleConfigValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config *RuleConfig
+		valid  bool
+	}{
+		{name: RuleSignature, config: nil, valid: true},
+		{name: RuleBaseFee, config: &RuleConfig{Severity: SeverityMajor, Tolerance: 1}, valid: true},
+		{name: "unknown", config: &RuleConfig{}, valid: false},
+		{name: RuleSignature, config: &RuleConfig{Severity: "fatal"}, valid: false},
+		{name: RuleSignature, config: &RuleConfig{Tolerance: 1}, valid: false},
+	} {
+		err := tc.config.Validate(tc.name)
+		if (err == nil) != tc.valid {
+			t.Fatalf("wrong validation of rule %s with config %+v: %v", tc.name, tc.config, err)
+		}
+	}
+}
+
+func TestRuleSetID(t *testing.T) {
+	defaults := newRuleSet(nil)
+	if id := defaults.id(); id != newRuleSet(map[string]*RuleConfig{RuleRandao: {}}).id() {
+		t.Fatalf("expected rules resolving to the defaults to share identifier %s", id)
+	}
+	tuned := newRuleSet(map[string]*RuleConfig{RuleGasLimit: {Tolerance: 100}})
+	if tuned.id() == defaults.id() {
+		t.Fatal("expected tuned rules to have a different identifier")
+	}
+	if ruleset := (&Config{Ruleset: "v2"}).ruleset(defaults); ruleset != "v2" {
+		t.Fatalf("expected configured ruleset, got %s", ruleset)
+	}
+	if ruleset := (*Config)(nil).ruleset(defaults); ruleset != defaults.id() {
+		t.Fatalf("expected derived ruleset %s, got %s", defaults.id(), ruleset)
+	}
+}
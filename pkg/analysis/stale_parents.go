@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// Reason of the analysis of a bid built on a canonical block older than the parent of its slot
+const ReasonStaleParent = "stale_parent"
+
+const (
+	// Minimum number of bids before a relay can be flagged as building on stale parents
+	DefaultStaleParentMinBids = 32
+	// A relay is flagged as building on stale parents if at least this share of its bids have a stale parent
+	DefaultStaleParentThreshold = 0.1
+)
+
+type StaleParentConfig struct {
+	MinBids   uint    `yaml:"min_bids"`
+	Threshold float64 `yaml:"threshold"`
+}
+
+func (c *Config) staleParents() *StaleParentConfig {
+	config := &StaleParentConfig{
+		MinBids:   DefaultStaleParentMinBids,
+		Threshold: DefaultStaleParentThreshold,
+	}
+	if c == nil || c.StaleParents == nil {
+		return config
+	}
+	if c.StaleParents.MinBids != 0 {
+		config.MinBids = c.StaleParents.MinBids
+	}
+	if c.StaleParents.Threshold != 0 {
+		config.Threshold = c.StaleParents.Threshold
+	}
+	return config
+}
+
+// `isStale` reports whether the relay with `stats` consistently builds on stale parents
+func (c *StaleParentConfig) isStale(stats *FaultStats) bool {
+	return stats.TotalBids >= c.MinBids && stats.StaleParentBids > 0 && stats.StaleParentRate >= c.Threshold
+}
+
+// `staleParentLag` returns the number of slots the canonical block `bid` was built on lags behind the parent of its slot,
+// or 0 if the parent of the bid is not an earlier canonical block
+func (a *Analyzer) staleParentLag(ctx context.Context, bidCtx *types.BidContext, bid *types.Bid) uint64 {
+	header := bid.Message.Header
+	if header.BlockNumber == 0 || bidCtx.Slot == 0 {
+		return 0
+	}
+	headSlot, headHash, err := a.consensusClient.GetCanonicalBlockByNumber(ctx, header.BlockNumber-1)
+	if err != nil || headHash != header.ParentHash {
+		// NOTE: a parent unknown to the monitor is either not canonical or too old to be indexed
+		return 0
+	}
+	parentSlot := bidCtx.Slot - 1
+	if headSlot >= parentSlot {
+		return 0
+	}
+	return parentSlot - headSlot
+}
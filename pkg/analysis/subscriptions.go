@@ -0,0 +1,44 @@
+package analysis
+
+import "github.com/ralexstokes/relay-monitor/pkg/types"
+
+// Number of analyses buffered for each subscriber before further analyses are dropped for it
+const subscriptionBufferSize = 256
+
+// `SubscribeBidAnalyses` returns a feed of bid analyses as they are made and a function to cancel the subscription.
+// Analyses are dropped for a subscriber which falls behind the feed so that analysis is never blocked on consumers.
+func (a *Analyzer) SubscribeBidAnalyses() (<-chan types.BidAnalysis, func()) {
+	a.subscriptionsLock.Lock()
+	defer a.subscriptionsLock.Unlock()
+
+	id := a.nextSubscriptionID
+	a.nextSubscriptionID += 1
+	feed := make(chan types.BidAnalysis, subscriptionBufferSize)
+	a.subscriptions[id] = feed
+
+	cancel := func() {
+		a.subscriptionsLock.Lock()
+		defer a.subscriptionsLock.Unlock()
+
+		if feed, ok := a.subscriptions[id]; ok {
+			delete(a.subscriptions, id)
+			close(feed)
+		}
+	}
+	return feed, cancel
+}
+
+func (a *Analyzer) publishBidAnalysis(analysis *types.BidAnalysis) {
+	logger := a.logger.Sugar()
+
+	a.subscriptionsLock.Lock()
+	defer a.subscriptionsLock.Unlock()
+
+	for id, feed := range a.subscriptions {
+		select {
+		case feed <- *analysis:
+		default:
+			logger.Debugw("dropping bid analysis for slow subscriber", "subscription", id, "context", analysis.Context)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/holiman/uint256"
+	"github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `toExecutionPayload` converts the execution payload of a canonical block into the type used by the Builder API
+func toExecutionPayload(p *common.ExecutionPayload) *types.ExecutionPayload {
+	payload := &types.ExecutionPayload{
+		ParentHash:   types.Hash(p.ParentHash),
+		FeeRecipient: types.Address(p.FeeRecipient),
+		StateRoot:    types.Root(p.StateRoot),
+		ReceiptsRoot: types.Root(p.ReceiptsRoot),
+		LogsBloom:    boostTypes.Bloom(p.LogsBloom),
+		Random:       types.Hash(p.PrevRandao),
+		BlockNumber:  uint64(p.BlockNumber),
+		GasLimit:     uint64(p.GasLimit),
+		GasUsed:      uint64(p.GasUsed),
+		Timestamp:    uint64(p.Timestamp),
+		ExtraData:    boostTypes.ExtraData(p.ExtraData),
+		BlockHash:    types.Hash(p.BlockHash),
+	}
+	baseFee := uint256.Int(p.BaseFeePerGas)
+	// NOTE: a `uint256.Int` always fits the 32 bytes of the value
+	_ = payload.BaseFeePerGas.FromBig(baseFee.ToBig())
+	for _, transaction := range p.Transactions {
+		payload.Transactions = append(payload.Transactions, hexutil.Bytes(transaction))
+	}
+	return payload
+}
+
+// `verifyPayment` checks that `payload` pays `value` to the proposer's `feeRecipient`, either as the fee recipient
+// of the block or with a transfer in the last transaction of the block
+func verifyPayment(payload *types.ExecutionPayload, feeRecipient types.Address, value *types.U256Str) error {
+	// NOTE: the claimed value of a block paying the proposer directly cannot be verified without execution state
+	if payload.FeeRecipient == feeRecipient {
+		return nil
+	}
+	if len(payload.Transactions) == 0 {
+		return fmt.Errorf("payload has no payment transaction to fee recipient %s", feeRecipient)
+	}
+	var transaction gethTypes.Transaction
+	err := transaction.UnmarshalBinary(payload.Transactions[len(payload.Transactions)-1])
+	if err != nil {
+		return fmt.Errorf("could not decode payment transaction: %w", err)
+	}
+	if to := transaction.To(); to == nil || types.Address(*to) != feeRecipient {
+		return fmt.Errorf("last transaction of payload is not a payment to fee recipient %s", feeRecipient)
+	}
+	if transaction.Value().Cmp(value.BigInt()) < 0 {
+		return fmt.Errorf("payment of %s is less than the value of the bid %s", transaction.Value(), value.String())
+	}
+	return nil
+}
+
+// `verifyPaymentReceipt` checks that the payment transaction of a block, as given by `verifyPayment`, was executed successfully
+func verifyPaymentReceipt(receipt *gethTypes.Receipt) error {
+	if receipt.Status != gethTypes.ReceiptStatusSuccessful {
+		return fmt.Errorf("payment transaction %s to fee recipient reverted", receipt.TxHash)
+	}
+	return nil
+}
+
+// `verifyExecutedPayment` checks with the execution client that the payment transaction of `payload` did not revert,
+// if the payload does not pay `feeRecipient` directly
+func (a *Analyzer) verifyExecutedPayment(ctx context.Context, payload *types.ExecutionPayload, feeRecipient types.Address) error {
+	logger := a.logger.Sugar()
+
+	if a.executionClient == nil || payload.FeeRecipient == feeRecipient {
+		return nil
+	}
+	var transaction gethTypes.Transaction
+	err := transaction.UnmarshalBinary(payload.Transactions[len(payload.Transactions)-1])
+	if err != nil {
+		return fmt.Errorf("could not decode payment transaction: %w", err)
+	}
+	receipt, err := a.executionClient.GetReceipt(ctx, types.Hash(transaction.Hash()))
+	if err != nil {
+		// NOTE: an unavailable receipt is not a fault of the relay
+		logger.Warnw("could not get receipt of payment transaction", "error", err, "block_number", payload.BlockNumber)
+		return nil
+	}
+	return verifyPaymentReceipt(receipt)
+}
+
+// `verifyDeliveredValue` checks that the value `delivered` to the proposer by a block is at least the value of its bid,
+// less `tolerance` wei
+func verifyDeliveredValue(delivered *big.Int, value *types.U256Str, tolerance uint64) error {
+	shortfall := new(big.Int).Sub(value.BigInt(), delivered)
+	if shortfall.Cmp(new(big.Int).SetUint64(tolerance)) > 0 {
+		return fmt.Errorf("block delivered %s to the fee recipient but the bid claimed a value of %s", delivered, value.String())
+	}
+	return nil
+}
+
+// `spentBy` returns the value transferred and the fees paid by the transactions of `payload` sent by `sender`,
+// where `receipt` returns the receipt of each such transaction
+func spentBy(payload *types.ExecutionPayload, sender types.Address, receipt func(*gethTypes.Transaction) (*gethTypes.Receipt, error)) (*big.Int, error) {
+	baseFee := payload.BaseFeePerGas.BigInt()
+	spent := new(big.Int)
+	for _, encodedTransaction := range payload.Transactions {
+		var transaction gethTypes.Transaction
+		err := transaction.UnmarshalBinary(encodedTransaction)
+		if err != nil {
+			return nil, err
+		}
+		signer := gethTypes.LatestSignerForChainID(transaction.ChainId())
+		from, err := gethTypes.Sender(signer, &transaction)
+		if err != nil {
+			return nil, err
+		}
+		if types.Address(from) != sender {
+			continue
+		}
+		transactionReceipt, err := receipt(&transaction)
+		if err != nil {
+			return nil, err
+		}
+		if transactionReceipt.Status == gethTypes.ReceiptStatusSuccessful {
+			spent.Add(spent, transaction.Value())
+		}
+		gasPrice := new(big.Int).Add(baseFee, transaction.EffectiveGasTipValue(baseFee))
+		spent.Add(spent, gasPrice.Mul(gasPrice, new(big.Int).SetUint64(transactionReceipt.GasUsed)))
+	}
+	return spent, nil
+}
+
+// `deliveredValue` returns the value `payload` delivered to the proposer's `feeRecipient`, the change in its balance given by
+// `balanceDelta` over the block. If the block pays the proposer directly, the value and fees of any transactions the fee recipient
+// sent in the block, where `receipt` returns the receipt of each such transaction, are added back.
+// NOTE: the block of a builder paying the proposer with a transfer may deliver more than the builder earns if the bid is subsidized,
+// so only the balance of the proposer's fee recipient is measured
+func deliveredValue(payload *types.ExecutionPayload, feeRecipient types.Address, balanceDelta func(types.Address) (*big.Int, error), receipt func(*gethTypes.Transaction) (*gethTypes.Receipt, error)) (*big.Int, error) {
+	delivered, err := balanceDelta(feeRecipient)
+	if err != nil {
+		return nil, err
+	}
+	if payload.FeeRecipient != feeRecipient {
+		return delivered, nil
+	}
+	spent, err := spentBy(payload, feeRecipient, receipt)
+	if err != nil {
+		return nil, err
+	}
+	return delivered.Add(delivered, spent), nil
+}
+
+// `verifyBlockValue` checks the value the canonical block of a winning bid delivered to the registered fee recipient of the proposer,
+// as given by `deliveredValue`, against the value claimed by the bid.
+// Only faults are recorded as transcript analyses, as the payload of the bid is verified by `verifyAcceptedPayload`.
+func (a *Analyzer) verifyBlockValue(ctx context.Context, bidCtx *types.BidContext, payload *types.ExecutionPayload, bid *types.Bid) {
+	logger := a.logger.Sugar()
+
+	if a.executionClient == nil || !a.rules.enabled(RuleBlockValue) {
+		return
+	}
+	registration, err := store.GetLatestValidatorRegistration(ctx, a.store, &bidCtx.ProposerPublicKey)
+	if err != nil || registration == nil {
+		logger.Debugw("could not find registration to verify value delivered by winning block", "error", err, "context", bidCtx)
+		return
+	}
+	delivered, err := deliveredValue(payload, registration.Message.FeeRecipient, func(address types.Address) (*big.Int, error) {
+		return a.executionClient.GetBalanceDelta(ctx, address, payload.BlockNumber)
+	}, func(transaction *gethTypes.Transaction) (*gethTypes.Receipt, error) {
+		return a.executionClient.GetReceipt(ctx, types.Hash(transaction.Hash()))
+	})
+	if err != nil {
+		logger.Warnw("could not get value delivered by winning block", "error", err, "context", bidCtx, "block_number", payload.BlockNumber)
+		return
+	}
+	err = verifyDeliveredValue(delivered, &bid.Message.Value, a.rules.tolerance(RuleBlockValue))
+	if err != nil {
+		a.recordTranscriptAnalysis(ctx, bidCtx, a.rules.fault(RuleBlockValue, InvalidBidValueOverstatedType, err.Error()))
+	}
+}
+
+// `verifyTranscriptHeader` checks that the proposer accepted the header of the bid in the transcript
+func verifyTranscriptHeader(transcript *types.AuctionTranscript) error {
+	block := transcript.Acceptance.Message
+	if block == nil || block.Body == nil || block.Body.ExecutionPayloadHeader == nil {
+		return fmt.Errorf("acceptance has no execution payload header")
+	}
+	if transcript.Bid.Message == nil || transcript.Bid.Message.Header == nil {
+		return fmt.Errorf("bid has no execution payload header")
+	}
+	accepted, err := block.Body.ExecutionPayloadHeader.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	offered, err := transcript.Bid.Message.Header.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if accepted != offered {
+		return fmt.Errorf("accepted header does not match header of bid")
+	}
+	return nil
+}
+
+// `verifyAcceptedPayload` checks the payload of an accepted bid against the accepted header and the payment
+// expected by the proposer, recording the result as a transcript analysis
+func (a *Analyzer) verifyAcceptedPayload(ctx context.Context, acceptance *types.Acceptance, payload *types.ExecutionPayload) {
+	logger := a.logger.Sugar()
+
+	bidCtx := &acceptance.Context
+	err := verifyPayload(payload, &acceptance.SignedBlindedBeaconBlock)
+	if err != nil {
+		a.recordTranscriptAnalysis(ctx, bidCtx, &InvalidBid{
+			Reason: err.Error(),
+			Type:   InvalidBidMalformedPayloadType,
+		})
+		return
+	}
+
+	if !a.rules.enabled(RuleFeeRecipient) {
+		a.recordTranscriptAnalysis(ctx, bidCtx, nil)
+		return
+	}
+	bid, err := a.store.GetBid(ctx, bidCtx)
+	if err != nil || bid == nil {
+		logger.Debugw("could not find accepted bid to verify payment", "error", err, "context", bidCtx)
+		return
+	}
+	registration, err := store.GetLatestValidatorRegistration(ctx, a.store, &bidCtx.ProposerPublicKey)
+	if err != nil || registration == nil {
+		logger.Debugw("could not find registration to verify payment", "error", err, "context", bidCtx)
+		return
+	}
+	err = verifyPayment(payload, registration.Message.FeeRecipient, &bid.Message.Value)
+	if err == nil {
+		err = a.verifyExecutedPayment(ctx, payload, registration.Message.FeeRecipient)
+	}
+	if err != nil {
+		a.recordTranscriptAnalysis(ctx, bidCtx, a.rules.fault(RuleFeeRecipient, InvalidBidPaymentType, err.Error()))
+		return
+	}
+	a.recordTranscriptAnalysis(ctx, bidCtx, nil)
+}
+
+// `recordTranscriptAnalysis` stores the analysis of an accepted bid derived from its auction transcript,
+// where a `nil` `result` indicates a valid bid, and counts any fault against the relay
+func (a *Analyzer) recordTranscriptAnalysis(ctx context.Context, bidCtx *types.BidContext, result *InvalidBid) {
+	logger := a.logger.Sugar()
+
+	analysis := a.newBidAnalysis(bidCtx, result, nil)
+	err := a.store.PutTranscriptAnalysis(ctx, analysis)
+	if err != nil {
+		logger.Warnf("could not store transcript analysis: %+v", analysis)
+		metrics.StoreErrors.WithLabelValues("put_transcript_analysis").Inc()
+	}
+	if result == nil {
+		return
+	}
+
+	logger.Warnw("invalid accepted bid", "context", bidCtx, "category", analysis.Category, "reason", analysis.Reason)
+
+	relay := bidCtx.RelayPublicKey
+	a.faultsLock.Lock()
+	defer a.faultsLock.Unlock()
+
+	faults := a.faultStats(relay, bidCtx.Slot)
+	if faults == nil {
+		return
+	}
+	switch result.Type {
+	case InvalidBidConsensusType:
+		faults.ConsensusInvalidBids += 1
+	case InvalidBidIgnoredPreferencesType:
+		faults.IgnoredPreferencesBids += 1
+	case InvalidBidMalformedPayloadType:
+		faults.MalformedPayloads += 1
+	case InvalidBidPaymentType:
+		faults.PaymentInvalidBids += 1
+	case InvalidBidValueOverstatedType:
+		faults.ValueOverstatedBids += 1
+	default:
+		return
+	}
+	metrics.BidFaults.WithLabelValues(relay.String(), result.Category()).Inc()
+	metrics.BidFaultReasons.WithLabelValues(relay.String(), result.Category(), result.Reason).Inc()
+}
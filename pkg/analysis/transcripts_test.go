@@ -0,0 +1,234 @@
+package analysis
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestVerifyPayment(t *testing.T) {
+	feeRecipient := types.Address{1}
+	var value types.U256Str
+	if err := value.FromBig(big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	payment := func(to types.Address, amount int64) hexutil.Bytes {
+		recipient := common.Address(to)
+		transaction := gethTypes.NewTx(&gethTypes.LegacyTx{To: &recipient, Value: big.NewInt(amount)})
+		encoded, err := transaction.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return encoded
+	}
+
+	for _, tc := range []struct {
+		name    string
+		payload types.ExecutionPayload
+		valid   bool
+	}{
+		{name: "direct payment", payload: types.ExecutionPayload{FeeRecipient: feeRecipient}, valid: true},
+		{name: "payment transaction", payload: types.ExecutionPayload{Transactions: []hexutil.Bytes{payment(feeRecipient, 100)}}, valid: true},
+		{name: "no transactions", payload: types.ExecutionPayload{}, valid: false},
+		{name: "underpayment", payload: types.ExecutionPayload{Transactions: []hexutil.Bytes{payment(feeRecipient, 99)}}, valid: false},
+		{name: "wrong recipient", payload: types.ExecutionPayload{Transactions: []hexutil.Bytes{payment(types.Address{2}, 100)}}, valid: false},
+		{name: "payment not last", payload: types.ExecutionPayload{Transactions: []hexutil.Bytes{payment(feeRecipient, 100), payment(types.Address{2}, 1)}}, valid: false},
+	} {
+		err := verifyPayment(&tc.payload, feeRecipient, &value)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("%s: expected valid %t, got error %v", tc.name, tc.valid, err)
+		}
+	}
+}
+
+func TestVerifyPaymentReceipt(t *testing.T) {
+	if err := verifyPaymentReceipt(&gethTypes.Receipt{Status: gethTypes.ReceiptStatusSuccessful}); err != nil {
+		t.Errorf("expected a successful payment to be valid but got %v", err)
+	}
+	if err := verifyPaymentReceipt(&gethTypes.Receipt{Status: gethTypes.ReceiptStatusFailed}); err == nil {
+		t.Error("expected a reverted payment to be invalid")
+	}
+}
+
+func TestVerifyDeliveredValue(t *testing.T) {
+	var value types.U256Str
+	if err := value.FromBig(big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		delivered int64
+		tolerance uint64
+		valid     bool
+	}{
+		{name: "exact value", delivered: 100, valid: true},
+		{name: "more than claimed", delivered: 150, valid: true},
+		{name: "shortfall", delivered: 99, valid: false},
+		{name: "shortfall within tolerance", delivered: 90, tolerance: 10, valid: true},
+		{name: "shortfall beyond tolerance", delivered: 89, tolerance: 10, valid: false},
+		{name: "balance decreased", delivered: -1, tolerance: 10, valid: false},
+	} {
+		err := verifyDeliveredValue(big.NewInt(tc.delivered), &value, tc.tolerance)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("%s: expected valid %t, got error %v", tc.name, tc.valid, err)
+		}
+	}
+}
+
+// `signedTransfer` is an encoded transfer of `value` from `key` to `to`, paying 2 wei per gas above a base fee of up to 18 wei
+func signedTransfer(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, to common.Address, value int64) hexutil.Bytes {
+	chainID := big.NewInt(1)
+	transaction, err := gethTypes.SignNewTx(key, gethTypes.LatestSignerForChainID(chainID), &gethTypes.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(20),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(value),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := transaction.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+func TestSpentBy(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	feeRecipient := types.Address(crypto.PubkeyToAddress(key.PublicKey))
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := &types.ExecutionPayload{
+		FeeRecipient: feeRecipient,
+		Transactions: []hexutil.Bytes{
+			signedTransfer(t, key, 0, common.Address{0xaa}, 1000),
+			signedTransfer(t, otherKey, 0, common.Address{0xaa}, 5000),
+			signedTransfer(t, key, 1, common.Address{0xaa}, 300),
+		},
+	}
+	if err := payload.BaseFeePerGas.FromBig(big.NewInt(10)); err != nil {
+		t.Fatal(err)
+	}
+	var reverted gethTypes.Transaction
+	if err := reverted.UnmarshalBinary(payload.Transactions[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	spent, err := spentBy(payload, feeRecipient, func(transaction *gethTypes.Transaction) (*gethTypes.Receipt, error) {
+		status := gethTypes.ReceiptStatusSuccessful
+		if transaction.Hash() == reverted.Hash() {
+			status = gethTypes.ReceiptStatusFailed
+		}
+		return &gethTypes.Receipt{Status: status, GasUsed: 21_000}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// NOTE: both transactions of the fee recipient pay 12 wei per gas, only the value of the successful one is transferred
+	expected := big.NewInt(1000 + 2*21_000*12)
+	if spent.Cmp(expected) != 0 {
+		t.Fatalf("expected fee recipient to spend %s but got %s", expected, spent)
+	}
+}
+
+func TestDeliveredValue(t *testing.T) {
+	builderKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder := types.Address(crypto.PubkeyToAddress(builderKey.PublicKey))
+	proposer := types.Address{0xbb}
+	var value types.U256Str
+	if err := value.FromBig(big.NewInt(1200)); err != nil {
+		t.Fatal(err)
+	}
+	receipt := func(*gethTypes.Transaction) (*gethTypes.Receipt, error) {
+		return &gethTypes.Receipt{Status: gethTypes.ReceiptStatusSuccessful, GasUsed: 21_000}, nil
+	}
+
+	// NOTE: the builder earns 1000 wei from the block but subsidizes the bid, paying the proposer 1200 wei
+	subsidized := &types.ExecutionPayload{
+		FeeRecipient: builder,
+		Transactions: []hexutil.Bytes{signedTransfer(t, builderKey, 0, common.Address(proposer), 1200)},
+	}
+	if err := subsidized.BaseFeePerGas.FromBig(big.NewInt(10)); err != nil {
+		t.Fatal(err)
+	}
+	balanceDeltas := map[types.Address]*big.Int{
+		builder:  big.NewInt(1000 - 1200 - 21_000*12),
+		proposer: big.NewInt(1200),
+	}
+	balanceDelta := func(address types.Address) (*big.Int, error) {
+		return new(big.Int).Set(balanceDeltas[address]), nil
+	}
+	delivered, err := deliveredValue(subsidized, proposer, balanceDelta, receipt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivered.Cmp(big.NewInt(1200)) != 0 {
+		t.Fatalf("expected subsidized block to deliver 1200 wei to the proposer but got %s", delivered)
+	}
+	if err := verifyDeliveredValue(delivered, &value, 0); err != nil {
+		t.Fatalf("expected subsidized bid to be valid: %v", err)
+	}
+
+	// NOTE: a block paying the proposer directly delivers its priority fees, including those the proposer spent in the block
+	direct := &types.ExecutionPayload{
+		FeeRecipient: builder,
+		Transactions: []hexutil.Bytes{signedTransfer(t, builderKey, 0, common.Address{0xaa}, 1000)},
+	}
+	if err := direct.BaseFeePerGas.FromBig(big.NewInt(10)); err != nil {
+		t.Fatal(err)
+	}
+	balanceDeltas[builder] = big.NewInt(200)
+	delivered, err = deliveredValue(direct, builder, balanceDelta, receipt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := big.NewInt(200 + 1000 + 21_000*12); delivered.Cmp(expected) != 0 {
+		t.Fatalf("expected block paying the proposer directly to deliver %s but got %s", expected, delivered)
+	}
+}
+
+func TestVerifyTranscriptHeader(t *testing.T) {
+	header := &boostTypes.ExecutionPayloadHeader{BlockNumber: 1000, BlockHash: types.Hash{1}}
+	transcript := &types.AuctionTranscript{
+		Bid: types.Bid{
+			Message: &boostTypes.BuilderBid{Header: header},
+		},
+		Acceptance: types.SignedBlindedBeaconBlock{
+			Message: &boostTypes.BlindedBeaconBlock{
+				Body: &boostTypes.BlindedBeaconBlockBody{ExecutionPayloadHeader: header},
+			},
+		},
+	}
+	if err := verifyTranscriptHeader(transcript); err != nil {
+		t.Fatalf("expected consistent transcript, got error %v", err)
+	}
+
+	other := *header
+	other.StateRoot = types.Root{1}
+	transcript.Acceptance.Message.Body.ExecutionPayloadHeader = &other
+	if err := verifyTranscriptHeader(transcript); err == nil {
+		t.Fatal("expected error for accepted header not matching bid")
+	}
+}
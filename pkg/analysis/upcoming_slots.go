@@ -0,0 +1,86 @@
+package analysis
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `ProposerRegistrationCoverage` is the share of the upcoming proposers of an epoch with a validator registration known to the monitor
+type ProposerRegistrationCoverage struct {
+	Epoch types.Epoch `json:"epoch,string"`
+	// Number of upcoming slots of the epoch with a known proposer
+	Proposers uint `json:"proposers"`
+	// Number of those proposers with a validator registration known to the monitor
+	RegisteredProposers uint    `json:"registered_proposers"`
+	Coverage            float64 `json:"coverage"`
+}
+
+func (a *Analyzer) processUpcomingSlot(event data.UpcomingSlotEvent, currentSlot types.Slot) {
+	a.upcomingSlotsLock.Lock()
+	defer a.upcomingSlotsLock.Unlock()
+
+	a.upcomingSlots[event.UpcomingSlot.Slot] = *event.UpcomingSlot
+	for slot := range a.upcomingSlots {
+		if slot < currentSlot {
+			delete(a.upcomingSlots, slot)
+		}
+	}
+}
+
+// `GetUpcomingSlots` returns the known slots after `currentSlot` with their proposers, ordered by slot
+func (a *Analyzer) GetUpcomingSlots(currentSlot types.Slot) []types.UpcomingSlot {
+	a.upcomingSlotsLock.Lock()
+	defer a.upcomingSlotsLock.Unlock()
+
+	upcomingSlots := []types.UpcomingSlot{}
+	for slot, upcomingSlot := range a.upcomingSlots {
+		if slot <= currentSlot {
+			continue
+		}
+		upcomingSlots = append(upcomingSlots, upcomingSlot)
+	}
+	sort.Slice(upcomingSlots, func(i, j int) bool {
+		return upcomingSlots[i].Slot < upcomingSlots[j].Slot
+	})
+	return upcomingSlots
+}
+
+// `computeProposerRegistrationCoverage` counts the registered proposers of `upcomingSlots`, ordered by slot, per epoch
+func computeProposerRegistrationCoverage(upcomingSlots []types.UpcomingSlot, slotsPerEpoch uint64) []ProposerRegistrationCoverage {
+	coverage := []ProposerRegistrationCoverage{}
+	for _, upcomingSlot := range upcomingSlots {
+		epoch := upcomingSlot.Slot / slotsPerEpoch
+		if len(coverage) == 0 || coverage[len(coverage)-1].Epoch != epoch {
+			coverage = append(coverage, ProposerRegistrationCoverage{Epoch: epoch})
+		}
+		entry := &coverage[len(coverage)-1]
+		entry.Proposers += 1
+		if upcomingSlot.Registered {
+			entry.RegisteredProposers += 1
+		}
+	}
+	for i := range coverage {
+		entry := &coverage[i]
+		entry.Coverage = float64(entry.RegisteredProposers) / float64(entry.Proposers)
+	}
+	return coverage
+}
+
+// `GetProposerRegistrationCoverage` returns the share of the proposers of the slots after `currentSlot` with a validator
+// registration in the store, per epoch, where registrations are looked up at the time of the call
+func (a *Analyzer) GetProposerRegistrationCoverage(ctx context.Context, currentSlot types.Slot) ([]ProposerRegistrationCoverage, error) {
+	upcomingSlots := a.GetUpcomingSlots(currentSlot)
+	for i := range upcomingSlots {
+		upcomingSlot := &upcomingSlots[i]
+		registration, err := store.GetLatestValidatorRegistration(ctx, a.store, &upcomingSlot.ProposerPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		upcomingSlot.Registered = registration != nil
+	}
+	return computeProposerRegistrationCoverage(upcomingSlots, a.clock.SlotsPerEpoch()), nil
+}
@@ -0,0 +1,30 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestComputeProposerRegistrationCoverage(t *testing.T) {
+	upcomingSlots := []types.UpcomingSlot{
+		{Slot: 62, Registered: true},
+		{Slot: 63},
+		{Slot: 64, Registered: true},
+		{Slot: 65, Registered: true},
+	}
+	coverage := computeProposerRegistrationCoverage(upcomingSlots, 32)
+	if len(coverage) != 2 {
+		t.Fatalf("expected coverage of 2 epochs, got %+v", coverage)
+	}
+	if coverage[0].Epoch != 1 || coverage[0].Proposers != 2 || coverage[0].RegisteredProposers != 1 || coverage[0].Coverage != 0.5 {
+		t.Fatalf("unexpected coverage of epoch 1: %+v", coverage[0])
+	}
+	if coverage[1].Epoch != 2 || coverage[1].Coverage != 1 {
+		t.Fatalf("unexpected coverage of epoch 2: %+v", coverage[1])
+	}
+
+	if coverage := computeProposerRegistrationCoverage(nil, 32); len(coverage) != 0 {
+		t.Fatalf("expected no coverage without upcoming slots, got %+v", coverage)
+	}
+}
@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// Number of events each worker can hold before dispatching to it blocks
+const workerQueueSize = 32
+
+// `eventKey` returns the key of the bid context the event belongs to, if any, so events of the same bid are processed in order.
+// The key leaves out the proposer, which is only known once the acceptance of an auction transcript is processed.
+func eventKey(event data.Event) (uint64, bool) {
+	var slot types.Slot
+	var parentHash types.Hash
+	var relay types.PublicKey
+	switch event := event.Payload.(type) {
+	case *data.BidEvent:
+		slot = event.Context.Slot
+		parentHash = event.Context.ParentHash
+		relay = event.Context.RelayPublicKey
+	case data.AuctionTranscriptEvent:
+		transcript := event.Transcript
+		if transcript.Acceptance.Message == nil || transcript.Bid.Message == nil || transcript.Bid.Message.Header == nil {
+			return 0, false
+		}
+		slot = transcript.Acceptance.Message.Slot
+		parentHash = transcript.Bid.Message.Header.ParentHash
+		relay = transcript.Bid.Message.Pubkey
+	case data.DeliveredPayloadEvent:
+		slot = event.BidTrace.Slot
+		parentHash = event.BidTrace.ParentHash
+		relay = event.Relay
+	default:
+		return 0, false
+	}
+	h := fnv.New64a()
+	var encodedSlot [8]byte
+	binary.BigEndian.PutUint64(encodedSlot[:], slot)
+	h.Write(encodedSlot[:])
+	h.Write(parentHash[:])
+	h.Write(relay[:])
+	return h.Sum64(), true
+}
+
+// `workerFor` returns the index of the worker to process `event` out of `count` workers.
+// Events without a bid context are all processed by the first worker to keep their relative order.
+func workerFor(event data.Event, count int) int {
+	key, ok := eventKey(event)
+	if !ok {
+		return 0
+	}
+	return int(key % uint64(count))
+}
+
+// `startWorkers` starts the configured number of workers to process events concurrently until `ctx` is done.
+// With a single worker, events are processed serially by `Run` instead.
+func (a *Analyzer) startWorkers(ctx context.Context) {
+	count := int(a.config.workers())
+	if count <= 1 {
+		return
+	}
+	a.workers = make([]chan data.Event, count)
+	for i := range a.workers {
+		events := make(chan data.Event, workerQueueSize)
+		a.workers[i] = events
+		go a.runWorker(ctx, strconv.Itoa(i), events)
+	}
+}
+
+func (a *Analyzer) runWorker(ctx context.Context, worker string, events <-chan data.Event) {
+	for {
+		select {
+		case event := <-events:
+			metrics.AnalyzerWorkerDepth.WithLabelValues(worker).Set(float64(len(events)))
+			a.processEvent(ctx, event)
+			metrics.AnalyzerWorkerEvents.WithLabelValues(worker).Inc()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// `dispatchEvent` hands `event` to its worker, or processes it directly without workers
+func (a *Analyzer) dispatchEvent(ctx context.Context, event data.Event) {
+	if len(a.workers) == 0 {
+		a.processEvent(ctx, event)
+		return
+	}
+	worker := a.workers[workerFor(event, len(a.workers))]
+	select {
+	case worker <- event:
+	case <-ctx.Done():
+	}
+}
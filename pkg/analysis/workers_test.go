@@ -0,0 +1,55 @@
+package analysis
+
+import (
+	"testing"
+
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestWorkerFor(t *testing.T) {
+	relay := types.PublicKey{1}
+	parentHash := types.Hash{2}
+	bidCtx := &types.BidContext{Slot: 100, ParentHash: parentHash, ProposerPublicKey: types.PublicKey{3}, RelayPublicKey: relay}
+
+	bid := data.Event{Payload: &data.BidEvent{Context: bidCtx}}
+	transcript := data.Event{Payload: data.AuctionTranscriptEvent{
+		Transcript: &types.AuctionTranscript{
+			Bid: types.Bid{
+				Message: &boostTypes.BuilderBid{
+					Header: &boostTypes.ExecutionPayloadHeader{ParentHash: parentHash},
+					Pubkey: relay,
+				},
+			},
+			Acceptance: types.SignedBlindedBeaconBlock{
+				Message: &boostTypes.BlindedBeaconBlock{Slot: 100},
+			},
+		},
+	}}
+	delivered := data.Event{Payload: data.DeliveredPayloadEvent{
+		Relay:    relay,
+		BidTrace: &types.BidTrace{Slot: 100, ParentHash: parentHash},
+	}}
+
+	const workers = 8
+	worker := workerFor(bid, workers)
+	if other := workerFor(transcript, workers); other != worker {
+		t.Errorf("expected transcript of bid to be processed by worker %d, got %d", worker, other)
+	}
+	if other := workerFor(delivered, workers); other != worker {
+		t.Errorf("expected delivered payload of bid to be processed by worker %d, got %d", worker, other)
+	}
+	if other := workerFor(data.Event{Payload: data.BlockEvent{Slot: 100}}, workers); other != 0 {
+		t.Errorf("expected event without bid context to be processed by the first worker, got %d", other)
+	}
+
+	workersUsed := make(map[int]struct{})
+	for slot := types.Slot(0); slot < 64; slot++ {
+		event := data.Event{Payload: &data.BidEvent{Context: &types.BidContext{Slot: slot, RelayPublicKey: relay}}}
+		workersUsed[workerFor(event, workers)] = struct{}{}
+	}
+	if len(workersUsed) < 2 {
+		t.Error("expected bids of different slots to be spread across workers")
+	}
+}
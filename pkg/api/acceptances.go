@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `AcceptanceRecord` is an acceptance with the bid it accepted and the analyses of the auction it concluded
+type AcceptanceRecord struct {
+	types.Acceptance
+	// The accepted bid, `nil` if the monitor did not observe it
+	Bid *types.Bid `json:"bid"`
+	// The analysis of the accepted bid, `nil` if the bid was not analyzed
+	Analysis *types.BidAnalysis `json:"analysis"`
+	// The analyses derived from the auction transcript, like the validity of the revealed payload
+	TranscriptAnalyses []types.BidAnalysis `json:"transcript_analyses"`
+}
+
+type SlotAcceptancesResponse struct {
+	Slot        types.Slot         `json:"slot"`
+	Acceptances []AcceptanceRecord `json:"acceptances"`
+}
+
+type ProposerAcceptancesResponse struct {
+	Span        SlotSpan           `json:"span"`
+	Proposer    types.PublicKey    `json:"proposer_public_key"`
+	Acceptances []AcceptanceRecord `json:"acceptances"`
+}
+
+// `acceptanceRecords` links each acceptance to its bid and analyses, where `transcriptAnalyses` are those of the slots of `acceptances`
+func (s *Server) acceptanceRecords(ctx context.Context, acceptances []types.Acceptance, transcriptAnalyses []types.BidAnalysis) ([]AcceptanceRecord, error) {
+	analysesByContext := make(map[types.BidContext][]types.BidAnalysis)
+	for _, analysis := range transcriptAnalyses {
+		analysesByContext[analysis.Context] = append(analysesByContext[analysis.Context], analysis)
+	}
+
+	records := make([]AcceptanceRecord, 0, len(acceptances))
+	for _, acceptance := range acceptances {
+		bidCtx := acceptance.Context
+		// NOTE: the store errors for a bid it does not know of, so treat any error as a bid which was not observed
+		bid, err := s.store.GetBid(ctx, &bidCtx)
+		if err != nil {
+			bid = nil
+		}
+		analysis, err := s.store.GetBidAnalysis(ctx, &bidCtx)
+		if err != nil {
+			return nil, err
+		}
+		analyses := analysesByContext[bidCtx]
+		if analyses == nil {
+			analyses = []types.BidAnalysis{}
+		}
+		records = append(records, AcceptanceRecord{
+			Acceptance:         acceptance,
+			Bid:                bid,
+			Analysis:           analysis,
+			TranscriptAnalyses: analyses,
+		})
+	}
+	return records, nil
+}
+
+func (s *Server) handleSlotAcceptancesRequest(w http.ResponseWriter, r *http.Request, slot types.Slot) {
+	logger := s.loggerFor(r.Context())
+
+	acceptances, err := s.store.GetAcceptances(r.Context(), slot)
+	if err != nil {
+		logger.Errorw("could not get acceptances for slot", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	transcriptAnalyses, err := s.store.GetTranscriptAnalyses(r.Context(), slot, slot)
+	if err != nil {
+		logger.Errorw("could not get transcript analyses for slot", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	records, err := s.acceptanceRecords(r.Context(), acceptances, transcriptAnalyses)
+	if err != nil {
+		logger.Errorw("could not link acceptances of slot to their bids", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := SlotAcceptancesResponse{
+		Slot:        slot,
+		Acceptances: records,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode slot acceptances", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleProposerAcceptancesRequest(w http.ResponseWriter, r *http.Request, proposer *types.PublicKey) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for proposer acceptances request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	acceptances, err := s.store.GetProposerAcceptances(r.Context(), proposer, span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not get acceptances for proposer", "error", err, "proposer", proposer)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	transcriptAnalyses, err := s.store.GetTranscriptAnalyses(r.Context(), span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not get transcript analyses for proposer", "error", err, "proposer", proposer)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	records, err := s.acceptanceRecords(r.Context(), acceptances, transcriptAnalyses)
+	if err != nil {
+		logger.Errorw("could not link acceptances of proposer to their bids", "error", err, "proposer", proposer)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := ProposerAcceptancesResponse{
+		Span:        *span,
+		Proposer:    *proposer,
+		Acceptances: records,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode proposer acceptances", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
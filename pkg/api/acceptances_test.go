@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+func TestAcceptanceRecords(t *testing.T) {
+	ctx := context.Background()
+	proposer := types.PublicKey{0x01}
+	s := &Server{
+		logger: zap.NewNop(),
+		store:  store.NewMemoryStore(),
+	}
+	observed := types.BidContext{Slot: 11, ProposerPublicKey: proposer, RelayPublicKey: types.PublicKey{0x02}}
+	unobserved := types.BidContext{Slot: 10, ProposerPublicKey: proposer, RelayPublicKey: types.PublicKey{0x03}}
+	other := types.BidContext{Slot: 10, ProposerPublicKey: types.PublicKey{0x04}, RelayPublicKey: types.PublicKey{0x02}}
+
+	err := s.store.PutBid(ctx, &observed, &types.Bid{Message: &boostTypes.BuilderBid{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.store.PutBidAnalysis(ctx, &types.BidAnalysis{Context: observed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.store.PutTranscriptAnalysis(ctx, &types.BidAnalysis{Context: observed, Category: "payload_invalid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, bidCtx := range []types.BidContext{observed, unobserved, other} {
+		bidCtx := bidCtx
+		err = s.store.PutAcceptance(ctx, &bidCtx, &types.SignedBlindedBeaconBlock{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	acceptances, err := s.store.GetProposerAcceptances(ctx, &proposer, 0, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acceptances) != 2 || acceptances[0].Context != unobserved || acceptances[1].Context != observed {
+		t.Fatalf("expected the acceptances of the proposer ordered by slot but got %+v", acceptances)
+	}
+	transcriptAnalyses, err := s.store.GetTranscriptAnalyses(ctx, 0, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := s.acceptanceRecords(ctx, acceptances, transcriptAnalyses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records[0].Bid != nil || records[0].Analysis != nil || len(records[0].TranscriptAnalyses) != 0 {
+		t.Errorf("expected no bid or analyses for an acceptance of an unobserved bid but got %+v", records[0])
+	}
+	if records[1].Bid == nil || records[1].Analysis == nil || len(records[1].TranscriptAnalyses) != 1 {
+		t.Errorf("expected the bid and analyses of the accepted bid but got %+v", records[1])
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// Maximum length of the message of a fault annotation, in bytes
+const maxAnnotationMessageLength = 1024
+
+// `AnnotateFaultRequest` attaches an annotation to the fault of the relay's bid with the given context,
+// as given by the fault record
+type AnnotateFaultRequest struct {
+	Context types.BidContext `json:"context"`
+	// Either `dispute` or `annotation`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+func (r *AnnotateFaultRequest) validate(relay *types.PublicKey) error {
+	if r.Context.RelayPublicKey != *relay {
+		return fmt.Errorf("context of annotation is of relay %s but expected %s", r.Context.RelayPublicKey, relay)
+	}
+	switch r.Kind {
+	case types.DisputeAnnotationKind, types.NoteAnnotationKind:
+	default:
+		return fmt.Errorf("unknown kind of annotation %q: must be %q or %q", r.Kind, types.DisputeAnnotationKind, types.NoteAnnotationKind)
+	}
+	if strings.TrimSpace(r.Message) == "" {
+		return fmt.Errorf("missing message of annotation")
+	}
+	if len(r.Message) > maxAnnotationMessageLength {
+		return fmt.Errorf("message of annotation of %d bytes exceeds the maximum of %d bytes", len(r.Message), maxAnnotationMessageLength)
+	}
+	return nil
+}
+
+// `FaultAnnotationsResponse` is the annotations of the faults of a relay over a span of slots
+type FaultAnnotationsResponse struct {
+	Span           SlotSpan                `json:"span"`
+	RelayPublicKey types.PublicKey         `json:"relay_public_key"`
+	Annotations    []types.FaultAnnotation `json:"annotations"`
+}
+
+// `authenticateOperator` reports whether the request carries the bearer token of the operator of `relay`
+func (s *Server) authenticateOperator(r *http.Request, relay *types.PublicKey) bool {
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return false
+	}
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	if token == "" {
+		return false
+	}
+	for _, operator := range s.config.operators() {
+		if operator.Relay != *relay {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(operator.Token), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// `isFault` reports whether the bid or the auction transcript with the given context was analyzed as faulty
+func (s *Server) isFault(ctx context.Context, bidCtx *types.BidContext) (bool, error) {
+	analysis, err := s.store.GetBidAnalysis(ctx, bidCtx)
+	if err != nil {
+		return false, err
+	}
+	if analysis != nil && analysis.Category != "" {
+		return true, nil
+	}
+	analyses, err := s.store.GetTranscriptAnalyses(ctx, bidCtx.Slot, bidCtx.Slot)
+	if err != nil {
+		return false, err
+	}
+	for _, analysis := range analyses {
+		if analysis.Context == *bidCtx && analysis.Category != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *Server) handleAnnotateFaultRequest(w http.ResponseWriter, r *http.Request, relay *types.PublicKey) {
+	logger := s.loggerFor(r.Context())
+
+	if !s.authenticateOperator(r, relay) {
+		logger.Warnw("rejecting fault annotation without valid credentials of the operator", "relay", relay)
+		http.Error(w, "missing or invalid operator credentials", http.StatusUnauthorized)
+		return
+	}
+
+	var request AnnotateFaultRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		logger.Warn("could not decode fault annotation")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = request.validate(relay)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bidCtx := request.Context
+	fault, err := s.isFault(r.Context(), &bidCtx)
+	if err != nil {
+		logger.Errorw("could not load analysis of annotated fault", "error", err, "context", bidCtx)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !fault {
+		http.Error(w, fmt.Sprintf("no fault of relay %s for the bid in slot %d", relay, bidCtx.Slot), http.StatusNotFound)
+		return
+	}
+
+	annotation := &types.FaultAnnotation{
+		Context:   bidCtx,
+		Kind:      request.Kind,
+		Message:   request.Message,
+		CreatedAt: time.Now().UTC(),
+	}
+	err = s.store.PutFaultAnnotation(r.Context(), annotation)
+	if err != nil {
+		logger.Errorw("could not store fault annotation", "error", err, "context", bidCtx)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	logger.Infow("annotated fault", "relay", relay, "slot", bidCtx.Slot, "kind", annotation.Kind)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleFaultAnnotationsRequest(w http.ResponseWriter, r *http.Request, relay *types.PublicKey) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for fault annotations request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	annotations, err := s.store.GetFaultAnnotations(r.Context(), relay, span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not load fault annotations", "error", err, "relay", relay)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if annotations == nil {
+		annotations = []types.FaultAnnotation{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := FaultAnnotationsResponse{
+		Span:           *span,
+		RelayPublicKey: *relay,
+		Annotations:    annotations,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode fault annotations", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
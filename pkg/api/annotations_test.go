@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+func TestAnnotateFault(t *testing.T) {
+	ctx := context.Background()
+	relay := types.PublicKey{0x01}
+	s := &Server{
+		config: &Config{
+			Operators: []OperatorConfig{{Relay: relay, Token: "secret"}},
+		},
+		logger: zap.NewNop(),
+		store:  store.NewMemoryStore(),
+	}
+	faulty := types.BidContext{Slot: 10, RelayPublicKey: relay}
+	valid := types.BidContext{Slot: 11, RelayPublicKey: relay}
+	for _, analysis := range []types.BidAnalysis{
+		{Context: faulty, Category: "consensus_invalid", Reason: "invalid timestamp"},
+		{Context: valid},
+	} {
+		analysis := analysis
+		err := s.store.PutBidAnalysis(ctx, &analysis)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	annotate := func(token string, bidCtx types.BidContext, kind string) int {
+		body, err := json.Marshal(AnnotateFaultRequest{
+			Context: bidCtx,
+			Kind:    kind,
+			Message: "beacon node of monitor was syncing",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodPost, RelaysEndpoint+"/"+relay.String()+"/annotations", bytes.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		s.handleAnnotateFaultRequest(w, req, &relay)
+		return w.Code
+	}
+
+	for _, tc := range []struct {
+		name   string
+		token  string
+		bidCtx types.BidContext
+		kind   string
+		status int
+	}{
+		{name: "missing token", bidCtx: faulty, kind: types.DisputeAnnotationKind, status: http.StatusUnauthorized},
+		{name: "wrong token", token: "guess", bidCtx: faulty, kind: types.DisputeAnnotationKind, status: http.StatusUnauthorized},
+		{name: "unknown kind", token: "secret", bidCtx: faulty, kind: "complaint", status: http.StatusBadRequest},
+		{name: "other relay", token: "secret", bidCtx: types.BidContext{Slot: 10, RelayPublicKey: types.PublicKey{0x02}}, kind: types.DisputeAnnotationKind, status: http.StatusBadRequest},
+		{name: "valid bid", token: "secret", bidCtx: valid, kind: types.DisputeAnnotationKind, status: http.StatusNotFound},
+		{name: "fault", token: "secret", bidCtx: faulty, kind: types.DisputeAnnotationKind, status: http.StatusOK},
+	} {
+		if status := annotate(tc.token, tc.bidCtx, tc.kind); status != tc.status {
+			t.Fatalf("%s: expected status %d but got %d", tc.name, tc.status, status)
+		}
+	}
+
+	annotations, err := s.store.GetFaultAnnotations(ctx, &relay, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(annotations) != 1 || annotations[0].Context != faulty || annotations[0].Kind != types.DisputeAnnotationKind {
+		t.Fatalf("expected the annotation of the fault to be stored, got %+v", annotations)
+	}
+}
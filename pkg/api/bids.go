@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	// Number of bids in a page of the bid archive without an explicit `limit`
+	DefaultBidsPageSize = 100
+	// Maximum number of bids in a page of the bid archive
+	MaxBidsPageSize = 1000
+)
+
+// `BidsResponse` is a page of the bids in the archive matching a query
+type BidsResponse struct {
+	Span SlotSpan            `json:"span"`
+	Bids []types.ArchivedBid `json:"bids"`
+	// Cursor of the next page, if there may be more matching bids
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// `encodeBidsCursor` encodes the cursor of the page following the bid with `bidCtx`
+func encodeBidsCursor(bidCtx *types.BidContext) (string, error) {
+	data, err := json.Marshal(bidCtx)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeBidsCursor(cursor string) (*types.BidContext, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	var bidCtx types.BidContext
+	err = json.Unmarshal(data, &bidCtx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	return &bidCtx, nil
+}
+
+func parsePublicKeyQueryParam(q url.Values, key string) (*types.PublicKey, error) {
+	valueStr := q.Get(key)
+	if valueStr == "" {
+		return nil, nil
+	}
+	var publicKey types.PublicKey
+	err := publicKey.UnmarshalText([]byte(valueStr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", key, err)
+	}
+	return &publicKey, nil
+}
+
+// `parseValueQueryParam` parses the optional value `key` in wei
+func parseValueQueryParam(q url.Values, key string) (*types.U256Str, error) {
+	valueStr := q.Get(key)
+	if valueStr == "" {
+		return nil, nil
+	}
+	var value types.U256Str
+	err := value.UnmarshalText([]byte(valueStr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", key, err)
+	}
+	return &value, nil
+}
+
+// `parseBidQuery` parses the filters and the page of a request for bids of the archive over `span`,
+// returning whether the signed bids are requested
+func parseBidQuery(q url.Values, span *SlotSpan) (*types.BidQuery, bool, error) {
+	query := &types.BidQuery{
+		StartSlot: span.Start,
+		EndSlot:   span.End,
+		Limit:     DefaultBidsPageSize,
+	}
+	var err error
+	query.Relay, err = parsePublicKeyQueryParam(q, "relay")
+	if err != nil {
+		return nil, false, err
+	}
+	query.Builder, err = parsePublicKeyQueryParam(q, "builder")
+	if err != nil {
+		return nil, false, err
+	}
+	query.Proposer, err = parsePublicKeyQueryParam(q, "proposer")
+	if err != nil {
+		return nil, false, err
+	}
+	query.MinValue, err = parseValueQueryParam(q, "min_value")
+	if err != nil {
+		return nil, false, err
+	}
+	query.MaxValue, err = parseValueQueryParam(q, "max_value")
+	if err != nil {
+		return nil, false, err
+	}
+	limit, err := parseUint64QueryParam(q, "limit")
+	if err != nil {
+		return nil, false, err
+	}
+	if limit != nil {
+		if *limit == 0 || *limit > MaxBidsPageSize {
+			return nil, false, fmt.Errorf("invalid limit %d: must be between 1 and %d", *limit, MaxBidsPageSize)
+		}
+		query.Limit = int(*limit)
+	}
+	if cursor := q.Get("cursor"); cursor != "" {
+		query.After, err = decodeBidsCursor(cursor)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	includeBid := false
+	if value := q.Get("include_bid"); value != "" {
+		includeBid, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid include_bid: %v", err)
+		}
+	}
+	return query, includeBid, nil
+}
+
+func (s *Server) handleBidsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for bids request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query, includeBid, err := parseBidQuery(r.URL.Query(), span)
+	if err != nil {
+		logger.Errorw("error parsing query param for bids request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bids, err := s.store.GetBids(r.Context(), query)
+	if err != nil {
+		logger.Errorw("could not load bids", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !includeBid {
+		for i := range bids {
+			bids[i].Bid = nil
+		}
+	}
+	response := BidsResponse{
+		Span: *span,
+		Bids: bids,
+	}
+	if len(bids) == query.Limit {
+		response.NextCursor, err = encodeBidsCursor(&bids[len(bids)-1].Context)
+		if err != nil {
+			logger.Errorw("could not encode cursor of bids", "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode bids", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
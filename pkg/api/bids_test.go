@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestParseBidQuery(t *testing.T) {
+	span := &SlotSpan{Start: 10, End: 20}
+	relay := types.PublicKey{1}
+	cursor, err := encodeBidsCursor(&types.BidContext{Slot: 12, RelayPublicKey: relay})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := url.ParseQuery("relay=" + relay.String() + "&min_value=1000&limit=5&include_bid=true&cursor=" + cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, includeBid, err := parseBidQuery(q, span)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.StartSlot != 10 || query.EndSlot != 20 || *query.Relay != relay || query.MinValue.String() != "1000" || query.Limit != 5 || !includeBid {
+		t.Errorf("unexpected query %+v", query)
+	}
+	if query.After == nil || query.After.Slot != 12 || query.After.RelayPublicKey != relay {
+		t.Errorf("expected the cursor to round trip but got %+v", query.After)
+	}
+
+	query, includeBid, err = parseBidQuery(url.Values{}, span)
+	if err != nil || query.Limit != DefaultBidsPageSize || includeBid || query.After != nil {
+		t.Errorf("unexpected default query %+v (%v)", query, err)
+	}
+
+	for _, invalid := range []string{"limit=0", "limit=1001", "cursor=%21", "min_value=-1", "builder=0x01", "include_bid=maybe"} {
+		q, err := url.ParseQuery(invalid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := parseBidQuery(q, span); err == nil {
+			t.Errorf("expected an error parsing %q", invalid)
+		}
+	}
+}
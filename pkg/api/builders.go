@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+type BuilderRelaysResponse struct {
+	Builder types.PublicKey                 `json:"builder_public_key"`
+	Relays  []types.BuilderRelayAssociation `json:"relays"`
+}
+
+type BuilderStatsResponse struct {
+	Span    SlotSpan               `json:"span"`
+	Builder types.PublicKey        `json:"builder_public_key"`
+	Stats   *reporter.BuilderStats `json:"stats"`
+}
+
+// `parseBuilderFromPath` returns the builder public key and the resource of a path like `/monitor/v1/builders/{pubkey}/{resource}`
+func parseBuilderFromPath(r *http.Request) (*types.PublicKey, string, error) {
+	path := strings.TrimPrefix(r.URL.Path, GetBuildersEndpoint+"/")
+	builderStr, resource, found := strings.Cut(path, "/")
+	if !found {
+		return nil, "", fmt.Errorf("unknown path %s", r.URL.Path)
+	}
+	var builder types.PublicKey
+	err := builder.UnmarshalText([]byte(builderStr))
+	if err != nil {
+		return nil, "", err
+	}
+	return &builder, strings.Trim(resource, "/"), nil
+}
+
+func (s *Server) handleBuildersRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	builder, resource, err := parseBuilderFromPath(r)
+	if err != nil {
+		logger.Errorw("error parsing builder public key for builders request", "err", err, "path", r.URL.Path)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch resource {
+	case "relays":
+		s.handleBuilderRelaysRequest(w, r, builder)
+	case "stats":
+		s.handleBuilderStatsRequest(w, r, builder)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleBuilderRelaysRequest(w http.ResponseWriter, r *http.Request, builder *types.PublicKey) {
+	logger := s.loggerFor(r.Context())
+
+	relays, err := s.store.GetBuilderRelays(r.Context(), builder)
+	if err != nil {
+		logger.Errorw("could not get relays for builder", "error", err, "builder", builder)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if relays == nil {
+		relays = []types.BuilderRelayAssociation{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := BuilderRelaysResponse{
+		Builder: *builder,
+		Relays:  relays,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode builder relays", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleBuilderStatsRequest(w http.ResponseWriter, r *http.Request, builder *types.PublicKey) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for builder stats request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := s.reporter.GetBuilderStats(r.Context(), builder, span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute builder stats", "error", err, "builder", builder)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := BuilderStatsResponse{
+		Span:    *span,
+		Builder: *builder,
+		Stats:   stats,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode builder stats", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
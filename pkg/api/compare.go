@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `RelayComparison` is the stats of a relay over the span of a comparison
+type RelayComparison struct {
+	RelayPublicKey types.PublicKey `json:"relay_public_key"`
+	Meta           *analysis.Meta  `json:"meta"`
+	// Share of the slots where any relay offered a bid in which the relay offered a bid
+	BidRate   float64                 `json:"bid_rate"`
+	BidValues *reporter.BidValueStats `json:"bid_values"`
+	Latency   *reporter.LatencyScore  `json:"latency"`
+	// Faults over the epochs of the span
+	Faults *analysis.FaultStats   `json:"faults"`
+	Score  *reporter.OverallScore `json:"score"`
+}
+
+// `CompareResponse` is the stats of the requested relays side by side over the same span
+type CompareResponse struct {
+	Span SlotSpan `json:"span"`
+	// Epochs the faults and bid rate of each relay cover, those of the slots of `Span`
+	EpochSpan Span `json:"epoch_span"`
+	// Stats of each relay, in the order requested
+	Relays []RelayComparison `json:"relays"`
+}
+
+// `parseRelayList` parses the comma-separated public keys of `relays`, dropping duplicates
+func parseRelayList(relays string) ([]types.PublicKey, error) {
+	result := []types.PublicKey{}
+	seen := make(map[types.PublicKey]struct{})
+	for _, relayStr := range strings.Split(relays, ",") {
+		relayStr = strings.TrimSpace(relayStr)
+		if relayStr == "" {
+			continue
+		}
+		var relay types.PublicKey
+		err := relay.UnmarshalText([]byte(relayStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid relay public key %s: %v", relayStr, err)
+		}
+		if _, ok := seen[relay]; ok {
+			continue
+		}
+		seen[relay] = struct{}{}
+		result = append(result, relay)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("missing relays to compare")
+	}
+	return result, nil
+}
+
+// `compareRelays` collects the stats of each of `relays` over the span
+func (s *Server) compareRelays(ctx context.Context, relays []types.PublicKey, span *SlotSpan, epochSpan Span) ([]RelayComparison, error) {
+	meta, err := s.relayMeta(ctx, relays)
+	if err != nil {
+		return nil, err
+	}
+	faults := s.analyzer.GetFaults(epochSpan.Start, epochSpan.End)
+
+	comparisons := []RelayComparison{}
+	for _, relay := range relays {
+		relay := relay
+		comparison := RelayComparison{
+			RelayPublicKey: relay,
+			Meta:           meta[relay],
+			Faults:         &analysis.FaultStats{},
+		}
+		if relayFaults, ok := faults[relay]; ok {
+			comparison.Faults = relayFaults.Stats
+		}
+		comparison.BidRate = bidRate(comparison.Faults)
+		comparison.BidValues, err = s.reporter.GetBidValueStats(ctx, &relay, span.Start, span.End)
+		if err != nil {
+			return nil, err
+		}
+		comparison.Latency, err = s.reporter.GetLatencyScore(ctx, &relay, span.Start, span.End)
+		if err != nil {
+			return nil, err
+		}
+		comparison.Score, err = s.reporter.GetOverallScore(ctx, &relay, span.Start, span.End)
+		if err != nil {
+			return nil, err
+		}
+		comparisons = append(comparisons, comparison)
+	}
+	return comparisons, nil
+}
+
+func (s *Server) handleCompareRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	relays, err := parseRelayList(r.URL.Query().Get("relays"))
+	if err != nil {
+		logger.Errorw("error parsing query param for compare request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, relay := range relays {
+		if !s.isMonitored(relay) {
+			http.Error(w, fmt.Sprintf("relay %s is not monitored", relay), http.StatusNotFound)
+			return
+		}
+	}
+
+	span, err := s.parseScoresSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for compare request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	epochSpan := Span{
+		Start: s.clock.EpochForSlot(span.Start),
+		End:   s.clock.EpochForSlot(span.End),
+	}
+
+	comparisons, err := s.compareRelays(r.Context(), relays, span, epochSpan)
+	if err != nil {
+		logger.Errorw("could not compare relays", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := CompareResponse{
+		Span:      *span,
+		EpochSpan: epochSpan,
+		Relays:    comparisons,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode relay comparison", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
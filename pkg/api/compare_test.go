@@ -0,0 +1,23 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestParseRelayList(t *testing.T) {
+	a, b := types.PublicKey{1}, types.PublicKey{2}
+	relays, err := parseRelayList(a.String() + ", " + b.String() + "," + a.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(relays) != 2 || relays[0] != a || relays[1] != b {
+		t.Errorf("expected the requested relays in order without duplicates but got %v", relays)
+	}
+	for _, relays := range []string{"", ",", "0x01," + a.String()} {
+		if _, err := parseRelayList(relays); err == nil {
+			t.Errorf("expected an error parsing %q", relays)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	DefaultEpochSpanForFaultsWindow = 256
+	DefaultSlotSpanForScoresWindow  = 7200
+	MaxSlotSpanForScoresWindow      = 100000
+	// How far into the future the timestamp of a validator registration may be
+	DefaultRegistrationTimestampTolerance = 10 * time.Second
+	// Number of distinct transcripts accepted for each slot
+	DefaultMaxTranscriptsPerSlot = 4
+	// How long a request may take before the queries serving it are canceled
+	DefaultRequestTimeout = 30 * time.Second
+	// Overall score a relay must reach to be recommended to mev-boost
+	DefaultRecommendMinScore = 0.9
+	// Number of validator registrations of a batch validated concurrently
+	DefaultRegistrationWorkers = 8
+)
+
+type Config struct {
+	Host string `yaml:"host"`
+	Port uint16 `yaml:"port"`
+	// Serve the `pprof` profiling endpoints under `/debug/pprof/`
+	Profiling bool `yaml:"profiling"`
+	// Serve `/monitor/v1/reanalyze`, which validates the stored bids of a span of slots again with the current rules
+	Reanalysis bool `yaml:"reanalysis"`
+	// How far into the future the timestamp of a validator registration may be before it is rejected
+	RegistrationTimestampTolerance time.Duration `yaml:"registration_timestamp_tolerance"`
+	// Number of validator registrations of a batch validated concurrently, `DefaultRegistrationWorkers` if unset
+	RegistrationWorkers uint `yaml:"registration_workers"`
+	// Number of distinct transcripts accepted for each slot, and so from its proposer, before further submissions are rejected
+	MaxTranscriptsPerSlot uint        `yaml:"max_transcripts_per_slot"`
+	Spans                 *SpanConfig `yaml:"spans"`
+	// How long a request may take before the store, reporter and consensus queries serving it are canceled,
+	// which does not apply to the streamed `tail` endpoint
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// Operators of relays who may annotate the faults of their relay
+	Operators []OperatorConfig `yaml:"operators"`
+	// Service level relays are evaluated against by the SLA endpoint
+	SLA *SLAConfig `yaml:"sla"`
+	// Scores relays must reach to be recommended to mev-boost
+	Recommend *RecommendConfig `yaml:"recommend"`
+}
+
+// `OperatorConfig` authenticates the operator of a relay with a bearer token
+type OperatorConfig struct {
+	Relay types.PublicKey `yaml:"relay"`
+	Token string          `yaml:"token"`
+}
+
+func (c *Config) registrationTimestampTolerance() time.Duration {
+	if c == nil || c.RegistrationTimestampTolerance == 0 {
+		return DefaultRegistrationTimestampTolerance
+	}
+	return c.RegistrationTimestampTolerance
+}
+
+func (c *Config) registrationWorkers() uint {
+	if c == nil || c.RegistrationWorkers == 0 {
+		return DefaultRegistrationWorkers
+	}
+	return c.RegistrationWorkers
+}
+
+func (c *Config) maxTranscriptsPerSlot() uint {
+	if c == nil || c.MaxTranscriptsPerSlot == 0 {
+		return DefaultMaxTranscriptsPerSlot
+	}
+	return c.MaxTranscriptsPerSlot
+}
+
+func (c *Config) requestTimeout() time.Duration {
+	if c == nil || c.RequestTimeout == 0 {
+		return DefaultRequestTimeout
+	}
+	return c.RequestTimeout
+}
+
+func (c *Config) operators() []OperatorConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Operators
+}
+
+func (c *Config) sla() *SLAConfig {
+	if c == nil {
+		return nil
+	}
+	return c.SLA
+}
+
+func (c *Config) recommend() *RecommendConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Recommend
+}
+
+func (c *Config) spans() *SpanConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Spans
+}
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.RegistrationTimestampTolerance < 0 {
+		return fmt.Errorf("invalid registration timestamp tolerance %s: must not be negative", c.RegistrationTimestampTolerance)
+	}
+	if c.RequestTimeout < 0 {
+		return fmt.Errorf("invalid request timeout %s: must not be negative", c.RequestTimeout)
+	}
+	for _, operator := range c.Operators {
+		if operator.Token == "" {
+			return fmt.Errorf("missing token of the operator of relay %s", operator.Relay)
+		}
+	}
+	err := c.SLA.Validate(c.Spans.MaxSlots())
+	if err != nil {
+		return err
+	}
+	err = c.Recommend.Validate(c.Spans.MaxSlots())
+	if err != nil {
+		return err
+	}
+	return c.Spans.Validate()
+}
+
+// `SLAConfig` is the service level relays are evaluated against, where only the rules which are set are evaluated
+type SLAConfig struct {
+	// Maximum share of a relay's bids which are faulty
+	MaxFaultRate *float64 `yaml:"max_fault_rate"`
+	// Minimum share of the slots where any relay offered a bid in which the relay offered a bid
+	MinBidDelivery *float64 `yaml:"min_bid_delivery"`
+	// Maximum 95th percentile `getHeader` latency
+	MaxLatency time.Duration `yaml:"max_latency"`
+	// Number of slots evaluated by a request without an explicit `window`, the default slot window if unset
+	Window uint64 `yaml:"window"`
+}
+
+func (c *SLAConfig) window(spans *SpanConfig) uint64 {
+	if c == nil || c.Window == 0 {
+		return spans.SlotWindow()
+	}
+	return c.Window
+}
+
+// `hasRules` reports whether any rule of the service level is set
+func (c *SLAConfig) hasRules() bool {
+	return c != nil && (c.MaxFaultRate != nil || c.MinBidDelivery != nil || c.MaxLatency != 0)
+}
+
+func (c *SLAConfig) Validate(maxWindow uint64) error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxFaultRate != nil && (*c.MaxFaultRate < 0 || *c.MaxFaultRate > 1) {
+		return fmt.Errorf("invalid SLA: maximum fault rate %g must be between 0 and 1", *c.MaxFaultRate)
+	}
+	if c.MinBidDelivery != nil && (*c.MinBidDelivery < 0 || *c.MinBidDelivery > 1) {
+		return fmt.Errorf("invalid SLA: minimum bid delivery %g must be between 0 and 1", *c.MinBidDelivery)
+	}
+	if c.MaxLatency < 0 {
+		return fmt.Errorf("invalid SLA: maximum latency %s must not be negative", c.MaxLatency)
+	}
+	if c.Window > maxWindow {
+		return fmt.Errorf("invalid SLA: window of %d slots exceeds the maximum of %d slots", c.Window, maxWindow)
+	}
+	return nil
+}
+
+// `RecommendConfig` sets the scores a relay must reach over a recent window to be recommended to mev-boost
+type RecommendConfig struct {
+	// Minimum overall score, `DefaultRecommendMinScore` if unset
+	MinScore *float64 `yaml:"min_score"`
+	// Minimum latency score, not required if unset
+	MinLatencyScore float64 `yaml:"min_latency_score"`
+	// Number of slots scored by a request without an explicit `window`, the default window of the scores if unset
+	Window uint64 `yaml:"window"`
+}
+
+func (c *RecommendConfig) minScore() float64 {
+	if c == nil || c.MinScore == nil {
+		return DefaultRecommendMinScore
+	}
+	return *c.MinScore
+}
+
+func (c *RecommendConfig) minLatencyScore() float64 {
+	if c == nil {
+		return 0
+	}
+	return c.MinLatencyScore
+}
+
+func (c *RecommendConfig) window() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.Window
+}
+
+func (c *RecommendConfig) Validate(maxWindow uint64) error {
+	if c == nil {
+		return nil
+	}
+	if c.minScore() < 0 || c.minScore() > 1 {
+		return fmt.Errorf("invalid recommendation: minimum score %g must be between 0 and 1", c.minScore())
+	}
+	if c.MinLatencyScore < 0 || c.MinLatencyScore > 1 {
+		return fmt.Errorf("invalid recommendation: minimum latency score %g must be between 0 and 1", c.MinLatencyScore)
+	}
+	if c.Window > maxWindow {
+		return fmt.Errorf("invalid recommendation: window of %d slots exceeds the maximum of %d slots", c.Window, maxWindow)
+	}
+	return nil
+}
+
+// `SpanConfig` bounds the spans of epochs or slots requests can cover, where unset values take their defaults
+type SpanConfig struct {
+	// Number of epochs covered by a faults request without an explicit `window`
+	DefaultEpochWindow uint64 `yaml:"default_epoch_window"`
+	// Number of slots covered by a slot-based request without an explicit `window`
+	DefaultSlotWindow uint64 `yaml:"default_slot_window"`
+	// Maximum number of slots a slot-based request can cover
+	MaxSlotWindow uint64 `yaml:"max_slot_window"`
+}
+
+func (c *SpanConfig) EpochWindow() uint64 {
+	if c == nil || c.DefaultEpochWindow == 0 {
+		return DefaultEpochSpanForFaultsWindow
+	}
+	return c.DefaultEpochWindow
+}
+
+func (c *SpanConfig) SlotWindow() uint64 {
+	if c == nil || c.DefaultSlotWindow == 0 {
+		return DefaultSlotSpanForScoresWindow
+	}
+	return c.DefaultSlotWindow
+}
+
+func (c *SpanConfig) MaxSlots() uint64 {
+	if c == nil || c.MaxSlotWindow == 0 {
+		return MaxSlotSpanForScoresWindow
+	}
+	return c.MaxSlotWindow
+}
+
+func (c *SpanConfig) Validate() error {
+	if c.SlotWindow() > c.MaxSlots() {
+		return fmt.Errorf("invalid spans: default slot window of %d slots exceeds the maximum of %d slots", c.SlotWindow(), c.MaxSlots())
+	}
+	return nil
+}
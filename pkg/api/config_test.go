@@ -0,0 +1,29 @@
+package api
+
+import "testing"
+
+func TestConfigValidate(t *testing.T) {
+	var config *Config
+	if err := config.Validate(); err != nil {
+		t.Fatalf("expected defaults to be valid: %v", err)
+	}
+
+	config = &Config{
+		Spans: &SpanConfig{
+			DefaultSlotWindow: 200000,
+		},
+	}
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected default slot window beyond the maximum to be invalid")
+	}
+
+	config.Spans.MaxSlotWindow = 200000
+	if err := config.Validate(); err != nil {
+		t.Fatalf("expected raised maximum to be valid: %v", err)
+	}
+
+	config.RegistrationTimestampTolerance = -1
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected negative tolerance to be invalid")
+	}
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `DashboardRelay` summarizes a relay over the span of a dashboard response
+type DashboardRelay struct {
+	RelayPublicKey types.PublicKey `json:"relay_public_key"`
+	Meta           *analysis.Meta  `json:"meta"`
+	Tags           []string        `json:"tags"`
+	// Whether data is collected from the relay, false for relays delisted from the registry
+	Active bool `json:"active"`
+	// Faults over the epochs of the span
+	Faults  *analysis.FaultStats   `json:"faults"`
+	Score   *reporter.OverallScore `json:"score"`
+	Latency *reporter.LatencyScore `json:"latency"`
+	Uptime  float64                `json:"uptime"`
+}
+
+// `DashboardSummaryResponse` is the data backing a dashboard of every monitored relay
+type DashboardSummaryResponse struct {
+	Span SlotSpan `json:"span"`
+	// Epochs the faults of each relay cover, those of the slots of `Span`
+	EpochSpan Span             `json:"epoch_span"`
+	Relays    []DashboardRelay `json:"relays"`
+}
+
+// `DashboardRelayResponse` is the data backing a dashboard of a single relay
+type DashboardRelayResponse struct {
+	Span      SlotSpan `json:"span"`
+	EpochSpan Span     `json:"epoch_span"`
+	DashboardRelay
+	// Intervals of the relay's status over the span
+	Intervals []types.RelayStatusInterval `json:"intervals"`
+	// Stats of each epoch of the span, ordered by epoch
+	Epochs []reporter.EpochStats `json:"epochs"`
+}
+
+// `parseDashboardWindow` returns the number of slots a dashboard request looks back over, given by either
+// `lookback_slots` or its alias `window` and bounded by `maxWindow`
+func parseDashboardWindow(q url.Values, defaultWindow, maxWindow uint64) (uint64, error) {
+	lookback, err := parseUint64QueryParam(q, "lookback_slots")
+	if err != nil {
+		return 0, err
+	}
+	window, err := parseUint64QueryParam(q, "window")
+	if err != nil {
+		return 0, err
+	}
+	if lookback != nil && window != nil && *lookback != *window {
+		return 0, fmt.Errorf("conflicting lookback of %d slots and window of %d slots", *lookback, *window)
+	}
+	if lookback == nil {
+		lookback = window
+	}
+	if lookback == nil {
+		return defaultWindow, nil
+	}
+	if *lookback > maxWindow {
+		return 0, fmt.Errorf("invalid span: requested span of %d slots exceeds the maximum of %d slots", *lookback, maxWindow)
+	}
+	return *lookback, nil
+}
+
+// `dashboardSpan` returns the slot span of a dashboard request and the epochs covering it
+func (s *Server) dashboardSpan(r *http.Request) (*SlotSpan, Span, error) {
+	spans := s.config.spans()
+	window, err := parseDashboardWindow(r.URL.Query(), spans.SlotWindow(), spans.MaxSlots())
+	if err != nil {
+		return nil, Span{}, err
+	}
+	currentSlot := s.currentSlot()
+	startSlot := uint64(0)
+	if currentSlot > window {
+		startSlot = currentSlot - window
+	}
+	span := &SlotSpan{
+		Start: startSlot,
+		End:   currentSlot,
+	}
+	return span, Span{
+		Start: s.clock.EpochForSlot(span.Start),
+		End:   s.clock.EpochForSlot(span.End),
+	}, nil
+}
+
+// `dashboardRelays` summarizes each of `relays` over the span
+func (s *Server) dashboardRelays(ctx context.Context, relays []types.PublicKey, span *SlotSpan, epochSpan Span) ([]DashboardRelay, error) {
+	relayTags, err := s.store.GetRelayTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[types.PublicKey][]string)
+	for _, entry := range relayTags {
+		tags[entry.RelayPublicKey] = entry.Tags
+	}
+	meta, err := s.relayMeta(ctx, relays)
+	if err != nil {
+		return nil, err
+	}
+	faults := s.analyzer.GetFaults(epochSpan.Start, epochSpan.End)
+
+	summaries := []DashboardRelay{}
+	for _, relay := range relays {
+		relay := relay
+		summary := DashboardRelay{
+			RelayPublicKey: relay,
+			Meta:           meta[relay],
+			Tags:           tags[relay],
+			Faults:         &analysis.FaultStats{},
+		}
+		if summary.Tags == nil {
+			summary.Tags = []string{}
+		}
+		if relayFaults, ok := faults[relay]; ok {
+			summary.Faults = relayFaults.Stats
+		}
+		settings, err := s.store.GetRelaySettings(ctx, &relay)
+		if err != nil {
+			return nil, err
+		}
+		summary.Active = settings != nil && settings.Active
+		summary.Score, err = s.reporter.GetOverallScore(ctx, &relay, span.Start, span.End)
+		if err != nil {
+			return nil, err
+		}
+		summary.Latency, err = s.reporter.GetLatencyScore(ctx, &relay, span.Start, span.End)
+		if err != nil {
+			return nil, err
+		}
+		uptime, err := s.reporter.GetUptime(ctx, &relay, span.Start, span.End)
+		if err != nil {
+			return nil, err
+		}
+		summary.Uptime = uptime.Uptime
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func (s *Server) handleDashboardSummaryRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	span, epochSpan, err := s.dashboardSpan(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for dashboard request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	relays, err := s.dashboardRelays(r.Context(), s.reporter.Relays(), span, epochSpan)
+	if err != nil {
+		logger.Errorw("could not summarize relays for dashboard", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := DashboardSummaryResponse{
+		Span:      *span,
+		EpochSpan: epochSpan,
+		Relays:    relays,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode dashboard summary", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleDashboardRelayRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	relayStr := strings.TrimPrefix(r.URL.Path, DashboardRelayEndpoint+"/")
+	if !strings.HasSuffix(relayStr, ".json") {
+		http.NotFound(w, r)
+		return
+	}
+	relayStr = strings.TrimSuffix(relayStr, ".json")
+	var relay types.PublicKey
+	err := relay.UnmarshalText([]byte(relayStr))
+	if err != nil {
+		logger.Errorw("error parsing relay public key for dashboard request", "err", err, "path", r.URL.Path)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !s.isMonitored(relay) {
+		http.Error(w, fmt.Sprintf("relay %s is not monitored", relay), http.StatusNotFound)
+		return
+	}
+
+	span, epochSpan, err := s.dashboardSpan(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for dashboard request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	summaries, err := s.dashboardRelays(r.Context(), []types.PublicKey{relay}, span, epochSpan)
+	if err != nil {
+		logger.Errorw("could not summarize relay for dashboard", "error", err, "relay", relay)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	uptime, err := s.reporter.GetUptime(r.Context(), &relay, span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute relay uptime", "error", err, "relay", relay)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	epochs, err := s.reporter.GetEpochStats(r.Context(), epochSpan.Start, epochSpan.End, s.clock.SlotsPerEpoch(), "")
+	if err != nil {
+		logger.Errorw("could not compute epoch stats", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := DashboardRelayResponse{
+		Span:           *span,
+		EpochSpan:      epochSpan,
+		DashboardRelay: summaries[0],
+		Intervals:      uptime.Intervals,
+		Epochs:         epochs[relay],
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode dashboard relay", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
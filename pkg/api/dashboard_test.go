@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestParseDashboardWindow(t *testing.T) {
+	for _, tc := range []struct {
+		query    string
+		expected uint64
+		err      bool
+	}{
+		{query: "", expected: 7200},
+		{query: "lookback_slots=300", expected: 300},
+		{query: "window=300", expected: 300},
+		{query: "lookback_slots=300&window=300", expected: 300},
+		{query: "lookback_slots=300&window=600", err: true},
+		{query: "lookback_slots=200000", err: true},
+		{query: "window=latest", err: true},
+	} {
+		q, err := url.ParseQuery(tc.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		window, err := parseDashboardWindow(q, 7200, 100000)
+		if tc.err {
+			if err == nil {
+				t.Errorf("query %q: expected an error but got a window of %d slots", tc.query, window)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("query %q: %v", tc.query, err)
+		} else if window != tc.expected {
+			t.Errorf("query %q: expected a window of %d slots but got %d", tc.query, tc.expected, window)
+		}
+	}
+}
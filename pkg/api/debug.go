@@ -0,0 +1,247 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `ExpectedSlotValues` are the values derived from consensus which the bids of a slot are validated against,
+// any value which could not be derived is omitted
+type ExpectedSlotValues struct {
+	ParentHash        *types.Hash      `json:"parent_hash,omitempty"`
+	ProposerPublicKey *types.PublicKey `json:"proposer_public_key,omitempty"`
+	PrevRandao        *types.Hash      `json:"prev_randao,omitempty"`
+	BlockNumber       *uint64          `json:"block_number,omitempty,string"`
+	BaseFeePerGas     string           `json:"base_fee_per_gas,omitempty"`
+}
+
+// `SlotBid` is a bid of a relay in the slot with its analysis and the latency of the request for it
+type SlotBid struct {
+	types.ProposerBid
+	// Round-trip time of the `getHeader` request, in milliseconds
+	Latency *int64 `json:"latency_ms,omitempty"`
+	// Analyses of the bid by each ruleset which analyzed it, where `analysis` is the latest
+	AnalysisVersions []types.BidAnalysis `json:"analysis_versions,omitempty"`
+}
+
+type CanonicalBlock struct {
+	BlockHash    types.Hash    `json:"block_hash"`
+	ParentHash   types.Hash    `json:"parent_hash"`
+	BlockNumber  uint64        `json:"block_number,string"`
+	FeeRecipient types.Address `json:"fee_recipient"`
+	GasLimit     uint64        `json:"gas_limit,string"`
+	GasUsed      uint64        `json:"gas_used,string"`
+	Transactions int           `json:"transactions"`
+}
+
+type SlotDebugResponse struct {
+	Slot types.Slot `json:"slot,string"`
+	// Unix time of the start of the slot
+	StartTime int64              `json:"start_time"`
+	Expected  ExpectedSlotValues `json:"expected"`
+	Bids      []SlotBid          `json:"bids"`
+	// Every bid requested from each relay in the slot, where `bids` holds the last sample of each relay
+	Samples []types.BidSample `json:"samples"`
+	// Samples where a relay withdrew its bid or offered a lower value than in its previous sample
+	Cancellations []types.BidCancellation `json:"cancellations"`
+	Acceptances   []types.Acceptance      `json:"acceptances"`
+	// Analyses of the accepted bids derived from their auction transcripts
+	TranscriptAnalyses []types.BidAnalysis `json:"transcript_analyses"`
+	WinningBids        []types.WinningBid  `json:"winning_bids"`
+	// Raw responses of the relays to each `getHeader` request in the slot, empty unless `collector.provenance` is set
+	Provenance []types.BidProvenance `json:"provenance"`
+	Missed     bool                  `json:"missed"`
+	// The canonical block of the slot, `null` if the slot was missed or the block is unavailable
+	CanonicalBlock *CanonicalBlock `json:"canonical_block"`
+}
+
+// `getExpectedSlotValues` derives what the monitor expects of the bids of `slot` from the consensus client
+func (s *Server) getExpectedSlotValues(r *http.Request, slot types.Slot) ExpectedSlotValues {
+	logger := s.loggerFor(r.Context())
+
+	var expected ExpectedSlotValues
+	parentHash, err := s.consensusClient.GetParentHash(r.Context(), slot)
+	if err != nil {
+		logger.Debugw("could not get parent hash for slot debug request", "error", err, "slot", slot)
+	} else {
+		expected.ParentHash = &parentHash
+	}
+	proposer, err := s.consensusClient.GetProposerPublicKey(r.Context(), slot)
+	if err != nil {
+		logger.Debugw("could not get proposer for slot debug request", "error", err, "slot", slot)
+	} else {
+		expected.ProposerPublicKey = proposer
+	}
+	prevRandao, err := s.consensusClient.GetRandomnessForProposal(r.Context(), slot)
+	if err != nil {
+		logger.Debugw("could not get randomness for slot debug request", "error", err, "slot", slot)
+	} else {
+		expected.PrevRandao = &prevRandao
+	}
+	blockNumber, err := s.consensusClient.GetBlockNumberForProposal(r.Context(), slot)
+	if err != nil {
+		logger.Debugw("could not get block number for slot debug request", "error", err, "slot", slot)
+	} else {
+		expected.BlockNumber = &blockNumber
+	}
+	baseFee, err := s.consensusClient.GetBaseFeeForProposal(r.Context(), slot)
+	if err != nil {
+		logger.Debugw("could not get base fee for slot debug request", "error", err, "slot", slot)
+	} else {
+		expected.BaseFeePerGas = baseFee.ToBig().String()
+	}
+	return expected
+}
+
+func (s *Server) getCanonicalBlock(r *http.Request, slot types.Slot) (bool, *CanonicalBlock) {
+	logger := s.loggerFor(r.Context())
+
+	missed, err := s.consensusClient.IsSlotMissed(r.Context(), slot)
+	if err != nil {
+		logger.Debugw("could not check for missed slot for slot debug request", "error", err, "slot", slot)
+		return false, nil
+	}
+	if missed {
+		return true, nil
+	}
+	block, err := s.consensusClient.GetBlock(r.Context(), slot)
+	if err != nil {
+		logger.Debugw("could not get canonical block for slot debug request", "error", err, "slot", slot)
+		return false, nil
+	}
+	payload := block.Message.Body.ExecutionPayload
+	return false, &CanonicalBlock{
+		BlockHash:    types.Hash(payload.BlockHash),
+		ParentHash:   types.Hash(payload.ParentHash),
+		BlockNumber:  uint64(payload.BlockNumber),
+		FeeRecipient: types.Address(payload.FeeRecipient),
+		GasLimit:     uint64(payload.GasLimit),
+		GasUsed:      uint64(payload.GasUsed),
+		Transactions: len(payload.Transactions),
+	}
+}
+
+func (s *Server) handleSlotDebugRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	slotStr := strings.Trim(strings.TrimPrefix(r.URL.Path, GetDebugSlotsEndpoint+"/"), "/")
+	slot, err := strconv.ParseUint(slotStr, 10, 64)
+	if err != nil {
+		logger.Errorw("error parsing slot for slot debug request", "err", err, "path", r.URL.Path)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	proposerBids, err := s.store.GetSlotBids(ctx, slot)
+	if err != nil {
+		logger.Errorw("could not get bids for slot debug request", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	bids := []SlotBid{}
+	for _, proposerBid := range proposerBids {
+		bid := SlotBid{ProposerBid: proposerBid}
+		latency, err := s.store.GetBidLatency(ctx, &proposerBid.Context)
+		if err != nil {
+			logger.Errorw("could not get bid latency for slot debug request", "error", err, "context", proposerBid.Context)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if latency != nil {
+			milliseconds := latency.Milliseconds()
+			bid.Latency = &milliseconds
+		}
+		bid.AnalysisVersions, err = s.store.GetBidAnalysisVersions(ctx, &proposerBid.Context)
+		if err != nil {
+			logger.Errorw("could not get analysis versions for slot debug request", "error", err, "context", proposerBid.Context)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		bids = append(bids, bid)
+	}
+	samples, err := s.store.GetBidSamples(ctx, slot, slot)
+	if err != nil {
+		logger.Errorw("could not get bid samples for slot debug request", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if samples == nil {
+		samples = []types.BidSample{}
+	}
+	cancellations, err := s.store.GetBidCancellations(ctx, slot, slot)
+	if err != nil {
+		logger.Errorw("could not get bid cancellations for slot debug request", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if cancellations == nil {
+		cancellations = []types.BidCancellation{}
+	}
+	acceptances, err := s.store.GetAcceptances(ctx, slot)
+	if err != nil {
+		logger.Errorw("could not get acceptances for slot debug request", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if acceptances == nil {
+		acceptances = []types.Acceptance{}
+	}
+	transcriptAnalyses, err := s.store.GetTranscriptAnalyses(ctx, slot, slot)
+	if err != nil {
+		logger.Errorw("could not get transcript analyses for slot debug request", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if transcriptAnalyses == nil {
+		transcriptAnalyses = []types.BidAnalysis{}
+	}
+	winningBids, err := s.store.GetWinningBids(ctx, slot, slot)
+	if err != nil {
+		logger.Errorw("could not get winning bids for slot debug request", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if winningBids == nil {
+		winningBids = []types.WinningBid{}
+	}
+	provenance, err := s.store.GetBidProvenances(ctx, slot, slot)
+	if err != nil {
+		logger.Errorw("could not get bid provenance for slot debug request", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if provenance == nil {
+		provenance = []types.BidProvenance{}
+	}
+	missed, canonicalBlock := s.getCanonicalBlock(r, slot)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := SlotDebugResponse{
+		Slot:               slot,
+		StartTime:          s.clock.SlotInSeconds(slot),
+		Expected:           s.getExpectedSlotValues(r, slot),
+		Bids:               bids,
+		Samples:            samples,
+		Cancellations:      cancellations,
+		Acceptances:        acceptances,
+		TranscriptAnalyses: transcriptAnalyses,
+		WinningBids:        winningBids,
+		Provenance:         provenance,
+		Missed:             missed,
+		CanonicalBlock:     canonicalBlock,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode slot debug info", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const RequestIDHeader = "X-Request-Id"
+
+// `statusRecorder` records the status code of a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// NOTE: streamed responses like the tail flush as they write
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func newRequestID() string {
+	var b [8]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// `loggerFor` returns the logger of a handler serving the request of `ctx`, which tags each line with the ID of the request
+func (s *Server) loggerFor(ctx context.Context) *zap.SugaredLogger {
+	logger := s.logger.Sugar()
+	if requestID := tracing.RequestID(ctx); requestID != "" {
+		logger = logger.With("request_id", requestID)
+	}
+	return logger
+}
+
+// `instrument` serves each request with `mux`, recording metrics per route and an access log line. Each request is given
+// an ID, taken from the `X-Request-Id` header if the client sent one, which is returned in the same header, tagged on the log
+// lines of its handler and carried in the context of the request into the reporter and the store. The context of the request is canceled once the request
+// timeout elapses, except for the streamed tail.
+func (s *Server) instrument(mux *http.ServeMux) http.Handler {
+	logger := s.logger.Sugar()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		// NOTE: label by the pattern the request is routed by to keep the cardinality of the metrics bounded
+		_, route := mux.Handler(r)
+		ctx, span := tracing.Tracer().Start(r.Context(), "api.request")
+		span.SetAttributes(
+			attribute.String("route", route),
+			attribute.String("request_id", requestID),
+		)
+		defer span.End()
+		ctx = tracing.WithRequestID(ctx, requestID)
+		if route != GetTailEndpoint {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, s.config.requestTimeout())
+			defer cancel()
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		mux.ServeHTTP(recorder, r.WithContext(ctx))
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		duration := time.Since(start)
+		span.SetAttributes(attribute.Int("status", recorder.status))
+
+		metrics.APIRequests.WithLabelValues(route, r.Method, strconv.Itoa(recorder.status)).Inc()
+		metrics.APIRequestLatency.WithLabelValues(route).Observe(duration.Seconds())
+		logger.Infow("served API request", "request_id", requestID, "method", r.Method, "path", r.URL.Path, "route", route, "status", recorder.status, "duration", duration)
+	})
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/tracing"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestInstrument(t *testing.T) {
+	s := &Server{logger: zap.NewNop()}
+
+	var requestID string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		requestID = tracing.RequestID(r.Context())
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := s.instrument(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set(RequestIDHeader, "abc")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if requestID != "abc" || w.Header().Get(RequestIDHeader) != "abc" {
+		t.Fatalf("expected the request ID of the client to be propagated, got %q and %q", requestID, w.Header().Get(RequestIDHeader))
+	}
+	if w.Code != http.StatusTeapot {
+		t.Fatalf("expected status %d but got %d", http.StatusTeapot, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	if requestID == "" || requestID == "abc" || w.Header().Get(RequestIDHeader) != requestID {
+		t.Fatalf("expected a new request ID to be propagated, got %q and %q", requestID, w.Header().Get(RequestIDHeader))
+	}
+}
+
+func TestInstrumentTimeout(t *testing.T) {
+	s := &Server{logger: zap.NewNop(), config: &Config{RequestTimeout: time.Minute}}
+
+	deadlines := make(map[string]bool)
+	mux := http.NewServeMux()
+	handle := func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.Context().Deadline()
+		deadlines[r.URL.Path] = ok
+	}
+	mux.HandleFunc("/ok", handle)
+	mux.HandleFunc(GetTailEndpoint, handle)
+	handler := s.instrument(mux)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, GetTailEndpoint, nil))
+	if !deadlines["/ok"] {
+		t.Fatal("expected the request to have a deadline")
+	}
+	if deadlines[GetTailEndpoint] {
+		t.Fatal("expected the tail to have no deadline")
+	}
+}
+
+func TestLoggerFor(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	s := &Server{logger: zap.New(core)}
+
+	s.loggerFor(tracing.WithRequestID(context.Background(), "abc")).Info("with request")
+	s.loggerFor(context.Background()).Info("without request")
+
+	entries := logs.All()
+	if requestID := entries[0].ContextMap()["request_id"]; requestID != "abc" {
+		t.Fatalf("expected log line to be tagged with the request ID but got %v", requestID)
+	}
+	if _, ok := entries[1].ContextMap()["request_id"]; ok {
+		t.Fatal("expected log line outside of a request not to be tagged with a request ID")
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+type ProposerBidsResponse struct {
+	Span     SlotSpan            `json:"span"`
+	Proposer types.PublicKey     `json:"proposer_public_key"`
+	Bids     []types.ProposerBid `json:"bids"`
+}
+
+// `parseProposerFromPath` returns the proposer public key and the resource of a path like `/monitor/v1/proposers/{pubkey}/bids`
+func parseProposerFromPath(r *http.Request) (*types.PublicKey, string, error) {
+	path := strings.TrimPrefix(r.URL.Path, GetProposersEndpoint+"/")
+	proposerStr, resource, found := strings.Cut(path, "/")
+	if !found {
+		return nil, "", fmt.Errorf("unknown path %s", r.URL.Path)
+	}
+	var proposer types.PublicKey
+	err := proposer.UnmarshalText([]byte(proposerStr))
+	if err != nil {
+		return nil, "", err
+	}
+	return &proposer, strings.Trim(resource, "/"), nil
+}
+
+func (s *Server) handleProposersRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	proposer, resource, err := parseProposerFromPath(r)
+	if err != nil {
+		logger.Errorw("error parsing proposer public key for proposer request", "err", err, "path", r.URL.Path)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch resource {
+	case "bids":
+		s.handleProposerBidsRequest(w, r, proposer)
+	case "acceptances":
+		s.handleProposerAcceptancesRequest(w, r, proposer)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleProposerBidsRequest(w http.ResponseWriter, r *http.Request, proposer *types.PublicKey) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for proposer bids request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bids, err := s.store.GetProposerBids(r.Context(), proposer, span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not get bids for proposer", "error", err, "proposer", proposer)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if bids == nil {
+		bids = []types.ProposerBid{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := ProposerBidsResponse{
+		Span:     *span,
+		Proposer: *proposer,
+		Bids:     bids,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode proposer bids", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
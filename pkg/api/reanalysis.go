@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+)
+
+// `ReanalyzeResponse` is the outcome of validating the stored bids of a span of slots again
+type ReanalyzeResponse struct {
+	Span SlotSpan `json:"span"`
+	analysis.Reanalysis
+}
+
+func (s *Server) handleReanalyzeRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for reanalyze request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.analyzer.Reanalyze(r.Context(), span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not reanalyze bids", "error", err, "start", span.Start, "end", span.End)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := ReanalyzeResponse{
+		Span:       *span,
+		Reanalysis: *result,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode reanalysis", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `RecommendedRelay` is a relay whose scores reach the thresholds of a recommendation
+type RecommendedRelay struct {
+	RelayPublicKey types.PublicKey `json:"relay_public_key"`
+	// Endpoint of the relay as given to mev-boost
+	URL          string  `json:"url"`
+	Score        float64 `json:"score"`
+	LatencyScore float64 `json:"latency_score"`
+}
+
+// `RecommendResponse` is the relays recommended to mev-boost over a span of slots
+type RecommendResponse struct {
+	Span            SlotSpan `json:"span"`
+	MinScore        float64  `json:"min_score"`
+	MinLatencyScore float64  `json:"min_latency_score"`
+	// Value of the `-relays` flag of mev-boost with the recommended relays
+	Relays string `json:"relays"`
+	// Recommended relays, ordered by decreasing overall score
+	Recommended []RecommendedRelay `json:"recommended"`
+}
+
+// `parseThresholdQueryParam` parses the optional score threshold `key` in [0, 1], returning `defaultValue` if it is not given
+func parseThresholdQueryParam(q url.Values, key string, defaultValue float64) (float64, error) {
+	value, err := parseFloat64QueryParam(q, key)
+	if err != nil {
+		return 0, err
+	}
+	if value == nil {
+		return defaultValue, nil
+	}
+	if *value < 0 || *value > 1 {
+		return 0, fmt.Errorf("invalid %s %g: must be between 0 and 1", key, *value)
+	}
+	return *value, nil
+}
+
+// `recommendRelays` selects the relays with a mev-boost endpoint in `urls` whose scores reach both thresholds
+func recommendRelays(scores reporter.OverallScoreRecord, latencies reporter.LatencyScoreRecord, urls map[types.PublicKey]string, minScore, minLatencyScore float64) []RecommendedRelay {
+	recommended := []RecommendedRelay{}
+	for relay, score := range scores {
+		relayURL, ok := urls[relay]
+		if !ok || relayURL == "" {
+			continue
+		}
+		var latencyScore float64
+		if latency, ok := latencies[relay]; ok {
+			latencyScore = latency.Score
+		}
+		if score.Score < minScore || latencyScore < minLatencyScore {
+			continue
+		}
+		recommended = append(recommended, RecommendedRelay{
+			RelayPublicKey: relay,
+			URL:            relayURL,
+			Score:          score.Score,
+			LatencyScore:   latencyScore,
+		})
+	}
+	sort.Slice(recommended, func(i, j int) bool {
+		if recommended[i].Score != recommended[j].Score {
+			return recommended[i].Score > recommended[j].Score
+		}
+		return recommended[i].RelayPublicKey.String() < recommended[j].RelayPublicKey.String()
+	})
+	return recommended
+}
+
+func (s *Server) handleRecommendRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	config := s.config.recommend()
+	q := r.URL.Query()
+	var span *SlotSpan
+	var err error
+	if window := config.window(); window != 0 {
+		span, err = s.parseSlotSpanWithWindow(r, window)
+	} else {
+		span, err = s.parseScoresSpanFromRequest(r)
+	}
+	if err != nil {
+		logger.Errorw("error parsing query param for recommend request", "err", err, "query", q)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	minScore, err := parseThresholdQueryParam(q, "min_score", config.minScore())
+	if err != nil {
+		logger.Errorw("error parsing query param for recommend request", "err", err, "query", q)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	minLatencyScore, err := parseThresholdQueryParam(q, "min_latency_score", config.minLatencyScore())
+	if err != nil {
+		logger.Errorw("error parsing query param for recommend request", "err", err, "query", q)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	scores, err := s.reporter.GetOverallScores(r.Context(), span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute overall scores", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	latencies, err := s.reporter.GetLatencyScores(r.Context(), span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute latency scores", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// NOTE: only relays data is collected from are recommended
+	urls := make(map[types.PublicKey]string)
+	for relay := range scores {
+		relay := relay
+		settings, err := s.store.GetRelaySettings(r.Context(), &relay)
+		if err != nil {
+			logger.Errorw("could not get relay settings", "error", err, "relay", relay)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if settings != nil && settings.Active {
+			urls[relay] = settings.URL
+		}
+	}
+
+	recommended := recommendRelays(scores, latencies, urls, minScore, minLatencyScore)
+	relayURLs := make([]string, 0, len(recommended))
+	for _, relay := range recommended {
+		relayURLs = append(relayURLs, relay.URL)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := RecommendResponse{
+		Span:            *span,
+		MinScore:        minScore,
+		MinLatencyScore: minLatencyScore,
+		Relays:          strings.Join(relayURLs, ","),
+		Recommended:     recommended,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode recommended relays", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
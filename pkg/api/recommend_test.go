@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestRecommendRelays(t *testing.T) {
+	good, slow, faulty, inactive := types.PublicKey{1}, types.PublicKey{2}, types.PublicKey{3}, types.PublicKey{4}
+	scores := reporter.OverallScoreRecord{
+		good:     {Score: 0.95},
+		slow:     {Score: 0.99},
+		faulty:   {Score: 0.5},
+		inactive: {Score: 1},
+	}
+	latencies := reporter.LatencyScoreRecord{
+		good:   {Score: 0.9},
+		slow:   {Score: 0.2},
+		faulty: {Score: 0.9},
+	}
+	urls := map[types.PublicKey]string{
+		good:   "https://0x01@good.example.com",
+		slow:   "https://0x02@slow.example.com",
+		faulty: "https://0x03@faulty.example.com",
+	}
+
+	recommended := recommendRelays(scores, latencies, urls, 0.9, 0)
+	if len(recommended) != 2 || recommended[0].RelayPublicKey != slow || recommended[1].RelayPublicKey != good {
+		t.Fatalf("expected the relays reaching the minimum score by decreasing score but got %+v", recommended)
+	}
+	recommended = recommendRelays(scores, latencies, urls, 0.9, 0.5)
+	if len(recommended) != 1 || recommended[0].URL != urls[good] {
+		t.Fatalf("expected only the relay reaching both minimum scores but got %+v", recommended)
+	}
+}
+
+func TestParseThresholdQueryParam(t *testing.T) {
+	for _, tc := range []struct {
+		query    string
+		expected float64
+		err      bool
+	}{
+		{query: "", expected: 0.9},
+		{query: "min_score=0.5", expected: 0.5},
+		{query: "min_score=0", expected: 0},
+		{query: "min_score=1.5", err: true},
+		{query: "min_score=high", err: true},
+	} {
+		q, err := url.ParseQuery(tc.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := parseThresholdQueryParam(q, "min_score", 0.9)
+		if tc.err {
+			if err == nil {
+				t.Errorf("query %q: expected an error but got %g", tc.query, value)
+			}
+			continue
+		}
+		if err != nil || value != tc.expected {
+			t.Errorf("query %q: expected %g but got %g (%v)", tc.query, tc.expected, value, err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `registrationError` reports why a validator registration of a batch was rejected
+type registrationError struct {
+	// Index of the registration in the submitted batch
+	Index     int             `json:"index"`
+	PublicKey types.PublicKey `json:"pubkey"`
+	Message   string          `json:"message"`
+}
+
+// `validateRegistrations` validates each of `registrations` concurrently with the configured number of workers,
+// returning the error of each invalid registration by its index in the batch, or an error if the store could not be queried
+func (s *Server) validateRegistrations(ctx context.Context, registrations []types.SignedValidatorRegistration) ([]error, error) {
+	validSignatures, err := s.verifyRegistrationSignatures(ctx, registrations)
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := make([]error, len(registrations))
+	failures := make([]error, len(registrations))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := uint(0); i < s.config.registrationWorkers(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				registration := &registrations[index]
+				currentRegistration, err := store.GetLatestValidatorRegistration(ctx, s.store, &registration.Message.Pubkey)
+				if err != nil {
+					failures[index] = err
+					continue
+				}
+				invalid[index] = s.validateRegistration(ctx, registration, currentRegistration, validSignatures[index])
+			}
+		}()
+	}
+	for i := range registrations {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range failures {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return invalid, nil
+}
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `RelayResponse` is a monitored relay with its tags, metadata and the settings the monitor collects from it with
+type RelayResponse struct {
+	types.RelayTags
+	Settings *types.RelaySettings `json:"settings"`
+	Metadata *types.RelayMetadata `json:"metadata"`
+}
+
+// `RelayMeta` is the endpoint and metadata of each relay of a response
+type RelayMeta = map[types.PublicKey]*analysis.Meta
+
+// `relayMeta` collects the endpoint and metadata of the given relays
+func (s *Server) relayMeta(ctx context.Context, relays []types.PublicKey) (RelayMeta, error) {
+	meta := make(RelayMeta)
+	for _, relay := range relays {
+		relay := relay
+		relayMeta := &analysis.Meta{}
+		settings, err := s.store.GetRelaySettings(ctx, &relay)
+		if err != nil {
+			return nil, err
+		}
+		if settings != nil {
+			relayMeta.Endpoint = settings.Endpoint
+		}
+		metadata, err := s.store.GetRelayMetadata(ctx, &relay)
+		if err != nil {
+			return nil, err
+		}
+		if metadata != nil {
+			relayMeta.RelayMetadata = *metadata
+		}
+		meta[relay] = relayMeta
+	}
+	return meta, nil
+}
+
+// `isMonitored` returns whether `relay` is reported on by the monitor
+func (s *Server) isMonitored(relay types.PublicKey) bool {
+	for _, monitoredRelay := range s.reporter.Relays() {
+		if monitoredRelay == relay {
+			return true
+		}
+	}
+	return false
+}
+
+// `relaysOf` returns the relays of `record`
+func relaysOf[V any](record map[types.PublicKey]V) []types.PublicKey {
+	relays := make([]types.PublicKey, 0, len(record))
+	for relay := range record {
+		relays = append(relays, relay)
+	}
+	return relays
+}
+
+// `parseTagFilter` returns the relays which have every tag given with the `tag` query param of the request,
+// or `nil` if the request does not filter by tag
+func (s *Server) parseTagFilter(r *http.Request) (map[types.PublicKey]struct{}, error) {
+	tags := r.URL.Query()["tag"]
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	relayTags, err := s.store.GetRelayTags(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return relaysWithTags(relayTags, tags), nil
+}
+
+func relaysWithTags(relayTags []types.RelayTags, tags []string) map[types.PublicKey]struct{} {
+	relays := make(map[types.PublicKey]struct{})
+	for _, entry := range relayTags {
+		has := make(map[string]struct{})
+		for _, tag := range entry.Tags {
+			has[tag] = struct{}{}
+		}
+		matches := true
+		for _, tag := range tags {
+			if _, ok := has[tag]; !ok {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			relays[entry.RelayPublicKey] = struct{}{}
+		}
+	}
+	return relays
+}
+
+// `filterByTags` returns the entries of `record` for `relays`, or `record` itself if `relays` is `nil`
+func filterByTags[V any](record map[types.PublicKey]V, relays map[types.PublicKey]struct{}) map[types.PublicKey]V {
+	if relays == nil {
+		return record
+	}
+	filtered := make(map[types.PublicKey]V)
+	for relay, value := range record {
+		if _, ok := relays[relay]; ok {
+			filtered[relay] = value
+		}
+	}
+	return filtered
+}
+
+func (s *Server) handleRelaysRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	relayTags, err := s.store.GetRelayTags(r.Context())
+	if err != nil {
+		logger.Errorw("could not get relay tags", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tags := make(map[types.PublicKey][]string)
+	for _, entry := range relayTags {
+		tags[entry.RelayPublicKey] = entry.Tags
+	}
+
+	relays := []RelayResponse{}
+	for _, relay := range s.reporter.Relays() {
+		relay := relay
+		relayTags := tags[relay]
+		if relayTags == nil {
+			relayTags = []string{}
+		}
+		settings, err := s.store.GetRelaySettings(r.Context(), &relay)
+		if err != nil {
+			logger.Errorw("could not get relay settings", "error", err, "relay", relay)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		metadata, err := s.store.GetRelayMetadata(r.Context(), &relay)
+		if err != nil {
+			logger.Errorw("could not get relay metadata", "error", err, "relay", relay)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		relays = append(relays, RelayResponse{
+			RelayTags: types.RelayTags{
+				RelayPublicKey: relay,
+				Tags:           relayTags,
+			},
+			Settings: settings,
+			Metadata: metadata,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(relays)
+	if err != nil {
+		logger.Errorw("could not encode relays", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// `UptimeResponse` is the uptime of a relay found by probing its status over the slot span
+type UptimeResponse struct {
+	Span           SlotSpan        `json:"span"`
+	RelayPublicKey types.PublicKey `json:"relay_public_key"`
+	*reporter.Uptime
+}
+
+// `handleRelayRequest` serves the resources of a monitored relay under `/monitor/v1/relays/{pubkey}/{resource}`
+func (s *Server) handleRelayRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, RelaysEndpoint+"/"), "/")
+	relayStr, resource, found := strings.Cut(path, "/")
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	var relay types.PublicKey
+	err := relay.UnmarshalText([]byte(relayStr))
+	if err != nil {
+		logger.Errorw("error parsing relay public key for relay request", "err", err, "path", r.URL.Path)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !s.isMonitored(relay) {
+		http.Error(w, fmt.Sprintf("relay %s is not monitored", relay), http.StatusNotFound)
+		return
+	}
+
+	switch resource {
+	case "tags":
+		post(func(w http.ResponseWriter, r *http.Request) {
+			s.handleRelayTagsRequest(w, r, &relay)
+		})(w, r)
+	case "uptime":
+		get(func(w http.ResponseWriter, r *http.Request) {
+			s.handleRelayUptimeRequest(w, r, &relay)
+		})(w, r)
+	case "annotations":
+		switch r.Method {
+		case http.MethodPost:
+			s.handleAnnotateFaultRequest(w, r, &relay)
+		default:
+			get(func(w http.ResponseWriter, r *http.Request) {
+				s.handleFaultAnnotationsRequest(w, r, &relay)
+			})(w, r)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleRelayTagsRequest(w http.ResponseWriter, r *http.Request, relay *types.PublicKey) {
+	logger := s.loggerFor(r.Context())
+
+	var tags []string
+	err := json.NewDecoder(r.Body).Decode(&tags)
+	if err != nil {
+		logger.Warn("could not decode relay tags")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = s.store.PutRelayTags(r.Context(), relay, tags)
+	if err != nil {
+		logger.Errorw("could not store relay tags", "error", err, "relay", relay)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleRelayUptimeRequest(w http.ResponseWriter, r *http.Request, relay *types.PublicKey) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for relay uptime request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	uptime, err := s.reporter.GetUptime(r.Context(), relay, span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute relay uptime", "error", err, "relay", relay)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := UptimeResponse{
+		Span:           *span,
+		RelayPublicKey: *relay,
+		Uptime:         uptime,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode relay uptime", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
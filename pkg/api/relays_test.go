@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestFilterByTags(t *testing.T) {
+	relayA := types.PublicKey{0x01}
+	relayB := types.PublicKey{0x02}
+	relayC := types.PublicKey{0x03}
+
+	relayTags := []types.RelayTags{
+		{RelayPublicKey: relayA, Tags: []string{"optimistic", "regional-eu"}},
+		{RelayPublicKey: relayB, Tags: []string{"optimistic"}},
+	}
+	record := map[types.PublicKey]int{relayA: 1, relayB: 2, relayC: 3}
+
+	filtered := filterByTags(record, relaysWithTags(relayTags, []string{"optimistic"}))
+	if len(filtered) != 2 || filtered[relayA] != 1 || filtered[relayB] != 2 {
+		t.Fatalf("unexpected relays with tag: %v", filtered)
+	}
+	filtered = filterByTags(record, relaysWithTags(relayTags, []string{"optimistic", "regional-eu"}))
+	if len(filtered) != 1 || filtered[relayA] != 1 {
+		t.Fatalf("unexpected relays with all tags: %v", filtered)
+	}
+	filtered = filterByTags(record, nil)
+	if len(filtered) != 3 {
+		t.Fatal("expected no filtering without tags")
+	}
+}
+
+func TestRelayMeta(t *testing.T) {
+	ctx := context.Background()
+	s := &Server{store: store.NewMemoryStore()}
+	described := types.PublicKey{0x01}
+	undescribed := types.PublicKey{0x02}
+
+	err := s.store.PutRelaySettings(ctx, &described, &types.RelaySettings{Endpoint: "relay.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.store.PutRelayMetadata(ctx, &described, &types.RelayMetadata{Operator: "Example", Region: "eu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := s.relayMeta(ctx, []types.PublicKey{described, undescribed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta) != 2 {
+		t.Fatalf("expected meta of both relays but got %v", meta)
+	}
+	if meta[described].Endpoint != "relay.example.com" || meta[described].Operator != "Example" || meta[described].Region != "eu" {
+		t.Fatalf("unexpected meta of described relay: %+v", meta[described])
+	}
+	if *meta[undescribed] != (analysis.Meta{}) {
+		t.Fatalf("expected empty meta of undescribed relay but got %+v", meta[undescribed])
+	}
+}
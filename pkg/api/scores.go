@@ -0,0 +1,223 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+type ScoresResponse struct {
+	Span SlotSpan    `json:"span"`
+	Data interface{} `json:"data"`
+	// Endpoint and metadata of the relays in `data`, as in the `meta` of each relay in the faults response
+	Meta RelayMeta `json:"meta,omitempty"`
+}
+
+// `parseSlotSpanFromRequest` computes the slot span for a scores request, bounded by the configured maximum
+func (s *Server) parseSlotSpanFromRequest(r *http.Request) (*SlotSpan, error) {
+	return s.parseSlotSpanWithWindow(r, s.config.spans().SlotWindow())
+}
+
+// `parseScoresSpanFromRequest` is `parseSlotSpanFromRequest` with the default window of the scoring configuration, if any
+func (s *Server) parseScoresSpanFromRequest(r *http.Request) (*SlotSpan, error) {
+	window := s.reporter.Window()
+	if window == 0 {
+		window = s.config.spans().SlotWindow()
+	}
+	return s.parseSlotSpanWithWindow(r, window)
+}
+
+func (s *Server) parseSlotSpanWithWindow(r *http.Request, defaultWindow uint64) (*SlotSpan, error) {
+	spans := s.config.spans()
+	startSlotRequest, endSlotRequest, slotSpanRequest, err := parseSpanQueryParams(r.URL.Query(), defaultWindow)
+	if err != nil {
+		return nil, err
+	}
+	startSlot, endSlot := ComputeSpanFromRequest(startSlotRequest, endSlotRequest, slotSpanRequest, s.currentSlot())
+	if endSlot < startSlot {
+		return nil, fmt.Errorf("invalid span: end slot %d is before start slot %d", endSlot, startSlot)
+	}
+	if endSlot-startSlot > spans.MaxSlots() {
+		return nil, fmt.Errorf("invalid span: requested span of %d slots exceeds the maximum of %d slots", endSlot-startSlot, spans.MaxSlots())
+	}
+	return &SlotSpan{
+		Start: startSlot,
+		End:   endSlot,
+	}, nil
+}
+
+// `parseLambdaQueryParam` parses the optional `lambda` query param overriding the decay per slot of the scores
+func parseLambdaQueryParam(q url.Values) (*float64, error) {
+	lambda, err := parseFloat64QueryParam(q, "lambda")
+	if err != nil || lambda == nil {
+		return nil, err
+	}
+	err = reporter.ValidateLambda(*lambda)
+	if err != nil {
+		return nil, err
+	}
+	return lambda, nil
+}
+
+// `parseRelayFromPath` returns the relay public key following `prefix` in the request path, if any
+func parseRelayFromPath(r *http.Request, prefix string) (*types.PublicKey, error) {
+	relayStr := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if relayStr == "" {
+		return nil, nil
+	}
+	var relay types.PublicKey
+	err := relay.UnmarshalText([]byte(relayStr))
+	if err != nil {
+		return nil, err
+	}
+	return &relay, nil
+}
+
+func (s *Server) handleLatencyScoresRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	relay, err := parseRelayFromPath(r, GetLatencyScoresEndpoint)
+	if err != nil {
+		logger.Errorw("error parsing relay public key for latency scores request", "err", err, "path", r.URL.Path)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span, err := s.parseScoresSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for latency scores request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tagged, err := s.parseTagFilter(r)
+	if err != nil {
+		logger.Errorw("could not filter latency scores by tag", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var data interface{}
+	var relays []types.PublicKey
+	if relay != nil {
+		data, err = s.reporter.GetLatencyScore(r.Context(), relay, span.Start, span.End)
+		relays = []types.PublicKey{*relay}
+	} else {
+		var scores reporter.LatencyScoreRecord
+		scores, err = s.reporter.GetLatencyScores(r.Context(), span.Start, span.End)
+		scores = filterByTags(scores, tagged)
+		data = scores
+		relays = relaysOf(scores)
+	}
+	if err != nil {
+		logger.Errorw("could not compute latency scores", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	meta, err := s.relayMeta(r.Context(), relays)
+	if err != nil {
+		logger.Errorw("could not get relay metadata", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := ScoresResponse{
+		Span: *span,
+		Data: data,
+		Meta: meta,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode latency scores", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleOverallScoresRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	relay, err := parseRelayFromPath(r, GetOverallScoresEndpoint)
+	if err != nil {
+		logger.Errorw("error parsing relay public key for overall scores request", "err", err, "path", r.URL.Path)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span, err := s.parseScoresSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for overall scores request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	lambda, err := parseLambdaQueryParam(r.URL.Query())
+	if err != nil {
+		logger.Errorw("error parsing query param for overall scores request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tagged, err := s.parseTagFilter(r)
+	if err != nil {
+		logger.Errorw("could not filter overall scores by tag", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var data interface{}
+	var relays []types.PublicKey
+	if relay != nil {
+		if lambda != nil {
+			data, err = s.reporter.GetOverallScoreWithLambda(r.Context(), relay, span.Start, span.End, *lambda)
+		} else {
+			data, err = s.reporter.GetOverallScore(r.Context(), relay, span.Start, span.End)
+		}
+		relays = []types.PublicKey{*relay}
+	} else {
+		var scores reporter.OverallScoreRecord
+		if lambda != nil {
+			scores, err = s.reporter.GetOverallScoresWithLambda(r.Context(), span.Start, span.End, *lambda)
+		} else {
+			scores, err = s.reporter.GetOverallScores(r.Context(), span.Start, span.End)
+		}
+		scores = filterByTags(scores, tagged)
+		data = scores
+		relays = relaysOf(scores)
+	}
+	if err != nil {
+		logger.Errorw("could not compute overall scores", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	meta, err := s.relayMeta(r.Context(), relays)
+	if err != nil {
+		logger.Errorw("could not get relay metadata", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := ScoresResponse{
+		Span: *span,
+		Data: data,
+		Meta: meta,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode overall scores", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"net/http/pprof"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -13,6 +15,8 @@ import (
 	"github.com/ralexstokes/relay-monitor/pkg/consensus"
 	"github.com/ralexstokes/relay-monitor/pkg/crypto"
 	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
 	"github.com/ralexstokes/relay-monitor/pkg/store"
 	"github.com/ralexstokes/relay-monitor/pkg/types"
 	"go.uber.org/zap"
@@ -21,21 +25,45 @@ import (
 const (
 	methodNotSupported              = "method not supported"
 	GetFaultEndpoint                = "/monitor/v1/faults"
+	GetFaultRecordsEndpoint         = "/monitor/v1/faults/records"
 	RegisterValidatorEndpoint       = "/eth/v1/builder/validators"
 	PostAuctionTranscriptEndpoint   = "/monitor/v1/transcript"
-	DefaultEpochSpanForFaultsWindow = 256
+	GetRegistrationCoverageEndpoint = "/monitor/v1/registrations"
+	GetProposerCoverageEndpoint     = "/monitor/v1/metrics/registrations/coverage"
+	GetLatencyScoresEndpoint        = "/monitor/v1/scores/latency"
+	GetOverallScoresEndpoint        = "/monitor/v1/scores/overall"
+	GetCensorshipReportEndpoint     = "/monitor/v1/reports/censorship"
+	GetSpecEndpoint                 = "/monitor/v1/spec"
+	GetBuildersEndpoint             = "/monitor/v1/builders"
+	GetProposersEndpoint            = "/monitor/v1/proposers"
+	GetBidValueStatsEndpoint        = "/monitor/v1/stats/bid_values"
+	GetTailEndpoint                 = "/monitor/v1/tail"
+	GetWinRateStatsEndpoint         = "/monitor/v1/stats/win_rate"
+	GetUniqueBlockStatsEndpoint     = "/monitor/v1/stats/unique_blocks"
+	GetEpochStatsEndpoint           = "/monitor/v1/stats/epochs"
+	GetDebugSlotsEndpoint           = "/monitor/v1/debug/slots"
+	GetSlotsEndpoint                = "/monitor/v1/slots"
+	RelaysEndpoint                  = "/monitor/v1/relays"
+	GetSLAEndpoint                  = "/monitor/v1/sla"
+	GetRecommendEndpoint            = "/monitor/v1/recommend"
+	GetCompareEndpoint              = "/monitor/v1/compare"
+	GetBidsEndpoint                 = "/monitor/v1/bids"
+	PostReanalyzeEndpoint           = "/monitor/v1/reanalyze"
+	MetricsEndpoint                 = "/metrics"
+	DashboardSummaryEndpoint        = "/data/summary.json"
+	DashboardRelayEndpoint          = "/data/relay"
 )
 
-type Config struct {
-	Host string `yaml:"host"`
-	Port uint16 `yaml:"port"`
-}
-
 type Span struct {
 	Start types.Epoch `json:"start_epoch,string"`
 	End   types.Epoch `json:"end_epoch,string"`
 }
 
+type SlotSpan struct {
+	Start types.Slot `json:"start_slot,string"`
+	End   types.Slot `json:"end_slot,string"`
+}
+
 type FaultsResponse struct {
 	Span                 Span `json:"span"`
 	analysis.FaultRecord `json:"data"`
@@ -45,29 +73,40 @@ type Server struct {
 	config *Config
 	logger *zap.Logger
 
-	analyzer        *analysis.Analyzer
-	events          chan<- data.Event
+	analyzer *analysis.Analyzer
+	reporter *reporter.Reporter
+	events   *data.Queue
+	// validator registrations to forward to the relays, if enabled
+	registrations   chan<- []types.SignedValidatorRegistration
 	clock           *consensus.Clock
 	store           store.Storer
 	consensusClient *consensus.Client
+	// transcripts accepted in recent slots
+	transcripts *transcriptFilter
+	// verifier of the signatures of validator registrations
+	verifier *crypto.BatchVerifier
 }
 
-func New(config *Config, logger *zap.Logger, analyzer *analysis.Analyzer, events chan<- data.Event, clock *consensus.Clock, store store.Storer, consensusClient *consensus.Client) *Server {
+func New(config *Config, logger *zap.Logger, analyzer *analysis.Analyzer, reporter *reporter.Reporter, events *data.Queue, registrations chan<- []types.SignedValidatorRegistration, clock *consensus.Clock, store store.Storer, consensusClient *consensus.Client) *Server {
 	return &Server{
 		config:          config,
 		logger:          logger,
 		analyzer:        analyzer,
+		reporter:        reporter,
 		events:          events,
+		registrations:   registrations,
 		clock:           clock,
 		store:           store,
 		consensusClient: consensusClient,
+		transcripts:     newTranscriptFilter(),
+		verifier:        crypto.NewBatchVerifier(),
 	}
 }
 
-// `computeSpan` ensures that `startEpoch` and `endEpoch` cover a "sensible" span where:
+// `ComputeSpanFromRequest` ensures that `startEpoch` and `endEpoch` cover a "sensible" span where:
 //   - `endEpoch` - `startEpoch` == `span` such that `startEpoch` >= 0 and `endEpoch` <= `math.MaxUint64`
 //     (so that the span is smaller than requested against the boundaries)
-func computeSpanFromRequest(startEpochRequest, endEpochRequest *types.Epoch, targetSpan uint64, currentEpoch types.Epoch) (types.Epoch, types.Epoch) {
+func ComputeSpanFromRequest(startEpochRequest, endEpochRequest *types.Epoch, targetSpan uint64, currentEpoch types.Epoch) (types.Epoch, types.Epoch) {
 	var startEpoch types.Epoch
 	endEpoch := currentEpoch
 
@@ -102,58 +141,81 @@ func computeSpanFromRequest(startEpochRequest, endEpochRequest *types.Epoch, tar
 	return startEpoch, endEpoch
 }
 
-func (s *Server) currentEpoch() types.Epoch {
+func (s *Server) currentSlot() types.Slot {
 	now := time.Now().Unix()
-	slot := s.clock.CurrentSlot(now)
-	return s.clock.EpochForSlot(slot)
+	return s.clock.CurrentSlot(now)
 }
 
-func (s *Server) handleFaultsRequest(w http.ResponseWriter, r *http.Request) {
-	logger := s.logger.Sugar()
+func (s *Server) currentEpoch() types.Epoch {
+	return s.clock.EpochForSlot(s.currentSlot())
+}
 
-	q := r.URL.Query()
+func parseUint64QueryParam(q url.Values, key string) (*uint64, error) {
+	valueStr := q.Get(key)
+	if valueStr == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseUint(valueStr, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
 
-	startEpochStr := q.Get("start")
-	var startEpochRequest *types.Epoch
-	if startEpochStr != "" {
-		startEpochValue, err := strconv.ParseUint(startEpochStr, 10, 64)
-		if err != nil {
-			logger.Errorw("error parsing query param for faults request", "err", err, "startEpoch", startEpochStr)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		epoch := types.Epoch(startEpochValue)
-		startEpochRequest = &epoch
+func parseFloat64QueryParam(q url.Values, key string) (*float64, error) {
+	valueStr := q.Get(key)
+	if valueStr == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return nil, err
 	}
+	return &value, nil
+}
 
-	endEpochStr := q.Get("end")
-	var endEpochRequest *types.Epoch
-	if endEpochStr != "" {
-		endEpochValue, err := strconv.ParseUint(endEpochStr, 10, 64)
-		if err != nil {
-			logger.Errorw("error parsing query param for faults request", "err", err, "endEpoch", endEpochStr)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		epoch := types.Epoch(endEpochValue)
-		endEpochRequest = &epoch
+// `parseSpanQueryParams` parses the optional `start`, `end` and `window` query params shared by endpoints serving data over a span
+func parseSpanQueryParams(q url.Values, defaultWindow uint64) (*uint64, *uint64, uint64, error) {
+	start, err := parseUint64QueryParam(q, "start")
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	end, err := parseUint64QueryParam(q, "end")
+	if err != nil {
+		return nil, nil, 0, err
 	}
+	window, err := parseUint64QueryParam(q, "window")
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if window == nil {
+		return start, end, defaultWindow, nil
+	}
+	return start, end, *window, nil
+}
 
-	epochSpanRequest := types.Epoch(DefaultEpochSpanForFaultsWindow)
-	epochSpanForFaultsWindow := q.Get("window")
-	if epochSpanForFaultsWindow != "" {
-		epochSpanValue, err := strconv.ParseUint(epochSpanForFaultsWindow, 10, 64)
-		if err != nil {
-			logger.Errorw("error parsing query param for faults request", "err", err, "epochSpan", epochSpanForFaultsWindow)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		epochSpanRequest = types.Epoch(epochSpanValue)
+func (s *Server) handleFaultsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	q := r.URL.Query()
+
+	startEpochRequest, endEpochRequest, epochSpanRequest, err := parseSpanQueryParams(q, s.config.spans().EpochWindow())
+	if err != nil {
+		logger.Errorw("error parsing query param for faults request", "err", err, "query", q)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	currentEpoch := s.currentEpoch()
-	startEpoch, endEpoch := computeSpanFromRequest(startEpochRequest, endEpochRequest, epochSpanRequest, currentEpoch)
-	faults := s.analyzer.GetFaults(startEpoch, endEpoch)
+	startEpoch, endEpoch := ComputeSpanFromRequest(startEpochRequest, endEpochRequest, epochSpanRequest, currentEpoch)
+	tagged, err := s.parseTagFilter(r)
+	if err != nil {
+		logger.Errorw("could not filter faults by tag", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	faults := filterByTags(s.analyzer.GetFaults(startEpoch, endEpoch), tagged)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -167,16 +229,145 @@ func (s *Server) handleFaultsRequest(w http.ResponseWriter, r *http.Request) {
 	}
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	err := encoder.Encode(response)
+	err = encoder.Encode(response)
 	if err != nil {
 		logger.Errorw("could not encode relay faults", "error", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
+// `BidAnalysesResponse` is the analyses of the bids of each relay over a span of slots, with the annotations their operators
+// attached to the faults
+type BidAnalysesResponse struct {
+	Span        SlotSpan                                    `json:"span"`
+	Data        map[types.PublicKey][]types.BidAnalysis     `json:"data"`
+	Annotations map[types.PublicKey][]types.FaultAnnotation `json:"annotations"`
+}
+
+func (s *Server) handleFaultRecordsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for fault records request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tagged, err := s.parseTagFilter(r)
+	if err != nil {
+		logger.Errorw("could not filter fault records by tag", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ruleset := r.URL.Query().Get("ruleset")
+
+	analyses := make(map[types.PublicKey][]types.BidAnalysis)
+	annotations := make(map[types.PublicKey][]types.FaultAnnotation)
+	for _, relay := range s.reporter.Relays() {
+		relay := relay
+		var relayAnalyses []types.BidAnalysis
+		if ruleset == "" {
+			relayAnalyses, err = s.store.GetBidAnalyses(r.Context(), &relay, span.Start, span.End)
+		} else {
+			relayAnalyses, err = s.store.GetRulesetBidAnalyses(r.Context(), &relay, span.Start, span.End, ruleset)
+		}
+		if err != nil {
+			logger.Errorw("could not load bid analyses", "error", err, "relay", relay)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if relayAnalyses == nil {
+			relayAnalyses = []types.BidAnalysis{}
+		}
+		analyses[relay] = relayAnalyses
+		relayAnnotations, err := s.store.GetFaultAnnotations(r.Context(), &relay, span.Start, span.End)
+		if err != nil {
+			logger.Errorw("could not load fault annotations", "error", err, "relay", relay)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(relayAnnotations) > 0 {
+			annotations[relay] = relayAnnotations
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := BidAnalysesResponse{
+		Span:        *span,
+		Data:        filterByTags(analyses, tagged),
+		Annotations: filterByTags(annotations, tagged),
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode fault records", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleProposerCoverageRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	coverage, err := s.analyzer.GetProposerRegistrationCoverage(r.Context(), s.currentSlot())
+	if err != nil {
+		logger.Errorw("could not compute registration coverage of upcoming proposers", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(coverage)
+	if err != nil {
+		logger.Errorw("could not encode registration coverage of upcoming proposers", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleRegistrationCoverageRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	coverage := s.analyzer.GetRegistrationCoverage()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(coverage)
+	if err != nil {
+		logger.Errorw("could not encode registration coverage", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleCensorshipReportRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	report := s.analyzer.GetCensorshipReport()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(report)
+	if err != nil {
+		logger.Errorw("could not encode censorship report", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func (s *Server) validateRegistrationTimestamp(registration, currentRegistration *types.SignedValidatorRegistration) error {
 	timestamp := registration.Message.Timestamp
-	deadline := time.Now().Add(10 * time.Second).Unix()
+	deadline := time.Now().Add(s.config.registrationTimestampTolerance()).Unix()
 	if timestamp >= uint64(deadline) {
 		return fmt.Errorf("invalid registration: too far in future, %+v", registration)
 	}
@@ -191,21 +382,25 @@ func (s *Server) validateRegistrationTimestamp(registration, currentRegistration
 	return nil
 }
 
-func (s *Server) validateRegistrationSignature(registration *types.SignedValidatorRegistration) error {
-	msg := registration.Message
-	valid, err := crypto.VerifySignature(msg, s.consensusClient.SignatureDomainForBuilder(), msg.Pubkey[:], registration.Signature[:])
-	if err != nil {
-		return err
-	}
-	if !valid {
-		return fmt.Errorf("signature invalid for validator registration %+v", registration)
+// `verifyRegistrationSignatures` returns whether the signature of each of `registrations` is valid, verifying them as a batch
+func (s *Server) verifyRegistrationSignatures(ctx context.Context, registrations []types.SignedValidatorRegistration) ([]bool, error) {
+	domain := s.consensusClient.SignatureDomainForBuilder()
+	messages := make([]crypto.SignedMessage, 0, len(registrations))
+	for _, registration := range registrations {
+		msg := registration.Message
+		messages = append(messages, crypto.SignedMessage{
+			Message:   msg,
+			Domain:    domain,
+			PublicKey: msg.Pubkey[:],
+			Signature: registration.Signature[:],
+		})
 	}
-	return nil
+	return s.verifier.VerifyAll(ctx, messages)
 }
 
-func (s *Server) validateRegistrationValidatorStatus(registration *types.SignedValidatorRegistration) error {
+func (s *Server) validateRegistrationValidatorStatus(ctx context.Context, registration *types.SignedValidatorRegistration) error {
 	publicKey := registration.Message.Pubkey
-	status, err := s.consensusClient.GetValidatorStatus(&publicKey)
+	status, err := s.consensusClient.GetValidatorStatus(ctx, &publicKey)
 	if err != nil {
 		return err
 	}
@@ -218,18 +413,17 @@ func (s *Server) validateRegistrationValidatorStatus(registration *types.SignedV
 	}
 }
 
-func (s *Server) validateRegistration(registration, currentRegistration *types.SignedValidatorRegistration) error {
+func (s *Server) validateRegistration(ctx context.Context, registration, currentRegistration *types.SignedValidatorRegistration, validSignature bool) error {
 	err := s.validateRegistrationTimestamp(registration, currentRegistration)
 	if err != nil {
 		return err
 	}
 
-	err = s.validateRegistrationSignature(registration)
-	if err != nil {
-		return err
+	if !validSignature {
+		return fmt.Errorf("signature invalid for validator registration %+v", registration)
 	}
 
-	err = s.validateRegistrationValidatorStatus(registration)
+	err = s.validateRegistrationValidatorStatus(ctx, registration)
 	if err != nil {
 		return err
 	}
@@ -240,11 +434,13 @@ func (s *Server) validateRegistration(registration, currentRegistration *types.S
 type apiError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	// Errors of each invalid validator registration of a batch
+	Errors []registrationError `json:"errors,omitempty"`
 }
 
 func (s *Server) handleRegisterValidator(w http.ResponseWriter, r *http.Request) {
-	logger := s.logger.Sugar()
-	ctx := context.Background()
+	logger := s.loggerFor(r.Context())
+	ctx := r.Context()
 
 	var registrations []types.SignedValidatorRegistration
 	err := json.NewDecoder(r.Body).Decode(&registrations)
@@ -254,44 +450,65 @@ func (s *Server) handleRegisterValidator(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	for _, registration := range registrations {
-		currentRegistration, err := store.GetLatestValidatorRegistration(ctx, s.store, &registration.Message.Pubkey)
+	validationErrors, err := s.validateRegistrations(ctx, registrations)
+	if err != nil {
+		logger.Warnw("could not validate validator registrations", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	accepted := make([]types.SignedValidatorRegistration, 0, len(registrations))
+	var rejected []registrationError
+	for i, err := range validationErrors {
 		if err != nil {
-			logger.Warnw("could not get registrations for validator", "error", err, "registration", registration)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+			logger.Warnw("invalid validator registration in batch", "registration", registrations[i], "error", err)
+			rejected = append(rejected, registrationError{
+				Index:     i,
+				PublicKey: registrations[i].Message.Pubkey,
+				Message:   err.Error(),
+			})
+			continue
 		}
-		err = s.validateRegistration(&registration, currentRegistration)
-		if err != nil {
-			logger.Warnw("invalid validator registration in batch", "registration", registration, "error", err)
-			w.WriteHeader(http.StatusBadRequest)
-			w.Header().Set("Content-Type", "application/json")
-			response := apiError{
-				Code:    http.StatusBadRequest,
-				Message: err.Error(),
-			}
-			encoder := json.NewEncoder(w)
-			err := encoder.Encode(response)
-			if err != nil {
-				logger.Warnw("could not send API error", "error", err)
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
+		accepted = append(accepted, registrations[i])
+	}
+
+	if len(accepted) > 0 {
+		payload := data.ValidatorRegistrationEvent{
+			Registrations: accepted,
+		}
+		s.events.Push(data.Event{Payload: payload})
+
+		if s.registrations != nil {
+			select {
+			case s.registrations <- accepted:
+			default:
+				logger.Warnw("dropping validator registrations to forward to relays as the queue is full", "count", len(accepted))
 			}
-			return
 		}
 	}
 
-	payload := data.ValidatorRegistrationEvent{
-		Registrations: registrations,
+	if len(rejected) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
 	}
-	// TODO what if this is full?
-	s.events <- data.Event{Payload: payload}
 
-	w.WriteHeader(http.StatusOK)
+	// NOTE: the valid registrations of the batch are accepted even though the batch is reported as invalid
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	response := apiError{
+		Code:    http.StatusBadRequest,
+		Message: fmt.Sprintf("%d of %d validator registrations are invalid: %s", len(rejected), len(registrations), rejected[0].Message),
+		Errors:  rejected,
+	}
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Warnw("could not send API error", "error", err)
+	}
 }
 
 func (s *Server) handleAuctionTranscript(w http.ResponseWriter, r *http.Request) {
-	logger := s.logger.Sugar()
+	logger := s.loggerFor(r.Context())
 
 	var transcript types.AuctionTranscript
 	err := json.NewDecoder(r.Body).Decode(&transcript)
@@ -303,11 +520,20 @@ func (s *Server) handleAuctionTranscript(w http.ResponseWriter, r *http.Request)
 
 	logger.Debugw("got auction transcript", "data", transcript)
 
+	transcriptErr := s.admitTranscript(r.Context(), &transcript)
+	if transcriptErr != nil {
+		logger.Warnw("rejecting auction transcript", "error", transcriptErr, "reason", transcriptErr.reason)
+		if transcriptErr.reason != "" {
+			metrics.InvalidTranscripts.WithLabelValues(transcriptErr.reason).Inc()
+		}
+		http.Error(w, transcriptErr.Error(), transcriptErr.code)
+		return
+	}
+
 	payload := data.AuctionTranscriptEvent{
 		Transcript: &transcript,
 	}
-	// TODO what if this is full?
-	s.events <- data.Event{Payload: payload}
+	s.events.Push(data.Event{Payload: payload})
 
 	w.WriteHeader(http.StatusOK)
 }
@@ -320,9 +546,47 @@ func (s *Server) Run(ctx context.Context) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", get(s.handleFaultsRequest))
 	mux.HandleFunc(GetFaultEndpoint, get(s.handleFaultsRequest))
+	mux.HandleFunc(GetFaultRecordsEndpoint, get(s.handleFaultRecordsRequest))
 	mux.HandleFunc(RegisterValidatorEndpoint, post(s.handleRegisterValidator))
 	mux.HandleFunc(PostAuctionTranscriptEndpoint, post(s.handleAuctionTranscript))
-	return http.ListenAndServe(host, mux)
+	mux.HandleFunc(GetRegistrationCoverageEndpoint, get(s.handleRegistrationCoverageRequest))
+	mux.HandleFunc(GetProposerCoverageEndpoint, get(s.handleProposerCoverageRequest))
+	mux.HandleFunc(GetLatencyScoresEndpoint, get(s.handleLatencyScoresRequest))
+	mux.HandleFunc(GetLatencyScoresEndpoint+"/", get(s.handleLatencyScoresRequest))
+	mux.HandleFunc(GetOverallScoresEndpoint, get(s.handleOverallScoresRequest))
+	mux.HandleFunc(GetOverallScoresEndpoint+"/", get(s.handleOverallScoresRequest))
+	mux.HandleFunc(GetSLAEndpoint+"/", get(s.handleSLARequest))
+	mux.HandleFunc(GetRecommendEndpoint, get(s.handleRecommendRequest))
+	mux.HandleFunc(GetCompareEndpoint, get(s.handleCompareRequest))
+	mux.HandleFunc(GetBidsEndpoint, get(s.handleBidsRequest))
+	mux.HandleFunc(GetCensorshipReportEndpoint, get(s.handleCensorshipReportRequest))
+	mux.HandleFunc(GetSpecEndpoint, get(s.handleSpecRequest))
+	mux.HandleFunc(GetBuildersEndpoint+"/", get(s.handleBuildersRequest))
+	mux.HandleFunc(GetProposersEndpoint+"/", get(s.handleProposersRequest))
+	mux.HandleFunc(GetBidValueStatsEndpoint, get(s.handleBidValueStatsRequest))
+	mux.HandleFunc(GetTailEndpoint, get(s.handleTailRequest))
+	mux.HandleFunc(GetWinRateStatsEndpoint, get(s.handleWinRateStatsRequest))
+	mux.HandleFunc(GetUniqueBlockStatsEndpoint, get(s.handleUniqueBlockStatsRequest))
+	mux.HandleFunc(GetEpochStatsEndpoint, get(s.handleEpochStatsRequest))
+	mux.HandleFunc(DashboardSummaryEndpoint, get(s.handleDashboardSummaryRequest))
+	mux.HandleFunc(DashboardRelayEndpoint+"/", get(s.handleDashboardRelayRequest))
+	mux.HandleFunc(GetDebugSlotsEndpoint+"/", get(s.handleSlotDebugRequest))
+	mux.HandleFunc(GetSlotsEndpoint+"/", get(s.handleSlotsRequest))
+	mux.HandleFunc(RelaysEndpoint, get(s.handleRelaysRequest))
+	mux.HandleFunc(RelaysEndpoint+"/", s.handleRelayRequest)
+	mux.Handle(MetricsEndpoint, metrics.Handler())
+	if s.config.Reanalysis {
+		mux.HandleFunc(PostReanalyzeEndpoint, post(s.handleReanalyzeRequest))
+	}
+	if s.config.Profiling {
+		logger.Info("serving profiling endpoints")
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	return http.ListenAndServe(host, s.instrument(mux))
 }
 
 func get(handler http.HandlerFunc) http.HandlerFunc {
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// Rules of the service level a relay is evaluated against
+const (
+	MaxFaultRateRule   = "max_fault_rate"
+	MinBidDeliveryRule = "min_bid_delivery"
+	MaxLatencyRule     = "max_latency"
+)
+
+// `SLARuleResult` is the evaluation of a relay against a rule of the service level
+type SLARuleResult struct {
+	Rule string `json:"rule"`
+	// Bound set by the rule, in milliseconds for `max_latency`
+	Threshold float64 `json:"threshold"`
+	// Value of the relay over the span, in milliseconds for `max_latency`
+	Value float64 `json:"value"`
+	Pass  bool    `json:"pass"`
+}
+
+// `SLAResponse` is the evaluation of a relay against each rule of the configured service level
+type SLAResponse struct {
+	Span SlotSpan `json:"span"`
+	// Epochs the fault rate and bid delivery cover, those of the slots of `Span`
+	EpochSpan      Span            `json:"epoch_span"`
+	RelayPublicKey types.PublicKey `json:"relay_public_key"`
+	// Whether the relay passes every rule
+	Pass  bool            `json:"pass"`
+	Rules []SLARuleResult `json:"rules"`
+}
+
+// `bidRate` is the share of the slots where any relay offered a bid in which the relay with `faults` offered a bid
+func bidRate(faults *analysis.FaultStats) float64 {
+	slots := faults.TotalBids + faults.NoBids
+	if slots == 0 {
+		return 0
+	}
+	return float64(faults.TotalBids) / float64(slots)
+}
+
+// `evaluateSLA` evaluates the relay with the given faults and latency against each rule of `config` which is set
+// NOTE: a relay without bids has no faults but delivered no bids
+func evaluateSLA(config *SLAConfig, faults *analysis.FaultStats, latency *reporter.LatencyScore) []SLARuleResult {
+	results := []SLARuleResult{}
+	if config.MaxFaultRate != nil {
+		var faultRate float64
+		if faults.TotalBids > 0 {
+			faulty := faults.ConsensusInvalidBids + faults.IgnoredPreferencesBids
+			faultRate = float64(faulty) / float64(faults.TotalBids)
+		}
+		results = append(results, SLARuleResult{
+			Rule:      MaxFaultRateRule,
+			Threshold: *config.MaxFaultRate,
+			Value:     faultRate,
+			Pass:      faultRate <= *config.MaxFaultRate,
+		})
+	}
+	if config.MinBidDelivery != nil {
+		delivery := bidRate(faults)
+		results = append(results, SLARuleResult{
+			Rule:      MinBidDeliveryRule,
+			Threshold: *config.MinBidDelivery,
+			Value:     delivery,
+			Pass:      delivery > 0 && delivery >= *config.MinBidDelivery,
+		})
+	}
+	if config.MaxLatency != 0 {
+		threshold := config.MaxLatency.Milliseconds()
+		results = append(results, SLARuleResult{
+			Rule:      MaxLatencyRule,
+			Threshold: float64(threshold),
+			Value:     float64(latency.P95),
+			Pass:      latency.P95 <= threshold,
+		})
+	}
+	return results
+}
+
+func (s *Server) handleSLARequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	config := s.config.sla()
+	if !config.hasRules() {
+		http.Error(w, "no SLA is configured", http.StatusNotFound)
+		return
+	}
+
+	relay, err := parseRelayFromPath(r, GetSLAEndpoint)
+	if err != nil {
+		logger.Errorw("error parsing relay public key for SLA request", "err", err, "path", r.URL.Path)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if relay == nil {
+		http.Error(w, "missing relay public key", http.StatusBadRequest)
+		return
+	}
+	if !s.isMonitored(*relay) {
+		http.Error(w, fmt.Sprintf("relay %s is not monitored", relay), http.StatusNotFound)
+		return
+	}
+
+	span, err := s.parseSlotSpanWithWindow(r, config.window(s.config.spans()))
+	if err != nil {
+		logger.Errorw("error parsing query param for SLA request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	epochSpan := Span{
+		Start: s.clock.EpochForSlot(span.Start),
+		End:   s.clock.EpochForSlot(span.End),
+	}
+
+	faults := &analysis.FaultStats{}
+	if relayFaults, ok := s.analyzer.GetFaults(epochSpan.Start, epochSpan.End)[*relay]; ok {
+		faults = relayFaults.Stats
+	}
+	latency, err := s.reporter.GetLatencyScore(r.Context(), relay, span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute latency score for SLA", "error", err, "relay", relay)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rules := evaluateSLA(config, faults, latency)
+	pass := true
+	for _, rule := range rules {
+		pass = pass && rule.Pass
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := SLAResponse{
+		Span:           *span,
+		EpochSpan:      epochSpan,
+		RelayPublicKey: *relay,
+		Pass:           pass,
+		Rules:          rules,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode SLA evaluation", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+)
+
+func TestEvaluateSLA(t *testing.T) {
+	maxFaultRate := 0.1
+	minBidDelivery := 0.9
+	config := &SLAConfig{
+		MaxFaultRate:   &maxFaultRate,
+		MinBidDelivery: &minBidDelivery,
+		MaxLatency:     500 * time.Millisecond,
+	}
+	for _, tc := range []struct {
+		name    string
+		faults  *analysis.FaultStats
+		latency *reporter.LatencyScore
+		pass    map[string]bool
+	}{
+		{
+			name:    "within service level",
+			faults:  &analysis.FaultStats{TotalBids: 95, ConsensusInvalidBids: 5, NoBids: 5},
+			latency: &reporter.LatencyScore{P95: 400},
+			pass:    map[string]bool{MaxFaultRateRule: true, MinBidDeliveryRule: true, MaxLatencyRule: true},
+		},
+		{
+			name:    "faulty, missing and slow bids",
+			faults:  &analysis.FaultStats{TotalBids: 80, ConsensusInvalidBids: 8, IgnoredPreferencesBids: 8, NoBids: 20},
+			latency: &reporter.LatencyScore{P95: 600},
+			pass:    map[string]bool{MaxFaultRateRule: false, MinBidDeliveryRule: false, MaxLatencyRule: false},
+		},
+		{
+			name:    "no bids",
+			faults:  &analysis.FaultStats{},
+			latency: &reporter.LatencyScore{},
+			pass:    map[string]bool{MaxFaultRateRule: true, MinBidDeliveryRule: false, MaxLatencyRule: true},
+		},
+	} {
+		results := evaluateSLA(config, tc.faults, tc.latency)
+		if len(results) != len(tc.pass) {
+			t.Fatalf("%s: expected %d rules but got %d", tc.name, len(tc.pass), len(results))
+		}
+		for _, result := range results {
+			if result.Pass != tc.pass[result.Rule] {
+				t.Errorf("%s: expected rule %s to pass %t but got %t with value %g", tc.name, result.Rule, tc.pass[result.Rule], result.Pass, result.Value)
+			}
+		}
+	}
+
+	results := evaluateSLA(&SLAConfig{MaxLatency: time.Second}, &analysis.FaultStats{}, &reporter.LatencyScore{})
+	if len(results) != 1 || results[0].Rule != MaxLatencyRule {
+		t.Errorf("expected only the configured rule to be evaluated but got %+v", results)
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+)
+
+type BestBidsResponse struct {
+	Span SlotSpan           `json:"span"`
+	Bids []reporter.BestBid `json:"bids"`
+}
+
+func (s *Server) handleSlotsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, GetSlotsEndpoint+"/"), "/")
+	switch path {
+	case "best_bids":
+		s.handleBestBidsRequest(w, r)
+		return
+	case "upcoming":
+		s.handleUpcomingSlotsRequest(w, r)
+		return
+	}
+
+	slotStr, resource, found := strings.Cut(path, "/")
+	if !found || (resource != "best_bid" && resource != "acceptances") {
+		http.NotFound(w, r)
+		return
+	}
+	slot, err := strconv.ParseUint(slotStr, 10, 64)
+	if err != nil {
+		logger.Errorw("error parsing slot for slot request", "err", err, "path", r.URL.Path)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if resource == "acceptances" {
+		s.handleSlotAcceptancesRequest(w, r, slot)
+		return
+	}
+
+	bestBid, err := s.reporter.GetBestBid(r.Context(), slot)
+	if err != nil {
+		logger.Errorw("could not compute best bid", "error", err, "slot", slot)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if bestBid == nil {
+		http.Error(w, fmt.Sprintf("no bids for slot %d", slot), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(bestBid)
+	if err != nil {
+		logger.Errorw("could not encode best bid", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleBestBidsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for best bids request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bestBids, err := s.reporter.GetBestBids(r.Context(), span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute best bids", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := BestBidsResponse{
+		Span: *span,
+		Bids: bestBids,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode best bids", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleUpcomingSlotsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	upcomingSlots := s.analyzer.GetUpcomingSlots(s.currentSlot())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(upcomingSlots)
+	if err != nil {
+		logger.Errorw("could not encode upcoming slots", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
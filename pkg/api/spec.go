@@ -0,0 +1,23 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// `spec` is the OpenAPI document describing the endpoints of the `Server`
+//
+//go:embed openapi.yaml
+var spec []byte
+
+func (s *Server) handleSpecRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write(spec)
+	if err != nil {
+		logger.Errorw("could not send API spec", "error", err)
+	}
+}
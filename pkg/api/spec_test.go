@@ -0,0 +1,86 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecCoversEndpoints(t *testing.T) {
+	var document struct {
+		Paths map[string]interface{} `yaml:"paths"`
+	}
+	err := yaml.Unmarshal(spec, &document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, endpoint := range []string{
+		GetFaultEndpoint,
+		GetFaultRecordsEndpoint,
+		RegisterValidatorEndpoint,
+		PostAuctionTranscriptEndpoint,
+		GetRegistrationCoverageEndpoint,
+		GetProposerCoverageEndpoint,
+		GetLatencyScoresEndpoint,
+		GetLatencyScoresEndpoint + "/{pubkey}",
+		GetOverallScoresEndpoint,
+		GetOverallScoresEndpoint + "/{pubkey}",
+		GetCensorshipReportEndpoint,
+		GetSpecEndpoint,
+		GetBuildersEndpoint + "/{pubkey}/relays",
+		GetBuildersEndpoint + "/{pubkey}/stats",
+		GetProposersEndpoint + "/{pubkey}/bids",
+		GetProposersEndpoint + "/{pubkey}/acceptances",
+		GetBidValueStatsEndpoint,
+		GetTailEndpoint,
+		GetWinRateStatsEndpoint,
+		GetUniqueBlockStatsEndpoint,
+		GetSlotsEndpoint + "/{slot}/best_bid",
+		GetSlotsEndpoint + "/{slot}/acceptances",
+		GetSlotsEndpoint + "/best_bids",
+		GetSlotsEndpoint + "/upcoming",
+		GetDebugSlotsEndpoint + "/{slot}",
+		RelaysEndpoint,
+		GetEpochStatsEndpoint,
+		DashboardSummaryEndpoint,
+		DashboardRelayEndpoint + "/{pubkey}.json",
+		RelaysEndpoint + "/{pubkey}/tags",
+		RelaysEndpoint + "/{pubkey}/uptime",
+		RelaysEndpoint + "/{pubkey}/annotations",
+		GetSLAEndpoint + "/{pubkey}",
+		GetRecommendEndpoint,
+		GetCompareEndpoint,
+		GetBidsEndpoint,
+		PostReanalyzeEndpoint,
+		MetricsEndpoint,
+	} {
+		if _, ok := document.Paths[endpoint]; !ok {
+			t.Errorf("endpoint %s is missing from the API spec", endpoint)
+		}
+	}
+}
+
+func TestSpecCoversFaultStats(t *testing.T) {
+	var document struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]interface{} `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	err := yaml.Unmarshal(spec, &document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := document.Components.Schemas["FaultStats"].Properties
+	statsType := reflect.TypeOf(analysis.FaultStats{})
+	for i := 0; i < statsType.NumField(); i++ {
+		name := strings.Split(statsType.Field(i).Tag.Get("json"), ",")[0]
+		if _, ok := properties[name]; !ok {
+			t.Errorf("fault stat %s is missing from the API spec", name)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const DefaultEpochStatsLookback = "24h"
+
+// Lookback windows of the epoch stats, where a day covers 225 epochs on mainnet
+var epochStatsLookbacks = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+// `EpochStatsResponse` is the stats of each relay per epoch over the epochs of the lookback window
+type EpochStatsResponse struct {
+	Span     Span                      `json:"span"`
+	Lookback string                    `json:"lookback"`
+	Data     reporter.EpochStatsRecord `json:"data"`
+}
+
+// `epochSpanForLookback` returns the epochs from the epoch `lookback` before `currentSlot` up to the current epoch
+func epochSpanForLookback(lookback time.Duration, currentSlot types.Slot, secondsPerSlot, slotsPerEpoch uint64) Span {
+	slots := uint64(lookback.Seconds()) / secondsPerSlot
+	startSlot := uint64(0)
+	if currentSlot > slots {
+		startSlot = currentSlot - slots
+	}
+	return Span{
+		Start: startSlot / slotsPerEpoch,
+		End:   currentSlot / slotsPerEpoch,
+	}
+}
+
+func (s *Server) handleBidValueStatsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for bid value stats request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tagged, err := s.parseTagFilter(r)
+	if err != nil {
+		logger.Errorw("could not filter bid value stats by tag", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	stats, err := s.reporter.GetBidValueStatsRecord(r.Context(), span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute bid value stats", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := ScoresResponse{
+		Span: *span,
+		Data: filterByTags(stats, tagged),
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode bid value stats", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleWinRateStatsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for win rate stats request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tagged, err := s.parseTagFilter(r)
+	if err != nil {
+		logger.Errorw("could not filter win rates by tag", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	winRates, err := s.reporter.GetWinRates(r.Context(), span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute win rates", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := ScoresResponse{
+		Span: *span,
+		Data: filterByTags(winRates, tagged),
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode win rates", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleUniqueBlockStatsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	span, err := s.parseSlotSpanFromRequest(r)
+	if err != nil {
+		logger.Errorw("error parsing query param for unique block stats request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tagged, err := s.parseTagFilter(r)
+	if err != nil {
+		logger.Errorw("could not filter block uniqueness by tag", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	uniqueness, err := s.reporter.GetBlockUniqueness(r.Context(), span.Start, span.End)
+	if err != nil {
+		logger.Errorw("could not compute block uniqueness", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := ScoresResponse{
+		Span: *span,
+		Data: filterByTags(uniqueness, tagged),
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode block uniqueness", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleEpochStatsRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	lookbackName := r.URL.Query().Get("lookback")
+	if lookbackName == "" {
+		lookbackName = DefaultEpochStatsLookback
+	}
+	lookback, ok := epochStatsLookbacks[lookbackName]
+	if !ok {
+		logger.Errorw("unknown lookback for epoch stats request", "lookback", lookbackName)
+		http.Error(w, fmt.Sprintf("unknown lookback %s, expected one of 1h, 24h or 7d", lookbackName), http.StatusBadRequest)
+		return
+	}
+
+	tagged, err := s.parseTagFilter(r)
+	if err != nil {
+		logger.Errorw("could not filter epoch stats by tag", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	currentSlot := s.clock.CurrentSlot(time.Now().Unix())
+	span := epochSpanForLookback(lookback, currentSlot, s.clock.SecondsPerSlot(), s.clock.SlotsPerEpoch())
+	stats, err := s.reporter.GetEpochStats(r.Context(), span.Start, span.End, s.clock.SlotsPerEpoch(), r.URL.Query().Get("ruleset"))
+	if err != nil {
+		logger.Errorw("could not compute epoch stats", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := EpochStatsResponse{
+		Span:     span,
+		Lookback: lookbackName,
+		Data:     filterByTags(stats, tagged),
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(response)
+	if err != nil {
+		logger.Errorw("could not encode epoch stats", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEpochSpanForLookback(t *testing.T) {
+	for _, tc := range []struct {
+		lookback    time.Duration
+		currentSlot uint64
+		expected    Span
+	}{
+		// an hour covers 300 slots of 12 seconds
+		{lookback: time.Hour, currentSlot: 3200, expected: Span{Start: 90, End: 100}},
+		{lookback: 24 * time.Hour, currentSlot: 100_000, expected: Span{Start: 2900, End: 3125}},
+		// the span starts at genesis if the lookback reaches before it
+		{lookback: 7 * 24 * time.Hour, currentSlot: 1000, expected: Span{Start: 0, End: 31}},
+	} {
+		span := epochSpanForLookback(tc.lookback, tc.currentSlot, 12, 32)
+		if span != tc.expected {
+			t.Errorf("lookback %s at slot %d: expected %+v but got %+v", tc.lookback, tc.currentSlot, tc.expected, span)
+		}
+	}
+}
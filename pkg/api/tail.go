@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	TailBidsType   = "bids"
+	TailFaultsType = "faults"
+)
+
+// `handleTailRequest` streams bid analyses as newline-delimited JSON as they are made, until the client disconnects.
+// With `type=faults` only the analyses which record a fault are streamed.
+func (s *Server) handleTailRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.loggerFor(r.Context())
+
+	tailType := r.URL.Query().Get("type")
+	if tailType == "" {
+		tailType = TailBidsType
+	}
+	if tailType != TailBidsType && tailType != TailFaultsType {
+		err := fmt.Errorf("unknown tail type %s", tailType)
+		logger.Errorw("error parsing query param for tail request", "err", err, "query", r.URL.Query())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	feed, cancel := s.analyzer.SubscribeBidAnalyses()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case analysis, ok := <-feed:
+			if !ok {
+				return
+			}
+			if !includeInTail(tailType, &analysis) {
+				continue
+			}
+			err := encoder.Encode(analysis)
+			if err != nil {
+				logger.Debugw("could not write to tail subscriber", "error", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func includeInTail(tailType string, analysis *types.BidAnalysis) bool {
+	if tailType == TailFaultsType {
+		return analysis.Category != ""
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/crypto"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	// Number of past slots transcripts are accepted for, which bounds the slots remembered to deduplicate submissions
+	transcriptRetentionSlots = 64
+	// Number of slots into the future transcripts are accepted for, to allow for clock skew of the proposer
+	transcriptFutureSlots = 1
+)
+
+// `transcriptError` is a rejection of a submitted transcript, with the HTTP status code and metric reason to report it with
+type transcriptError struct {
+	code   int
+	reason string
+	err    error
+}
+
+func (e *transcriptError) Error() string {
+	return e.err.Error()
+}
+
+// `transcriptFilter` remembers the transcripts accepted in recent slots to deduplicate submissions
+// and limit the number of transcripts accepted per slot, and so per proposer
+type transcriptFilter struct {
+	lock sync.Mutex
+	// slot -> root of each accepted signed blinded beacon block
+	seen map[types.Slot]map[types.Root]struct{}
+}
+
+func newTranscriptFilter() *transcriptFilter {
+	return &transcriptFilter{
+		seen: make(map[types.Slot]map[types.Root]struct{}),
+	}
+}
+
+// `admit` records the transcript with the given slot and root, returning an error if it was already admitted
+// or `limit` transcripts were already admitted for the slot
+func (f *transcriptFilter) admit(slot types.Slot, root types.Root, limit uint, currentSlot types.Slot) *transcriptError {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if currentSlot >= transcriptRetentionSlots {
+		boundary := currentSlot - transcriptRetentionSlots
+		for otherSlot := range f.seen {
+			if otherSlot < boundary {
+				delete(f.seen, otherSlot)
+			}
+		}
+	}
+
+	roots, ok := f.seen[slot]
+	if !ok {
+		roots = make(map[types.Root]struct{})
+		f.seen[slot] = roots
+	}
+	if _, ok := roots[root]; ok {
+		return &transcriptError{
+			code:   http.StatusConflict,
+			reason: metrics.DuplicateReason,
+			err:    fmt.Errorf("transcript for slot %d was already submitted", slot),
+		}
+	}
+	if uint(len(roots)) >= limit {
+		return &transcriptError{
+			code:   http.StatusTooManyRequests,
+			reason: metrics.RateLimitedReason,
+			err:    fmt.Errorf("too many transcripts submitted for slot %d", slot),
+		}
+	}
+	roots[root] = struct{}{}
+	return nil
+}
+
+// `validateTranscript` checks that the transcript is for a recent slot and that its acceptance is signed by the proposer scheduled for the slot
+func (s *Server) validateTranscript(ctx context.Context, transcript *types.AuctionTranscript, currentSlot types.Slot) *transcriptError {
+	if transcript.Bid.Message == nil || transcript.Bid.Message.Header == nil || transcript.Acceptance.Message == nil {
+		return &transcriptError{
+			code:   http.StatusBadRequest,
+			reason: metrics.MalformedReason,
+			err:    fmt.Errorf("transcript is missing its bid or acceptance"),
+		}
+	}
+
+	block := transcript.Acceptance.Message
+	if block.Slot > currentSlot+transcriptFutureSlots || block.Slot+transcriptRetentionSlots < currentSlot {
+		return &transcriptError{
+			code:   http.StatusBadRequest,
+			reason: metrics.StaleSlotReason,
+			err:    fmt.Errorf("transcript for slot %d is outside of the accepted slots around the current slot %d", block.Slot, currentSlot),
+		}
+	}
+
+	proposerPublicKey, err := s.consensusClient.GetPublicKeyForIndex(ctx, block.ProposerIndex)
+	if err != nil {
+		return &transcriptError{
+			code: http.StatusInternalServerError,
+			err:  fmt.Errorf("could not find public key for validator index %d: %w", block.ProposerIndex, err),
+		}
+	}
+	scheduledProposer, err := s.consensusClient.GetProposerPublicKey(ctx, block.Slot)
+	if err != nil {
+		return &transcriptError{
+			code: http.StatusInternalServerError,
+			err:  fmt.Errorf("could not find scheduled proposer for slot %d: %w", block.Slot, err),
+		}
+	}
+	if *scheduledProposer != *proposerPublicKey {
+		return &transcriptError{
+			code:   http.StatusBadRequest,
+			reason: metrics.NotProposerReason,
+			err:    fmt.Errorf("transcript was not signed by the proposer scheduled for slot %d", block.Slot),
+		}
+	}
+
+	domain := s.consensusClient.SignatureDomain(block.Slot)
+	valid, err := crypto.VerifySignature(block, domain, proposerPublicKey[:], transcript.Acceptance.Signature[:])
+	if err != nil {
+		return &transcriptError{
+			code: http.StatusBadRequest,
+			err:  fmt.Errorf("could not verify signature of acceptance: %w", err),
+		}
+	}
+	if !valid {
+		return &transcriptError{
+			code:   http.StatusBadRequest,
+			reason: metrics.InvalidSignatureReason,
+			err:    fmt.Errorf("signature of acceptance is invalid"),
+		}
+	}
+	return nil
+}
+
+// `admitTranscript` validates the transcript and deduplicates it against the transcripts accepted so far
+func (s *Server) admitTranscript(ctx context.Context, transcript *types.AuctionTranscript) *transcriptError {
+	currentSlot := s.clock.CurrentSlot(time.Now().Unix())
+	transcriptErr := s.validateTranscript(ctx, transcript, currentSlot)
+	if transcriptErr != nil {
+		return transcriptErr
+	}
+
+	block := transcript.Acceptance.Message
+	root, err := block.HashTreeRoot()
+	if err != nil {
+		return &transcriptError{
+			code:   http.StatusBadRequest,
+			reason: metrics.MalformedReason,
+			err:    err,
+		}
+	}
+	return s.transcripts.admit(block.Slot, root, s.config.maxTranscriptsPerSlot(), currentSlot)
+}
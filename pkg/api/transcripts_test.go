@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestTranscriptFilter(t *testing.T) {
+	f := newTranscriptFilter()
+
+	if err := f.admit(100, types.Root{1}, 2, 100); err != nil {
+		t.Fatalf("expected first transcript to be admitted, got %v", err)
+	}
+	if err := f.admit(100, types.Root{1}, 2, 100); err == nil || err.code != http.StatusConflict {
+		t.Fatalf("expected duplicate transcript to be rejected, got %v", err)
+	}
+	if err := f.admit(100, types.Root{2}, 2, 100); err != nil {
+		t.Fatalf("expected second transcript to be admitted, got %v", err)
+	}
+	if err := f.admit(100, types.Root{3}, 2, 100); err == nil || err.code != http.StatusTooManyRequests {
+		t.Fatalf("expected transcript over the limit to be rejected, got %v", err)
+	}
+	if err := f.admit(101, types.Root{3}, 2, 101); err != nil {
+		t.Fatalf("expected transcript for another slot to be admitted, got %v", err)
+	}
+
+	f.admit(200, types.Root{1}, 2, 200)
+	if _, ok := f.seen[100]; ok {
+		t.Fatal("expected transcripts of slots past retention to be forgotten")
+	}
+}
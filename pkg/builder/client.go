@@ -1,34 +1,103 @@
 package builder
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
-	"time"
+	"sort"
 
 	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
 	"github.com/ralexstokes/relay-monitor/pkg/types"
 )
 
-const clientTimeoutSec = 2
+const (
+	jsonMediaType = "application/json"
+	sszMediaType  = "application/octet-stream"
+)
 
 type Client struct {
-	endpoint  string
-	hostname  string
+	endpoint string
+	hostname string
+	// endpoint of the relay in the format of mev-boost, without any credentials
+	relayURL  string
 	PublicKey types.PublicKey
 	client    http.Client
+	// counts the connections used by requests to the relay
+	trace     *httptrace.ClientTrace
+	headers   map[string]string
+	basicAuth *BasicAuthConfig
+	// categories of analysis disabled for this relay
+	disabledChecks []string
+	// configuration the client was made from
+	config *Config
 }
 
 func (c *Client) Hostname() string {
 	return c.hostname
 }
 
+func (c *Client) DisabledChecks() []string {
+	return c.disabledChecks
+}
+
+func (c *Client) Config() *Config {
+	return c.config
+}
+
+// `Settings` returns the collection settings of the relay, without any credentials
+func (c *Client) Settings() *types.RelaySettings {
+	headers := []string{}
+	for key := range c.headers {
+		headers = append(headers, key)
+	}
+	sort.Strings(headers)
+	disabledChecks := c.disabledChecks
+	if disabledChecks == nil {
+		disabledChecks = []string{}
+	}
+	return &types.RelaySettings{
+		Endpoint:       c.hostname,
+		URL:            c.relayURL,
+		TimeoutMs:      c.client.Timeout.Milliseconds(),
+		Samples:        c.config.Samples,
+		DisabledChecks: disabledChecks,
+		Headers:        headers,
+		BasicAuth:      c.basicAuth != nil,
+		SSZ:            c.config.SSZ,
+	}
+}
+
+// `Metadata` returns the configured metadata of the relay or `nil` if none is configured
+func (c *Client) Metadata() *types.RelayMetadata {
+	if c.config.Metadata == nil {
+		return nil
+	}
+	metadata := c.config.Metadata
+	return &types.RelayMetadata{
+		Operator:    metadata.Operator,
+		Network:     metadata.Network,
+		Description: metadata.Description,
+		Region:      metadata.Region,
+	}
+}
+
 func (c *Client) String() string {
 	return c.PublicKey.String()
 }
 
 func NewClient(endpoint string) (*Client, error) {
+	return NewClientFromConfig(&Config{Endpoint: endpoint})
+}
+
+func NewClientFromConfig(config *Config) (*Client, error) {
+	endpoint := config.Endpoint
 	u, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
@@ -43,21 +112,51 @@ func NewClient(endpoint string) (*Client, error) {
 		return nil, err
 	}
 
+	relayURL := url.URL{
+		Scheme: u.Scheme,
+		User:   url.User(publicKey.String()),
+		Host:   u.Host,
+		Path:   u.Path,
+	}
+
 	client := http.Client{
-		Timeout: clientTimeoutSec * time.Second,
+		Transport: transport(),
+		Timeout:   config.timeout(),
 	}
 	return &Client{
-		endpoint:  endpoint,
-		hostname:  hostname,
-		PublicKey: publicKey,
-		client:    client,
+		endpoint:       endpoint,
+		hostname:       hostname,
+		relayURL:       relayURL.String(),
+		PublicKey:      publicKey,
+		client:         client,
+		trace:          newClientTrace(publicKey.String()),
+		headers:        config.Headers,
+		basicAuth:      config.BasicAuth,
+		disabledChecks: config.DisabledChecks,
+		config:         config,
 	}, nil
 }
 
+// `newRequest` prepares a request to the relay with any configured headers and credentials
+func (c *Client) newRequest(method, requestUrl string, body io.Reader) (*http.Request, error) {
+	ctx := httptrace.WithClientTrace(context.Background(), c.trace)
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+	if c.basicAuth != nil {
+		req.SetBasicAuth(c.basicAuth.Username, c.basicAuth.Password)
+	}
+	return req, nil
+}
+
 // GetStatus implements the `status` endpoint in the Builder API
 func (c *Client) GetStatus() error {
 	statusUrl := c.endpoint + "/eth/v1/builder/status"
-	req, err := http.NewRequest(http.MethodGet, statusUrl, nil)
+	req, err := c.newRequest(http.MethodGet, statusUrl, nil)
 	if err != nil {
 		return err
 	}
@@ -65,6 +164,7 @@ func (c *Client) GetStatus() error {
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("relay status was not healthy with HTTP status code %d", resp.StatusCode)
@@ -72,26 +172,184 @@ func (c *Client) GetStatus() error {
 	return nil
 }
 
+// RegisterValidators implements the `registerValidator` endpoint in the Builder API
+func (c *Client) RegisterValidators(registrations []types.SignedValidatorRegistration) error {
+	body, err := json.Marshal(registrations)
+	if err != nil {
+		return err
+	}
+	registerUrl := c.endpoint + "/eth/v1/builder/validators"
+	req, err := c.newRequest(http.MethodPost, registerUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to register validators with HTTP status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// `BidResponse` is the raw response of a relay to a `getHeader` request, so the bid can be verified independently
+type BidResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
 // GetBid implements the `getHeader` endpoint in the Builder API
 // A return value of `(nil, nil)` indicates the relay was reachable but had no bid for the given parameters
 func (c *Client) GetBid(slot types.Slot, parentHash types.Hash, publicKey types.PublicKey) (*types.Bid, error) {
+	bid, _, err := c.GetBidWithResponse(slot, parentHash, publicKey)
+	return bid, err
+}
+
+// `GetBidWithResponse` is `GetBid` which also returns the raw response of the relay, if the relay responded
+func (c *Client) GetBidWithResponse(slot types.Slot, parentHash types.Hash, publicKey types.PublicKey) (*types.Bid, *BidResponse, error) {
 	bidUrl := c.endpoint + fmt.Sprintf("/eth/v1/builder/header/%d/%s/%s", slot, parentHash, publicKey)
-	req, err := http.NewRequest(http.MethodGet, bidUrl, nil)
+	req, err := c.newRequest(http.MethodGet, bidUrl, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.config.SSZ {
+		req.Header.Set("Accept", sszMediaType+";q=1.0,"+jsonMediaType+";q=0.9")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	response := &BidResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, response, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, response, fmt.Errorf("failed to get bid with HTTP status code %d", resp.StatusCode)
+	}
+
+	bid, encoding, err := DecodeBid(resp.Header.Get("Content-Type"), body)
+	if err != nil {
+		metrics.BidDecodeErrors.WithLabelValues(c.PublicKey.String(), encoding).Inc()
+		return nil, response, err
+	}
+	metrics.BidEncodings.WithLabelValues(c.PublicKey.String(), encoding).Inc()
+	return bid, response, nil
+}
+
+// `DecodeBid` decodes the bid in the body of a `getHeader` response according to its content type
+// and returns the encoding it was served with
+func DecodeBid(contentType string, body []byte) (*types.Bid, string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && mediaType == sszMediaType {
+		var bid types.Bid
+		err = bid.UnmarshalSSZ(body)
+		if err != nil {
+			return nil, metrics.SSZEncoding, fmt.Errorf("could not decode SSZ-encoded bid: %v", err)
+		}
+		return &bid, metrics.SSZEncoding, nil
+	}
+
+	var bid boostTypes.GetHeaderResponse
+	err = json.Unmarshal(body, &bid)
+	if err != nil {
+		return nil, metrics.JSONEncoding, err
+	}
+	return bid.Data, metrics.JSONEncoding, nil
+}
+
+// GetPayload implements the `getPayload` endpoint in the Builder API
+func (c *Client) GetPayload(signedBlindedBeaconBlock *types.SignedBlindedBeaconBlock) (*types.ExecutionPayload, error) {
+	body, err := json.Marshal(signedBlindedBeaconBlock)
 	if err != nil {
 		return nil, err
 	}
+	payloadUrl := c.endpoint + "/eth/v1/builder/blinded_blocks"
+	req, err := c.newRequest(http.MethodPost, payloadUrl, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode == http.StatusNoContent {
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get payload with HTTP status code %d", resp.StatusCode)
+	}
+
+	var response boostTypes.GetPayloadResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Data == nil {
+		return nil, fmt.Errorf("relay returned no payload")
+	}
+	return response.Data, nil
+}
+
+// GetValidatorRegistration implements the `validator_registration` endpoint in the Relay Data API
+// A return value of `(nil, nil)` indicates the relay was reachable but had no registration for the given public key
+func (c *Client) GetValidatorRegistration(publicKey *types.PublicKey) (*types.SignedValidatorRegistration, error) {
+	registrationUrl := c.endpoint + fmt.Sprintf("/relay/v1/data/validator_registration?pubkey=%s", publicKey)
+	req, err := c.newRequest(http.MethodGet, registrationUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// NOTE: relays signal an unknown validator with either of these status codes
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
 		return nil, nil
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get bid with HTTP status code %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to get validator registration with HTTP status code %d", resp.StatusCode)
 	}
 
-	var bid boostTypes.GetHeaderResponse
-	err = json.NewDecoder(resp.Body).Decode(&bid)
-	return bid.Data, err
+	var registration types.SignedValidatorRegistration
+	err = json.NewDecoder(resp.Body).Decode(&registration)
+	return &registration, err
+}
+
+// GetDeliveredPayloads implements the `proposer_payload_delivered` endpoint in the Relay Data API
+func (c *Client) GetDeliveredPayloads(slot types.Slot) ([]types.BidTrace, error) {
+	deliveredUrl := c.endpoint + fmt.Sprintf("/relay/v1/data/bidtraces/proposer_payload_delivered?slot=%d", slot)
+	req, err := c.newRequest(http.MethodGet, deliveredUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get delivered payloads with HTTP status code %d", resp.StatusCode)
+	}
+
+	var traces []types.BidTrace
+	err = json.NewDecoder(resp.Body).Decode(&traces)
+	return traces, err
 }
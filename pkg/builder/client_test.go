@@ -1,13 +1,23 @@
 package builder_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/ralexstokes/relay-monitor/pkg/builder"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
 )
 
 const (
-	exampleRelayURL = "https://0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a@builder-relay-sepolia.flashbots.net"
+	exampleRelayPublicKey = "0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"
+	exampleRelayURL       = "https://" + exampleRelayPublicKey + "@builder-relay-sepolia.flashbots.net"
 )
 
 func TestClientStatus(t *testing.T) {
@@ -23,3 +33,148 @@ func TestClientStatus(t *testing.T) {
 		return
 	}
 }
+
+func TestClientSettings(t *testing.T) {
+	c, err := builder.NewClientFromConfig(&builder.Config{
+		Endpoint: exampleRelayURL,
+		Headers: map[string]string{
+			"X-Api-Key": "secret",
+			"X-Region":  "eu",
+		},
+		Timeout: 4 * time.Second,
+		Samples: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings := c.Settings()
+	if settings.Endpoint != "builder-relay-sepolia.flashbots.net" || settings.TimeoutMs != 4000 || settings.Samples != 3 || settings.BasicAuth {
+		t.Fatalf("unexpected settings %+v", settings)
+	}
+	if settings.URL != exampleRelayURL {
+		t.Fatalf("expected relay URL %s, got %s", exampleRelayURL, settings.URL)
+	}
+	if len(settings.Headers) != 2 || settings.Headers[0] != "X-Api-Key" || settings.Headers[1] != "X-Region" {
+		t.Fatalf("expected sorted header names only, got %v", settings.Headers)
+	}
+
+	c, err = builder.NewClient(exampleRelayURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout := c.Settings().TimeoutMs; timeout != builder.DefaultTimeout.Milliseconds() {
+		t.Fatalf("expected default timeout, got %dms", timeout)
+	}
+}
+
+func TestGetBidEncodings(t *testing.T) {
+	bid := &types.Bid{
+		Message: &boostTypes.BuilderBid{
+			Header: &boostTypes.ExecutionPayloadHeader{BlockNumber: 1000},
+		},
+	}
+	bid.Message.Value[0] = 1
+	encoded, err := bid.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Accept"), "application/octet-stream") {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(encoded)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(boostTypes.GetHeaderResponse{Version: "bellatrix", Data: bid})
+	}))
+	defer server.Close()
+
+	endpoint := "http://" + exampleRelayPublicKey + "@" + strings.TrimPrefix(server.URL, "http://")
+	for _, ssz := range []bool{false, true} {
+		c, err := builder.NewClientFromConfig(&builder.Config{Endpoint: endpoint, SSZ: ssz})
+		if err != nil {
+			t.Fatal(err)
+		}
+		received, err := c.GetBid(1, types.Hash{}, types.PublicKey{})
+		if err != nil {
+			t.Fatalf("could not get bid with ssz %t: %v", ssz, err)
+		}
+		if received == nil || received.Message.Header.BlockNumber != 1000 || received.Message.Value != bid.Message.Value {
+			t.Fatalf("unexpected bid with ssz %t: %+v", ssz, received)
+		}
+	}
+}
+
+func TestGetBidWithResponse(t *testing.T) {
+	bid := &types.Bid{
+		Message: &boostTypes.BuilderBid{
+			Header: &boostTypes.ExecutionPayloadHeader{BlockNumber: 1000},
+		},
+	}
+	noBid := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if noBid {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "1")
+		_ = json.NewEncoder(w).Encode(boostTypes.GetHeaderResponse{Version: "bellatrix", Data: bid})
+	}))
+	defer server.Close()
+
+	endpoint := "http://" + exampleRelayPublicKey + "@" + strings.TrimPrefix(server.URL, "http://")
+	c, err := builder.NewClient(endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	received, response, err := c.GetBidWithResponse(1, types.Hash{}, types.PublicKey{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response == nil || response.StatusCode != http.StatusOK || response.Header.Get("X-Request-Id") != "1" {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	replayed, _, err := builder.DecodeBid(response.Header.Get("Content-Type"), response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.Message.Header.BlockNumber != received.Message.Header.BlockNumber {
+		t.Fatalf("expected replayed bid %+v to match received bid %+v", replayed, received)
+	}
+
+	noBid = true
+	received, response, err = c.GetBidWithResponse(1, types.Hash{}, types.PublicKey{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received != nil || response == nil || response.StatusCode != http.StatusNoContent || len(response.Body) != 0 {
+		t.Fatalf("expected a response without bid, got bid %+v and response %+v", received, response)
+	}
+}
+
+func TestClientsShareConnections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	endpoint := "http://" + exampleRelayPublicKey + "@" + strings.TrimPrefix(server.URL, "http://")
+	reused := metrics.RelayConnections.WithLabelValues(exampleRelayPublicKey, "true")
+	before := testutil.ToFloat64(reused)
+	for i := 0; i < 2; i++ {
+		c, err := builder.NewClient(endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = c.GetStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if testutil.ToFloat64(reused) <= before {
+		t.Fatal("expected the second client to reuse the connection of the first")
+	}
+}
@@ -0,0 +1,60 @@
+package builder
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Timeout of requests to a relay without a configured `timeout`
+const DefaultTimeout = 2 * time.Second
+
+type BasicAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// `MetadataConfig` describes the operator of a relay
+type MetadataConfig struct {
+	Operator    string `yaml:"operator"`
+	Network     string `yaml:"network"`
+	Description string `yaml:"description"`
+	Region      string `yaml:"region"`
+}
+
+type Config struct {
+	// URL of the relay where the user info holds the relay's public key
+	Endpoint string `yaml:"endpoint"`
+	// Additional headers sent with every request to the relay
+	Headers map[string]string `yaml:"headers"`
+	// Credentials sent with every request to the relay, instead of the relay's public key
+	BasicAuth *BasicAuthConfig `yaml:"basic_auth"`
+	// Categories of analysis to skip for bids from the relay, e.g. `consensus_invalid` or `ignored_preferences`
+	DisabledChecks []string `yaml:"disabled_checks"`
+	// Free-form tags of the relay, e.g. `optimistic` or `regional-eu`, to filter reports by
+	Tags []string `yaml:"tags"`
+	// Timeout of requests to the relay, defaults to `DefaultTimeout`
+	Timeout time.Duration `yaml:"timeout"`
+	// If given, the number of bids requested from the relay in each sampled slot instead of `collector.sampling.samples`
+	Samples uint `yaml:"samples"`
+	// Whether to request bids SSZ-encoded from the relay, accepting JSON from relays which do not support SSZ
+	SSZ bool `yaml:"ssz"`
+	// Optional description of the operator of the relay, exposed alongside its reports
+	Metadata *MetadataConfig `yaml:"metadata"`
+}
+
+func (c *Config) timeout() time.Duration {
+	if c == nil || c.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return c.Timeout
+}
+
+// `UnmarshalYAML` accepts either a plain endpoint or the full configuration
+func (c *Config) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&c.Endpoint)
+	}
+	type config Config
+	return value.Decode((*config)(c))
+}
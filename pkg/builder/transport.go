@@ -0,0 +1,101 @@
+package builder
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+)
+
+const (
+	// Idle connections kept open to each relay, enough for the requests of a slot to reuse connections
+	maxIdleConnsPerHost = 32
+	// How long an idle connection to a relay is kept open, longer than a slot so connections last between slots
+	idleConnTimeout = 90 * time.Second
+	// Sessions of relays kept to resume TLS sessions without a full handshake
+	tlsSessionCacheSize = 256
+	dialTimeout         = 5 * time.Second
+	keepAliveInterval   = 15 * time.Second
+)
+
+var (
+	sharedTransport     *http.Transport
+	sharedTransportOnce sync.Once
+)
+
+// `transport` returns the transport shared by the clients of every relay, so connections and TLS sessions
+// are pooled across the clients and reused from slot to slot
+func transport() *http.Transport {
+	sharedTransportOnce.Do(func() {
+		dialer := &net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: keepAliveInterval,
+		}
+		sharedTransport = &http.Transport{
+			Proxy:       http.ProxyFromEnvironment,
+			DialContext: countConnections(dialer.DialContext),
+			// NOTE: a custom `TLSClientConfig` disables HTTP/2 unless it is forced
+			ForceAttemptHTTP2: true,
+			TLSClientConfig: &tls.Config{
+				ClientSessionCache: tls.NewLRUClientSessionCache(tlsSessionCacheSize),
+			},
+			MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+			IdleConnTimeout:       idleConnTimeout,
+			TLSHandshakeTimeout:   dialTimeout,
+			ExpectContinueTimeout: time.Second,
+		}
+	})
+	return sharedTransport
+}
+
+// `trackedConn` is a connection counted in the open connections to its host until it is closed
+type trackedConn struct {
+	net.Conn
+	host      string
+	closeOnce sync.Once
+}
+
+func (c *trackedConn) Close() error {
+	c.closeOnce.Do(func() {
+		metrics.RelayOpenConnections.WithLabelValues(c.host).Dec()
+	})
+	return c.Conn.Close()
+}
+
+// `countConnections` wraps `dial` to count the connections opened to each host
+func countConnections(dial func(ctx context.Context, network, address string) (net.Conn, error)) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		conn, err := dial(ctx, network, address)
+		if err != nil {
+			metrics.RelayDialErrors.WithLabelValues(host).Inc()
+			return nil, err
+		}
+		metrics.RelayOpenConnections.WithLabelValues(host).Inc()
+		return &trackedConn{Conn: conn, host: host}, nil
+	}
+}
+
+// `newClientTrace` counts whether each request to `relay` reused a pooled connection and resumed its TLS session
+func newClientTrace(relay string) *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			metrics.RelayConnections.WithLabelValues(relay, strconv.FormatBool(info.Reused)).Inc()
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			if err != nil {
+				return
+			}
+			metrics.RelayTLSHandshakes.WithLabelValues(relay, strconv.FormatBool(state.DidResume)).Inc()
+		},
+	}
+}
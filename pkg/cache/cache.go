@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	MemoryBackend = "memory"
+	RedisBackend  = "redis"
+
+	defaultRedisPrefix = "relay-monitor"
+)
+
+type RedisConfig struct {
+	Address  string `yaml:"address"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+	// Namespace for all keys written by the monitor
+	Prefix string `yaml:"prefix"`
+	// Expiry of cached entries, overriding the default expiry of each cache if set
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type Config struct {
+	// One of `memory` (the default) or `redis`
+	Backend string       `yaml:"backend"`
+	Redis   *RedisConfig `yaml:"redis"`
+	// Duration for which computed reports are reused, reports are computed on each request if unset
+	ReportTTL time.Duration `yaml:"report_ttl"`
+}
+
+func (c *Config) ReportCacheTTL() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.ReportTTL
+}
+
+// `Cache` is a key-value cache either local to the process or shared across monitor instances
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Add(key K, value V)
+}
+
+// `Backend` holds any connection required by the configured cache backend
+// NOTE: keys are namespaced by the prefix and, once known, the network so that monitors of different networks can share a redis
+type Backend struct {
+	redis  *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+func NewBackend(ctx context.Context, config *Config) (*Backend, error) {
+	if config == nil || config.Backend == "" || config.Backend == MemoryBackend {
+		return &Backend{}, nil
+	}
+	if config.Backend != RedisBackend {
+		return nil, fmt.Errorf("unknown cache backend %s", config.Backend)
+	}
+	if config.Redis == nil {
+		return nil, fmt.Errorf("missing configuration for redis cache backend")
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     config.Redis.Address,
+		Password: config.Redis.Password,
+		DB:       config.Redis.DB,
+	})
+	err := client.Ping(ctx).Err()
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to redis at %s: %v", config.Redis.Address, err)
+	}
+
+	prefix := config.Redis.Prefix
+	if prefix == "" {
+		prefix = defaultRedisPrefix
+	}
+	return &Backend{
+		redis:  client,
+		prefix: prefix,
+		ttl:    config.Redis.TTL,
+	}, nil
+}
+
+// `ForNetwork` returns a backend writing the keys of the network with genesis fork version `forkVersion`
+// under their own namespace
+func (b *Backend) ForNetwork(forkVersion types.ForkVersion) *Backend {
+	if b == nil || b.redis == nil {
+		return b
+	}
+	return &Backend{
+		redis:  b.redis,
+		prefix: fmt.Sprintf("%s:%#x", b.prefix, forkVersion[:]),
+		ttl:    b.ttl,
+	}
+}
+
+// `New` returns a cache named `name` holding up to `size` entries if kept in memory, or entries expiring after `ttl`
+// unless configured otherwise if kept in redis.
+// A `nil` backend yields an in-process cache.
+func New[K comparable, V any](backend *Backend, name string, size int, ttl time.Duration) (Cache[K, V], error) {
+	if backend == nil || backend.redis == nil {
+		return newMemoryCache[K, V](size)
+	}
+	if backend.ttl != 0 {
+		ttl = backend.ttl
+	}
+	return newRedisCache[K, V](backend, name, ttl), nil
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+type testValue struct {
+	Slot   types.Slot
+	Values []uint64
+}
+
+func newTestBackend(t *testing.T, ttl time.Duration) (*Backend, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	backend, err := NewBackend(context.Background(), &Config{
+		Backend: RedisBackend,
+		Redis: &RedisConfig{
+			Address: server.Addr(),
+			TTL:     ttl,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return backend, server
+}
+
+func TestRedisCacheRoundTrip(t *testing.T) {
+	backend, _ := newTestBackend(t, 0)
+	cache, err := New[types.Slot, *testValue](backend, "values", 16, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := cache.Get(1)
+	if ok {
+		t.Fatal("found value which was never added")
+	}
+
+	cache.Add(1, &testValue{Slot: 1, Values: []uint64{2, 3}})
+	value, ok := cache.Get(1)
+	if !ok {
+		t.Fatal("could not find added value")
+	}
+	if value.Slot != 1 || len(value.Values) != 2 || value.Values[1] != 3 {
+		t.Fatalf("unexpected value %+v", value)
+	}
+
+	// NOTE: the cache is shared with other monitors using the same backend
+	other, err := New[types.Slot, *testValue](backend, "values", 16, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok = other.Get(1)
+	if !ok {
+		t.Fatal("value is not shared across caches of the same name")
+	}
+}
+
+func TestRedisCacheExpiry(t *testing.T) {
+	backend, server := newTestBackend(t, 0)
+	cache, err := New[uint64, uint64](backend, "values", 16, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Add(1, 10)
+	ttl := server.TTL("relay-monitor:values:1")
+	if ttl != time.Hour {
+		t.Fatalf("expected default expiry of %s but got %s", time.Hour, ttl)
+	}
+
+	server.FastForward(time.Hour)
+	_, ok := cache.Get(1)
+	if ok {
+		t.Fatal("found value after its expiry")
+	}
+}
+
+func TestRedisCacheConfiguredExpiry(t *testing.T) {
+	backend, server := newTestBackend(t, time.Minute)
+	cache, err := New[uint64, uint64](backend, "values", 16, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Add(1, 10)
+	ttl := server.TTL("relay-monitor:values:1")
+	if ttl != time.Minute {
+		t.Fatalf("expected configured expiry of %s but got %s", time.Minute, ttl)
+	}
+}
+
+func TestRedisCacheNetworkNamespace(t *testing.T) {
+	backend, server := newTestBackend(t, 0)
+	mainnet, err := New[uint64, uint64](backend.ForNetwork(types.ForkVersion{}), "values", 16, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sepolia, err := New[uint64, uint64](backend.ForNetwork(types.ForkVersion{0x90, 0x00, 0x00, 0x69}), "values", 16, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mainnet.Add(1, 10)
+	_, ok := sepolia.Get(1)
+	if ok {
+		t.Fatal("value is shared across networks")
+	}
+	if !server.Exists("relay-monitor:0x00000000:values:1") {
+		t.Fatalf("value is not namespaced by network, keys are %v", server.Keys())
+	}
+
+	sepolia.Add(1, 20)
+	value, ok := mainnet.Get(1)
+	if !ok || value != 10 {
+		t.Fatalf("expected value of mainnet to be 10 but got %d", value)
+	}
+}
+
+func TestMemoryBackend(t *testing.T) {
+	backend, err := NewBackend(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := New[uint64, uint64](backend.ForNetwork(types.ForkVersion{}), "values", 1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Add(1, 10)
+	cache.Add(2, 20)
+	_, ok := cache.Get(1)
+	if ok {
+		t.Fatal("found value evicted from in-process cache")
+	}
+	value, ok := cache.Get(2)
+	if !ok || value != 20 {
+		t.Fatalf("expected value 20 but got %d", value)
+	}
+}
@@ -0,0 +1,31 @@
+package cache
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+)
+
+type memoryCache[K comparable, V any] struct {
+	cache *lru.Cache
+}
+
+func newMemoryCache[K comparable, V any](size int) (*memoryCache[K, V], error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryCache[K, V]{cache: cache}, nil
+}
+
+func (c *memoryCache[K, V]) Get(key K) (V, bool) {
+	var value V
+	val, ok := c.cache.Get(key)
+	if !ok {
+		return value, false
+	}
+	value, ok = val.(V)
+	return value, ok
+}
+
+func (c *memoryCache[K, V]) Add(key K, value V) {
+	c.cache.Add(key, value)
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisTimeout = 2 * time.Second
+
+// `redisCache` stores JSON-encoded values in redis so that the cache is shared across monitor instances and survives restarts
+// NOTE: errors talking to redis are treated as cache misses so that callers fall back to the source of the data
+type redisCache[K comparable, V any] struct {
+	client    *redis.Client
+	namespace string
+	ttl       time.Duration
+}
+
+func newRedisCache[K comparable, V any](backend *Backend, name string, ttl time.Duration) *redisCache[K, V] {
+	return &redisCache[K, V]{
+		client:    backend.redis,
+		namespace: fmt.Sprintf("%s:%s", backend.prefix, name),
+		ttl:       ttl,
+	}
+}
+
+func (c *redisCache[K, V]) key(key K) string {
+	return fmt.Sprintf("%s:%v", c.namespace, key)
+}
+
+func (c *redisCache[K, V]) Get(key K) (V, bool) {
+	var value V
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		return value, false
+	}
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return value, false
+	}
+	return value, true
+}
+
+func (c *redisCache[K, V]) Add(key K, value V) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	_ = c.client.Set(ctx, c.key(key), data, c.ttl).Err()
+}
@@ -0,0 +1,637 @@
+// Package client implements a client for the API of the relay monitor
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/api"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const clientTimeoutSec = 10
+
+// `SpanQuery` selects the span of epochs (or slots) for a request, where any unset bound is defaulted by the monitor
+type SpanQuery struct {
+	Start  *uint64
+	End    *uint64
+	Window *uint64
+	// If given, only relays with all of these tags are reported, where the endpoint supports it
+	Tags []string
+	// If given, overrides the decay per slot of the scores, where the endpoint supports it
+	Lambda *float64
+	// If given, only the analyses by this ruleset are counted, where the endpoint supports it
+	Ruleset string
+}
+
+func (q *SpanQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	if q.Start != nil {
+		values.Set("start", strconv.FormatUint(*q.Start, 10))
+	}
+	if q.End != nil {
+		values.Set("end", strconv.FormatUint(*q.End, 10))
+	}
+	if q.Window != nil {
+		values.Set("window", strconv.FormatUint(*q.Window, 10))
+	}
+	for _, tag := range q.Tags {
+		values.Add("tag", tag)
+	}
+	if q.Lambda != nil {
+		values.Set("lambda", strconv.FormatFloat(*q.Lambda, 'g', -1, 64))
+	}
+	if q.Ruleset != "" {
+		values.Set("ruleset", q.Ruleset)
+	}
+	return values
+}
+
+type LatencyScoresResponse struct {
+	Span api.SlotSpan                `json:"span"`
+	Data reporter.LatencyScoreRecord `json:"data"`
+	Meta api.RelayMeta               `json:"meta"`
+}
+
+type LatencyScoreResponse struct {
+	Span api.SlotSpan           `json:"span"`
+	Data *reporter.LatencyScore `json:"data"`
+	Meta api.RelayMeta          `json:"meta"`
+}
+
+type OverallScoresResponse struct {
+	Span api.SlotSpan                `json:"span"`
+	Data reporter.OverallScoreRecord `json:"data"`
+	Meta api.RelayMeta               `json:"meta"`
+}
+
+type OverallScoreResponse struct {
+	Span api.SlotSpan           `json:"span"`
+	Data *reporter.OverallScore `json:"data"`
+	Meta api.RelayMeta          `json:"meta"`
+}
+
+type BidValueStatsResponse struct {
+	Span api.SlotSpan                 `json:"span"`
+	Data reporter.BidValueStatsRecord `json:"data"`
+}
+
+type BlockUniquenessResponse struct {
+	Span api.SlotSpan                   `json:"span"`
+	Data reporter.BlockUniquenessRecord `json:"data"`
+}
+
+type WinRatesResponse struct {
+	Span api.SlotSpan           `json:"span"`
+	Data reporter.WinRateRecord `json:"data"`
+}
+
+type Client struct {
+	endpoint string
+	client   http.Client
+}
+
+// `New` returns a client for the relay monitor API at `endpoint`, e.g. `http://localhost:8080`
+func New(endpoint string) *Client {
+	return &Client{
+		endpoint: endpoint,
+		client: http.Client{
+			Timeout: clientTimeoutSec * time.Second,
+		},
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
+	return c.doWithHeader(ctx, method, path, query, nil, body, result)
+}
+
+func (c *Client) doWithHeader(ctx context.Context, method, path string, query url.Values, header http.Header, body, result interface{}) error {
+	requestUrl := c.endpoint + path
+	if len(query) > 0 {
+		requestUrl += "?" + query.Encode()
+	}
+
+	var requestBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, requestBody)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("request to %s failed with HTTP status code %d: %s", path, resp.StatusCode, bytes.TrimSpace(message))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, result interface{}) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, result)
+}
+
+// `GetFaults` returns the fault stats of each relay over the span of epochs
+func (c *Client) GetFaults(ctx context.Context, span *SpanQuery) (*api.FaultsResponse, error) {
+	var response api.FaultsResponse
+	err := c.get(ctx, api.GetFaultEndpoint, span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetFaultRecords` returns the analyses of the bids of each relay over the span of slots
+func (c *Client) GetFaultRecords(ctx context.Context, span *SpanQuery) (*api.BidAnalysesResponse, error) {
+	var response api.BidAnalysesResponse
+	err := c.get(ctx, api.GetFaultRecordsEndpoint, span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetRegistrationCoverage` returns the coverage by each relay of the validator registrations known to the monitor
+func (c *Client) GetRegistrationCoverage(ctx context.Context) (analysis.RegistrationCoverageRecord, error) {
+	var response analysis.RegistrationCoverageRecord
+	err := c.get(ctx, api.GetRegistrationCoverageEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// `GetProposerRegistrationCoverage` returns the share of the upcoming proposers of each epoch with a validator registration known to the monitor
+func (c *Client) GetProposerRegistrationCoverage(ctx context.Context) ([]analysis.ProposerRegistrationCoverage, error) {
+	var response []analysis.ProposerRegistrationCoverage
+	err := c.get(ctx, api.GetProposerCoverageEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// `GetLatencyScores` returns the latency score of each relay over the span of slots
+func (c *Client) GetLatencyScores(ctx context.Context, span *SpanQuery) (*LatencyScoresResponse, error) {
+	var response LatencyScoresResponse
+	err := c.get(ctx, api.GetLatencyScoresEndpoint, span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `Tail` calls `handler` with each bid analysis streamed by the monitor for `tailType`, one of `api.TailBidsType` or
+// `api.TailFaultsType`, until `ctx` is done, the stream ends or `handler` returns an error
+func (c *Client) Tail(ctx context.Context, tailType string, handler func(*types.BidAnalysis) error) error {
+	query := url.Values{}
+	query.Set("type", tailType)
+	requestUrl := c.endpoint + api.GetTailEndpoint + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the stream is long-lived so do not apply the request timeout of `c.client`
+	streamClient := http.Client{
+		Transport: c.client.Transport,
+	}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("request to %s failed with HTTP status code %d: %s", api.GetTailEndpoint, resp.StatusCode, bytes.TrimSpace(message))
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var analysis types.BidAnalysis
+		err := decoder.Decode(&analysis)
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = handler(&analysis)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// `GetBidValueStats` returns the distribution of the values of each relay's bids over the span of slots
+func (c *Client) GetBidValueStats(ctx context.Context, span *SpanQuery) (*BidValueStatsResponse, error) {
+	var response BidValueStatsResponse
+	err := c.get(ctx, api.GetBidValueStatsEndpoint, span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetWinRates` returns the win rate and delivered value of each relay over the span of slots
+func (c *Client) GetWinRates(ctx context.Context, span *SpanQuery) (*WinRatesResponse, error) {
+	var response WinRatesResponse
+	err := c.get(ctx, api.GetWinRateStatsEndpoint, span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetEpochStats` returns the stats of each relay per epoch over the `lookback` window, one of `1h`, `24h` or `7d`
+func (c *Client) GetEpochStats(ctx context.Context, lookback string, tags []string) (*api.EpochStatsResponse, error) {
+	query := url.Values{}
+	if lookback != "" {
+		query.Set("lookback", lookback)
+	}
+	for _, tag := range tags {
+		query.Add("tag", tag)
+	}
+	var response api.EpochStatsResponse
+	err := c.get(ctx, api.GetEpochStatsEndpoint, query, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `dashboardQuery` requests a dashboard over the `lookbackSlots` before the current slot, where `0` indicates the default slot window
+func dashboardQuery(lookbackSlots uint64) url.Values {
+	query := url.Values{}
+	if lookbackSlots != 0 {
+		query.Set("lookback_slots", strconv.FormatUint(lookbackSlots, 10))
+	}
+	return query
+}
+
+// `GetDashboardSummary` returns the data backing a dashboard of every monitored relay over the `lookbackSlots` before the current slot
+func (c *Client) GetDashboardSummary(ctx context.Context, lookbackSlots uint64) (*api.DashboardSummaryResponse, error) {
+	var response api.DashboardSummaryResponse
+	err := c.get(ctx, api.DashboardSummaryEndpoint, dashboardQuery(lookbackSlots), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetDashboardRelay` returns the data backing a dashboard of the monitored `relay` over the `lookbackSlots` before the current slot
+func (c *Client) GetDashboardRelay(ctx context.Context, relay *types.PublicKey, lookbackSlots uint64) (*api.DashboardRelayResponse, error) {
+	var response api.DashboardRelayResponse
+	err := c.get(ctx, api.DashboardRelayEndpoint+"/"+relay.String()+".json", dashboardQuery(lookbackSlots), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetBlockUniqueness` returns the share of each relay's bids with a block no other relay offered over the span of slots
+func (c *Client) GetBlockUniqueness(ctx context.Context, span *SpanQuery) (*BlockUniquenessResponse, error) {
+	var response BlockUniquenessResponse
+	err := c.get(ctx, api.GetUniqueBlockStatsEndpoint, span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetRelays` returns the relays monitored with their tags and settings
+func (c *Client) GetRelays(ctx context.Context) ([]api.RelayResponse, error) {
+	var response []api.RelayResponse
+	err := c.get(ctx, api.RelaysEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// `SetRelayTags` replaces the tags of the monitored `relay`
+func (c *Client) SetRelayTags(ctx context.Context, relay *types.PublicKey, tags []string) error {
+	return c.do(ctx, http.MethodPost, api.RelaysEndpoint+"/"+relay.String()+"/tags", nil, tags, nil)
+}
+
+// `AnnotateFault` attaches an annotation to the fault of the monitored `relay`, authenticated with the bearer `token` of the
+// operator of the relay
+func (c *Client) AnnotateFault(ctx context.Context, relay *types.PublicKey, token string, annotation *api.AnnotateFaultRequest) error {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	return c.doWithHeader(ctx, http.MethodPost, api.RelaysEndpoint+"/"+relay.String()+"/annotations", nil, header, annotation, nil)
+}
+
+// `GetFaultAnnotations` returns the annotations of the faults of the monitored `relay` over the span of slots
+func (c *Client) GetFaultAnnotations(ctx context.Context, relay *types.PublicKey, span *SpanQuery) (*api.FaultAnnotationsResponse, error) {
+	var response api.FaultAnnotationsResponse
+	err := c.get(ctx, api.RelaysEndpoint+"/"+relay.String()+"/annotations", span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetRelayUptime` returns the uptime of the monitored `relay` found by probing its status over the span of slots
+func (c *Client) GetRelayUptime(ctx context.Context, relay *types.PublicKey, span *SpanQuery) (*api.UptimeResponse, error) {
+	var response api.UptimeResponse
+	err := c.get(ctx, api.RelaysEndpoint+"/"+relay.String()+"/uptime", span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `BidsQuery` selects a page of the bid archive, where unset filters match every bid
+type BidsQuery struct {
+	SpanQuery
+	Relay    *types.PublicKey
+	Builder  *types.PublicKey
+	Proposer *types.PublicKey
+	// Inclusive bounds of the value of the bids, in wei
+	MinValue   *types.U256Str
+	MaxValue   *types.U256Str
+	IncludeBid bool
+	Limit      uint64
+	// The `NextCursor` of the previous page
+	Cursor string
+}
+
+func (q *BidsQuery) values() url.Values {
+	if q == nil {
+		return url.Values{}
+	}
+	values := q.SpanQuery.values()
+	for key, publicKey := range map[string]*types.PublicKey{"relay": q.Relay, "builder": q.Builder, "proposer": q.Proposer} {
+		if publicKey != nil {
+			values.Set(key, publicKey.String())
+		}
+	}
+	if q.MinValue != nil {
+		values.Set("min_value", q.MinValue.String())
+	}
+	if q.MaxValue != nil {
+		values.Set("max_value", q.MaxValue.String())
+	}
+	if q.IncludeBid {
+		values.Set("include_bid", "true")
+	}
+	if q.Limit != 0 {
+		values.Set("limit", strconv.FormatUint(q.Limit, 10))
+	}
+	if q.Cursor != "" {
+		values.Set("cursor", q.Cursor)
+	}
+	return values
+}
+
+// `GetBids` returns a page of the stored bids matching the query
+func (c *Client) GetBids(ctx context.Context, query *BidsQuery) (*api.BidsResponse, error) {
+	var response api.BidsResponse
+	err := c.get(ctx, api.GetBidsEndpoint, query.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `Compare` returns the stats of `relays` side by side over the span of slots
+func (c *Client) Compare(ctx context.Context, relays []types.PublicKey, span *SpanQuery) (*api.CompareResponse, error) {
+	relayStrs := make([]string, 0, len(relays))
+	for _, relay := range relays {
+		relayStrs = append(relayStrs, relay.String())
+	}
+	values := span.values()
+	values.Set("relays", strings.Join(relayStrs, ","))
+	var response api.CompareResponse
+	err := c.get(ctx, api.GetCompareEndpoint, values, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `RecommendQuery` overrides the thresholds of a recommendation of relays, where unset thresholds are those configured by the monitor
+type RecommendQuery struct {
+	SpanQuery
+	MinScore        *float64
+	MinLatencyScore *float64
+}
+
+func (q *RecommendQuery) values() url.Values {
+	if q == nil {
+		return url.Values{}
+	}
+	values := q.SpanQuery.values()
+	if q.MinScore != nil {
+		values.Set("min_score", strconv.FormatFloat(*q.MinScore, 'g', -1, 64))
+	}
+	if q.MinLatencyScore != nil {
+		values.Set("min_latency_score", strconv.FormatFloat(*q.MinLatencyScore, 'g', -1, 64))
+	}
+	return values
+}
+
+// `Recommend` returns the relays whose scores reach the thresholds, along with the `-relays` flag of mev-boost selecting them
+func (c *Client) Recommend(ctx context.Context, query *RecommendQuery) (*api.RecommendResponse, error) {
+	var response api.RecommendResponse
+	err := c.get(ctx, api.GetRecommendEndpoint, query.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetSLA` returns the evaluation of the monitored `relay` against the service level of the monitor over the span of slots
+func (c *Client) GetSLA(ctx context.Context, relay *types.PublicKey, span *SpanQuery) (*api.SLAResponse, error) {
+	var response api.SLAResponse
+	err := c.get(ctx, api.GetSLAEndpoint+"/"+relay.String(), span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetSlotDebug` returns everything the monitor knows about `slot`
+func (c *Client) GetSlotDebug(ctx context.Context, slot types.Slot) (*api.SlotDebugResponse, error) {
+	var response api.SlotDebugResponse
+	err := c.get(ctx, api.GetDebugSlotsEndpoint+"/"+strconv.FormatUint(slot, 10), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetBestBid` returns the best bid across relays in `slot`
+func (c *Client) GetBestBid(ctx context.Context, slot types.Slot) (*reporter.BestBid, error) {
+	var response reporter.BestBid
+	err := c.get(ctx, api.GetSlotsEndpoint+"/"+strconv.FormatUint(slot, 10)+"/best_bid", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetBestBids` returns the best bid across relays of each slot with a bid over the span of slots
+func (c *Client) GetBestBids(ctx context.Context, span *SpanQuery) (*api.BestBidsResponse, error) {
+	var response api.BestBidsResponse
+	err := c.get(ctx, api.GetSlotsEndpoint+"/best_bids", span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetSlotAcceptances` returns the acceptances of bids in `slot`, with the accepted bids and their analyses
+func (c *Client) GetSlotAcceptances(ctx context.Context, slot types.Slot) (*api.SlotAcceptancesResponse, error) {
+	var response api.SlotAcceptancesResponse
+	err := c.get(ctx, api.GetSlotsEndpoint+"/"+strconv.FormatUint(slot, 10)+"/acceptances", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetUpcomingSlots` returns the upcoming slots known to the monitor with their proposers and the relays expected to serve them
+func (c *Client) GetUpcomingSlots(ctx context.Context) ([]types.UpcomingSlot, error) {
+	var response []types.UpcomingSlot
+	err := c.get(ctx, api.GetSlotsEndpoint+"/upcoming", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// `GetLatencyScore` returns the latency score of `relay` over the span of slots
+func (c *Client) GetLatencyScore(ctx context.Context, relay *types.PublicKey, span *SpanQuery) (*LatencyScoreResponse, error) {
+	var response LatencyScoreResponse
+	err := c.get(ctx, api.GetLatencyScoresEndpoint+"/"+relay.String(), span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetOverallScores` returns the overall score of each relay over the span of slots
+func (c *Client) GetOverallScores(ctx context.Context, span *SpanQuery) (*OverallScoresResponse, error) {
+	var response OverallScoresResponse
+	err := c.get(ctx, api.GetOverallScoresEndpoint, span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetOverallScore` returns the overall score of `relay` over the span of slots
+func (c *Client) GetOverallScore(ctx context.Context, relay *types.PublicKey, span *SpanQuery) (*OverallScoreResponse, error) {
+	var response OverallScoreResponse
+	err := c.get(ctx, api.GetOverallScoresEndpoint+"/"+relay.String(), span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetCensorshipReport` returns the inclusion of transactions from the watch list in payloads delivered by each relay
+func (c *Client) GetCensorshipReport(ctx context.Context) (*analysis.CensorshipReport, error) {
+	var response analysis.CensorshipReport
+	err := c.get(ctx, api.GetCensorshipReportEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetBuilderRelays` returns the relays `builder` has been seen delivering blocks through
+func (c *Client) GetBuilderRelays(ctx context.Context, builder *types.PublicKey) (*api.BuilderRelaysResponse, error) {
+	var response api.BuilderRelaysResponse
+	err := c.get(ctx, api.GetBuildersEndpoint+"/"+builder.String()+"/relays", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetBuilderStats` returns the faults and deliveries of `builder` aggregated across relays over the span of slots
+func (c *Client) GetBuilderStats(ctx context.Context, builder *types.PublicKey, span *SpanQuery) (*api.BuilderStatsResponse, error) {
+	var response api.BuilderStatsResponse
+	err := c.get(ctx, api.GetBuildersEndpoint+"/"+builder.String()+"/stats", span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetProposerBids` returns the bids made by each relay to `proposer` over the span of slots, with their analysis
+func (c *Client) GetProposerBids(ctx context.Context, proposer *types.PublicKey, span *SpanQuery) (*api.ProposerBidsResponse, error) {
+	var response api.ProposerBidsResponse
+	err := c.get(ctx, api.GetProposersEndpoint+"/"+proposer.String()+"/bids", span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `GetProposerAcceptances` returns the acceptances of bids by `proposer` over the span of slots, with the accepted bids and their analyses
+func (c *Client) GetProposerAcceptances(ctx context.Context, proposer *types.PublicKey, span *SpanQuery) (*api.ProposerAcceptancesResponse, error) {
+	var response api.ProposerAcceptancesResponse
+	err := c.get(ctx, api.GetProposersEndpoint+"/"+proposer.String()+"/acceptances", span.values(), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `Reanalyze` validates the stored bids of the span of slots again with the current rules of the monitor,
+// which must serve the endpoint with `api.reanalysis`
+func (c *Client) Reanalyze(ctx context.Context, span *SpanQuery) (*api.ReanalyzeResponse, error) {
+	var response api.ReanalyzeResponse
+	err := c.do(ctx, http.MethodPost, api.PostReanalyzeEndpoint, span.values(), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// `RegisterValidators` submits validator registrations to the monitor
+func (c *Client) RegisterValidators(ctx context.Context, registrations []types.SignedValidatorRegistration) error {
+	return c.do(ctx, http.MethodPost, api.RegisterValidatorEndpoint, nil, registrations, nil)
+}
+
+// `PostAuctionTranscript` submits the transcript of an auction to the monitor for analysis
+func (c *Client) PostAuctionTranscript(ctx context.Context, transcript *types.AuctionTranscript) error {
+	return c.do(ctx, http.MethodPost, api.PostAuctionTranscriptEndpoint, nil, transcript, nil)
+}
@@ -0,0 +1,88 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/api"
+	"github.com/ralexstokes/relay-monitor/pkg/client"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const exampleRelayPublicKey = "0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"
+
+func TestGetFaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != api.GetFaultEndpoint {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("start") != "10" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		_, err := w.Write([]byte(`{"span":{"start_epoch":"10","end_epoch":"20"},"data":{"` + exampleRelayPublicKey + `":{"stats":{"total_bids":3,"late_bids":1},"meta":{"endpoint":"relay.example.com"}}}}`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	start := uint64(10)
+	response, err := client.New(server.URL).GetFaults(context.Background(), &client.SpanQuery{Start: &start})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Span.Start != 10 || response.Span.End != 20 {
+		t.Fatalf("unexpected span %+v", response.Span)
+	}
+	if len(response.FaultRecord) != 1 {
+		t.Fatalf("unexpected faults %+v", response.FaultRecord)
+	}
+	for relay, faults := range response.FaultRecord {
+		if relay.String() != exampleRelayPublicKey {
+			t.Fatalf("unexpected relay %s", relay)
+		}
+		if faults.Stats.TotalBids != 3 || faults.Stats.LateBids != 1 || faults.Meta.Endpoint != "relay.example.com" {
+			t.Fatalf("unexpected faults %+v", faults)
+		}
+	}
+}
+
+func TestRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid span", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	_, err := client.New(server.URL).GetLatencyScores(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for bad request")
+	}
+}
+
+func TestTail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != api.GetTailEndpoint || r.URL.Query().Get("type") != api.TailFaultsType {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, err := w.Write([]byte(`{"context":{"slot":1,"relay_public_key":"` + exampleRelayPublicKey + `"},"category":"consensus_invalid"}` + "\n" +
+			`{"context":{"slot":2,"relay_public_key":"` + exampleRelayPublicKey + `"},"category":"ignored_preferences"}` + "\n"))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var slots []uint64
+	err := client.New(server.URL).Tail(context.Background(), api.TailFaultsType, func(analysis *types.BidAnalysis) error {
+		slots = append(slots, analysis.Context.Slot)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != 2 || slots[0] != 1 || slots[1] != 2 {
+		t.Fatalf("unexpected analyses for slots %v", slots)
+	}
+}
@@ -0,0 +1,18 @@
+// Package config holds the handling of config files shared by the commands
+package config
+
+import (
+	"os"
+	"regexp"
+)
+
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// `ExpandEnv` substitutes references like `${VAR}` in `data` from the environment, leaving any other `$` as is
+// so that values like templates or passwords may contain it
+func ExpandEnv(data []byte) []byte {
+	return envReference.ReplaceAllFunc(data, func(reference []byte) []byte {
+		name := envReference.FindSubmatch(reference)[1]
+		return []byte(os.Getenv(string(name)))
+	})
+}
@@ -0,0 +1,21 @@
+package config
+
+import "testing"
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("RELAY_PASSWORD", "secret")
+
+	for _, tc := range []struct {
+		data     string
+		expected string
+	}{
+		{data: "password: ${RELAY_PASSWORD}", expected: "password: secret"},
+		{data: "password: ${UNSET_RELAY_PASSWORD}", expected: "password: "},
+		{data: "body: {{ range $relay := .Relays }}", expected: "body: {{ range $relay := .Relays }}"},
+		{data: "password: pa$$word $RELAY_PASSWORD", expected: "password: pa$$word $RELAY_PASSWORD"},
+	} {
+		if expanded := string(ExpandEnv([]byte(tc.data))); expanded != tc.expected {
+			t.Errorf("expected %q to expand to %q but got %q", tc.data, tc.expected, expanded)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package consensus
+
+import (
+	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	"github.com/ralexstokes/relay-monitor/pkg/cache"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `blockNumberIndex` maps the number of an execution block to the slot of the beacon block containing it
+// and holds at most as many entries as the configured cache size
+type blockNumberIndex struct {
+	cache cache.Cache[uint64, types.Slot]
+}
+
+func newBlockNumberIndex(backend *cache.Backend, size int) (*blockNumberIndex, error) {
+	cache, err := cache.New[uint64, types.Slot](backend, "block-numbers", size, cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &blockNumberIndex{cache: cache}, nil
+}
+
+func (i *blockNumberIndex) put(slot types.Slot, block *bellatrix.SignedBeaconBlock) {
+	blockNumber := uint64(block.Message.Body.ExecutionPayload.BlockNumber)
+	i.cache.Add(blockNumber, slot)
+}
+
+func (i *blockNumberIndex) slotFor(blockNumber uint64) (types.Slot, bool) {
+	return i.cache.Get(blockNumber)
+}
@@ -0,0 +1,84 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	"github.com/protolambda/ztyp/view"
+	"github.com/ralexstokes/relay-monitor/pkg/cache"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func newBlock(blockNumber, gasLimit uint64) *bellatrix.SignedBeaconBlock {
+	block := &bellatrix.SignedBeaconBlock{}
+	block.Message.Body.ExecutionPayload.BlockNumber = view.Uint64View(blockNumber)
+	block.Message.Body.ExecutionPayload.GasLimit = view.Uint64View(gasLimit)
+	return block
+}
+
+func newTestClient(t *testing.T, size int) *Client {
+	blockCache, err := cache.New[types.Slot, *bellatrix.SignedBeaconBlock](nil, "blocks", size, cacheTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockNumberToSlotIndex, err := newBlockNumberIndex(nil, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{
+		blockCache:             blockCache,
+		blockNumberToSlotIndex: blockNumberToSlotIndex,
+	}
+}
+
+func TestGetParentGasLimit(t *testing.T) {
+	client := newTestClient(t, cacheSize)
+	client.cacheBlock(100, newBlock(1000, 30_000_000))
+	// NOTE: slot 101 is missed
+	client.cacheBlock(102, newBlock(1001, 30_029_295))
+
+	for _, tc := range []struct {
+		blockNumber    uint64
+		parentGasLimit uint64
+		err            bool
+	}{
+		{blockNumber: 1001, parentGasLimit: 30_000_000},
+		// parent is found across the missed slot
+		{blockNumber: 1002, parentGasLimit: 30_029_295},
+		{blockNumber: 1000, err: true},
+		{blockNumber: 0, err: true},
+	} {
+		parentGasLimit, err := client.GetParentGasLimit(context.Background(), tc.blockNumber)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("expected error for block number %d", tc.blockNumber)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parentGasLimit != tc.parentGasLimit {
+			t.Fatalf("wrong parent gas limit for block number %d: %d but expected %d", tc.blockNumber, parentGasLimit, tc.parentGasLimit)
+		}
+	}
+}
+
+func TestBlockNumberIndexIsBounded(t *testing.T) {
+	size := 4
+	index, err := newBlockNumberIndex(nil, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2*size; i++ {
+		index.put(types.Slot(i), newBlock(uint64(i), 0))
+	}
+	if _, ok := index.slotFor(0); ok {
+		t.Fatal("expected oldest block number to be evicted")
+	}
+	slot, ok := index.slotFor(uint64(2*size - 1))
+	if !ok || slot != types.Slot(2*size-1) {
+		t.Fatal("expected latest block number to be indexed")
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/math"
-	lru "github.com/hashicorp/golang-lru"
 	"github.com/holiman/uint256"
 	"github.com/protolambda/eth2api"
 	"github.com/protolambda/eth2api/client/beaconapi"
@@ -21,9 +20,13 @@ import (
 	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
 	"github.com/protolambda/zrnt/eth2/beacon/common"
 	"github.com/r3labs/sse/v2"
+	"github.com/ralexstokes/relay-monitor/pkg/cache"
 	"github.com/ralexstokes/relay-monitor/pkg/crypto"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/tracing"
 	"github.com/ralexstokes/relay-monitor/pkg/types"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -31,6 +34,10 @@ const (
 	cacheSize                       = 1024
 	GasElasticityMultiplier         = 2
 	BaseFeeChangeDenominator uint64 = 8
+	// How long the status of a validator fetched on its own is served before it is fetched again, within the epoch it was fetched in
+	DefaultValidatorTTL = time.Minute
+	// Expiry of entries cached in redis, about as long as the `cacheSize` slots kept in memory
+	cacheTTL = 4 * time.Hour
 )
 
 var (
@@ -39,8 +46,18 @@ var (
 )
 
 type ValidatorInfo struct {
-	publicKey types.PublicKey
-	index     types.ValidatorIndex
+	PublicKey types.PublicKey      `json:"public_key"`
+	Index     types.ValidatorIndex `json:"index"`
+}
+
+// `validatorEntry` is a validator of the cached validator set as of when it was fetched
+type validatorEntry struct {
+	// `nil` if the consensus client did not know the validator
+	validator *eth2api.ValidatorResponse
+	fetchedAt time.Time
+	epoch     types.Epoch
+	// whether the entry was loaded with the whole validator set, which is refreshed each epoch
+	bulk bool
 }
 
 type Client struct {
@@ -59,20 +76,23 @@ type Client struct {
 
 	builderSignatureDomain *crypto.Domain
 
-	// slot -> ValidatorInfo
-	proposerCache *lru.Cache
-	// slot -> SignedBeaconBlock
-	blockCache *lru.Cache
-	// blockNumber -> slot
-	blockNumberToSlotIndex *lru.Cache
-	validatorLock          sync.RWMutex
+	proposerCache cache.Cache[types.Slot, ValidatorInfo]
+	// fetches of the proposer duties of an epoch on a miss of `proposerCache`, by epoch
+	proposerFetches        singleflight.Group
+	blockCache             cache.Cache[types.Slot, *bellatrix.SignedBeaconBlock]
+	blockNumberToSlotIndex *blockNumberIndex
+	// NOTE: the validator set is bulk loaded from the consensus client each epoch so it is always kept in-process
+	validatorLock sync.RWMutex
+	validatorTTL  time.Duration
 	// publicKey -> Validator
-	validatorCache map[types.PublicKey]*eth2api.ValidatorResponse
+	validatorCache map[types.PublicKey]*validatorEntry
 	// validatorIndex -> publicKey, note: points into `validatorCache`
 	validatorIndexCache map[types.ValidatorIndex]*types.PublicKey
 }
 
-func NewClient(ctx context.Context, endpoint string, logger *zap.Logger) (*Client, error) {
+// `NewClient` returns a client for the consensus node at `endpoint`, keeping its caches in `cacheBackend`.
+// A `nil` `cacheBackend` keeps all caches in-process.
+func NewClient(ctx context.Context, endpoint string, logger *zap.Logger, cacheBackend *cache.Backend) (*Client, error) {
 	httpClient := &eth2api.Eth2HttpClient{
 		Addr: endpoint,
 		Cli: &http.Client{
@@ -84,38 +104,39 @@ func NewClient(ctx context.Context, endpoint string, logger *zap.Logger) (*Clien
 		Codec: eth2api.JSONCodec{},
 	}
 
-	proposerCache, err := lru.New(cacheSize)
-	if err != nil {
-		return nil, err
+	validatorCache := make(map[types.PublicKey]*validatorEntry)
+	validatorIndexCache := make(map[types.ValidatorIndex]*types.PublicKey)
+
+	client := &Client{
+		logger:              logger,
+		client:              httpClient,
+		validatorTTL:        DefaultValidatorTTL,
+		validatorCache:      validatorCache,
+		validatorIndexCache: validatorIndexCache,
 	}
 
-	blockCache, err := lru.New(cacheSize)
+	err := client.fetchGenesis(ctx)
 	if err != nil {
-		return nil, err
+		logger := client.logger.Sugar()
+		logger.Fatalf("could not load genesis info: %v", err)
 	}
 
-	blockNumberToSlotIndex, err := lru.New(cacheSize)
+	// NOTE: the genesis is loaded first so that shared caches are namespaced by the network
+	cacheBackend = cacheBackend.ForNetwork(client.genesisForkVersion)
+
+	client.proposerCache, err = cache.New[types.Slot, ValidatorInfo](cacheBackend, "proposers", cacheSize, cacheTTL)
 	if err != nil {
 		return nil, err
 	}
 
-	validatorCache := make(map[types.PublicKey]*eth2api.ValidatorResponse)
-	validatorIndexCache := make(map[types.ValidatorIndex]*types.PublicKey)
-
-	client := &Client{
-		logger:                 logger,
-		client:                 httpClient,
-		proposerCache:          proposerCache,
-		blockCache:             blockCache,
-		blockNumberToSlotIndex: blockNumberToSlotIndex,
-		validatorCache:         validatorCache,
-		validatorIndexCache:    validatorIndexCache,
+	client.blockCache, err = cache.New[types.Slot, *bellatrix.SignedBeaconBlock](cacheBackend, "blocks", cacheSize, cacheTTL)
+	if err != nil {
+		return nil, err
 	}
 
-	err = client.fetchGenesis(ctx)
+	client.blockNumberToSlotIndex, err = newBlockNumberIndex(cacheBackend, cacheSize)
 	if err != nil {
-		logger := client.logger.Sugar()
-		logger.Fatalf("could not load genesis info: %v", err)
+		return nil, err
 	}
 
 	err = client.fetchSpec(ctx)
@@ -127,6 +148,10 @@ func NewClient(ctx context.Context, endpoint string, logger *zap.Logger) (*Clien
 	return client, nil
 }
 
+func (c *Client) GenesisForkVersion() types.ForkVersion {
+	return c.genesisForkVersion
+}
+
 func (c *Client) SignatureDomainForBuilder() crypto.Domain {
 	if c.builderSignatureDomain == nil {
 		domain := crypto.Domain(crypto.ComputeDomain(crypto.DomainTypeAppBuilder, c.genesisForkVersion, types.Root{}))
@@ -214,51 +239,110 @@ func (c *Client) GetForkVersion(slot types.Slot) types.ForkVersion {
 }
 
 func (c *Client) GetProposer(slot types.Slot) (*ValidatorInfo, error) {
-	val, ok := c.proposerCache.Get(slot)
+	validator, ok := c.proposerCache.Get(slot)
 	if !ok {
 		return nil, fmt.Errorf("could not find proposer for slot %d", slot)
 	}
-	validator, ok := val.(ValidatorInfo)
-	if !ok {
-		return nil, fmt.Errorf("internal: proposer cache contains an unexpected type %T", val)
-	}
 	return &validator, nil
 }
 
-func (c *Client) GetBlock(slot types.Slot) (*bellatrix.SignedBeaconBlock, error) {
-	val, ok := c.blockCache.Get(slot)
+// `GetBlock` returns the block at `slot`, fetching it with `ctx` if it is not cached
+func (c *Client) GetBlock(ctx context.Context, slot types.Slot) (*bellatrix.SignedBeaconBlock, error) {
+	block, ok := c.blockCache.Get(slot)
 	if !ok {
-		// TODO pipe in context
-		err := c.FetchBlock(context.Background(), slot)
+		err := c.FetchBlock(ctx, slot)
 		if err != nil {
 			return nil, err
 		}
-		val, ok = c.blockCache.Get(slot)
+		block, ok = c.blockCache.Get(slot)
 		if !ok {
 			return nil, fmt.Errorf("could not find block for slot %d", slot)
 		}
 	}
-	block, ok := val.(*bellatrix.SignedBeaconBlock)
-	if !ok {
-		return nil, fmt.Errorf("internal: block cache contains an unexpected value %v with type %T", val, val)
-	}
 	return block, nil
 }
 
-func (c *Client) GetValidator(publicKey *types.PublicKey) (*eth2api.ValidatorResponse, error) {
+// `currentEpoch` returns the epoch of the wall clock, which the cached validator set is invalidated by
+func (c *Client) currentEpoch(now time.Time) types.Epoch {
+	secondsPerEpoch := int64(c.SecondsPerSlot * c.SlotsPerEpoch)
+	elapsed := now.Unix() - int64(c.GenesisTime)
+	if secondsPerEpoch == 0 || elapsed < 0 {
+		return 0
+	}
+	return types.Epoch(elapsed / secondsPerEpoch)
+}
+
+// `isFresh` returns whether `entry` may still be served at `now`, as it was fetched in the current epoch,
+// within `ttl` unless it was loaded with the whole validator set
+func (entry *validatorEntry) isFresh(now time.Time, epoch types.Epoch, ttl time.Duration) bool {
+	return entry.epoch == epoch && (entry.bulk || now.Sub(entry.fetchedAt) < ttl)
+}
+
+// `GetValidator` returns the validator with `publicKey`, fetching it again from the consensus client if its cached entry
+// is from an earlier epoch or, if it was not loaded with the whole validator set, older than the TTL, and serving the cached entry if it can not be fetched
+func (c *Client) GetValidator(ctx context.Context, publicKey *types.PublicKey) (*eth2api.ValidatorResponse, error) {
+	now := time.Now()
+	epoch := c.currentEpoch(now)
+
 	c.validatorLock.RLock()
-	defer c.validatorLock.RUnlock()
+	entry, ok := c.validatorCache[*publicKey]
+	c.validatorLock.RUnlock()
 
-	validator, ok := c.validatorCache[*publicKey]
-	if !ok {
+	if !ok || !entry.isFresh(now, epoch, c.validatorTTL) {
+		fetched, err := c.fetchValidator(ctx, publicKey, now, epoch)
+		if err != nil {
+			if !ok {
+				return nil, err
+			}
+			logger := c.logger.Sugar()
+			logger.Warnw("could not refresh validator, serving cached entry", "error", err, "publicKey", publicKey, "epoch", entry.epoch)
+		} else {
+			entry = fetched
+		}
+	}
+	if entry.validator == nil {
 		return nil, fmt.Errorf("missing validator entry for public key %s", publicKey)
 	}
-	return validator, nil
+	return entry.validator, nil
+}
+
+// `fetchValidator` fetches the validator with `publicKey` from the consensus client and caches it, including if it is unknown
+func (c *Client) fetchValidator(ctx context.Context, publicKey *types.PublicKey, now time.Time, epoch types.Epoch) (*validatorEntry, error) {
+	var response eth2api.ValidatorResponse
+	exists, err := beaconapi.StateValidator(ctx, c.client, eth2api.StateHead, eth2api.ValidatorIdPubkey(*publicKey), &response)
+	if err != nil {
+		metrics.ValidatorFetches.WithLabelValues("error").Inc()
+		return nil, err
+	}
+
+	entry := &validatorEntry{
+		fetchedAt: now,
+		epoch:     epoch,
+	}
+	if exists {
+		entry.validator = &response
+		metrics.ValidatorFetches.WithLabelValues("found").Inc()
+	} else {
+		metrics.ValidatorFetches.WithLabelValues("unknown").Inc()
+	}
+
+	c.validatorLock.Lock()
+	defer c.validatorLock.Unlock()
+
+	c.validatorCache[*publicKey] = entry
+	if entry.validator != nil {
+		key := *publicKey
+		c.validatorIndexCache[uint64(entry.validator.Index)] = &key
+	}
+	return entry, nil
 }
 
 func (c *Client) GetParentHash(ctx context.Context, slot types.Slot) (types.Hash, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "consensus.getParentHash")
+	defer span.End()
+
 	targetSlot := slot - 1
-	block, err := c.GetBlock(targetSlot)
+	block, err := c.GetBlock(ctx, targetSlot)
 	if err != nil {
 		return types.Hash{}, err
 	}
@@ -266,12 +350,73 @@ func (c *Client) GetParentHash(ctx context.Context, slot types.Slot) (types.Hash
 }
 
 func (c *Client) GetProposerPublicKey(ctx context.Context, slot types.Slot) (*types.PublicKey, error) {
+	_, span := tracing.Tracer().Start(ctx, "consensus.getProposerPublicKey")
+	defer span.End()
+
 	validator, err := c.GetProposer(slot)
 	if err != nil {
-		// TODO consider fallback to grab the assignments for the missing epoch...
-		return nil, fmt.Errorf("missing proposer for slot %d: %v", slot, err)
+		validator, err = c.fetchProposer(ctx, slot)
+		if err != nil {
+			return nil, fmt.Errorf("missing proposer for slot %d: %v", slot, err)
+		}
+	}
+	return &validator.PublicKey, nil
+}
+
+// `fetchProposer` fetches the proposer duties of the epoch of `slot` on a miss of the proposer cache, e.g. after a restart,
+// sharing the fetch with concurrent misses in the same epoch.
+// NOTE: the shared fetch runs on its own context so that a caller giving up does not fail the fetch for the other callers
+func (c *Client) fetchProposer(ctx context.Context, slot types.Slot) (*ValidatorInfo, error) {
+	epoch := slot / c.SlotsPerEpoch
+	fetch := c.proposerFetches.DoChan(strconv.FormatUint(epoch, 10), func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.Background(), clientTimeoutSec*time.Second)
+		defer cancel()
+		return nil, c.FetchProposers(fetchCtx, epoch)
+	})
+	var err error
+	select {
+	case result := <-fetch:
+		err = result.Err
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		metrics.ProposerCacheMisses.WithLabelValues("error").Inc()
+		return nil, err
 	}
-	return &validator.publicKey, nil
+	validator, err := c.GetProposer(slot)
+	if err != nil {
+		metrics.ProposerCacheMisses.WithLabelValues("missing").Inc()
+		return nil, err
+	}
+	metrics.ProposerCacheMisses.WithLabelValues("fetched").Inc()
+	return validator, nil
+}
+
+// `hasProposers` returns whether the proposer of every slot of `epoch` is cached
+func (c *Client) hasProposers(epoch types.Epoch) bool {
+	startSlot := epoch * c.SlotsPerEpoch
+	for slot := startSlot; slot < startSlot+c.SlotsPerEpoch; slot++ {
+		if _, ok := c.proposerCache.Get(slot); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// `PrefetchProposers` fetches the proposer duties of `epoch` and the next epoch, the furthest duties are known,
+// unless they are already cached
+func (c *Client) PrefetchProposers(ctx context.Context, epoch types.Epoch) error {
+	for _, target := range []types.Epoch{epoch, epoch + 1} {
+		if c.hasProposers(target) {
+			continue
+		}
+		err := c.FetchProposers(ctx, target)
+		if err != nil {
+			return fmt.Errorf("could not fetch proposer duties for epoch %d: %w", target, err)
+		}
+	}
+	return nil
 }
 
 func (c *Client) FetchProposers(ctx context.Context, epoch types.Epoch) error {
@@ -286,8 +431,8 @@ func (c *Client) FetchProposers(ctx context.Context, epoch types.Epoch) error {
 	// TODO handle reorgs, etc.
 	for _, duty := range proposerDuties.Data {
 		c.proposerCache.Add(uint64(duty.Slot), ValidatorInfo{
-			publicKey: types.PublicKey(duty.Pubkey),
-			index:     uint64(duty.ValidatorIndex),
+			PublicKey: types.PublicKey(duty.Pubkey),
+			Index:     uint64(duty.ValidatorIndex),
 		})
 	}
 
@@ -312,11 +457,32 @@ func (c *Client) FetchBlock(ctx context.Context, slot types.Slot) error {
 		return fmt.Errorf("could not parse block %s", signedBeaconBlock)
 	}
 
-	c.blockCache.Add(slot, bellatrixBlock)
-	c.blockNumberToSlotIndex.Add(uint64(bellatrixBlock.Message.Body.ExecutionPayload.BlockNumber), slot)
+	c.cacheBlock(slot, bellatrixBlock)
 	return nil
 }
 
+func (c *Client) cacheBlock(slot types.Slot, block *bellatrix.SignedBeaconBlock) {
+	c.blockCache.Add(slot, block)
+	c.blockNumberToSlotIndex.put(slot, block)
+}
+
+// `IsSlotMissed` reports whether the consensus client has no block for the given `slot`
+func (c *Client) IsSlotMissed(ctx context.Context, slot types.Slot) (bool, error) {
+	if _, ok := c.blockCache.Get(slot); ok {
+		return false, nil
+	}
+
+	blockID := eth2api.BlockIdSlot(slot)
+	var signedBeaconBlock eth2api.VersionedSignedBeaconBlock
+	exists, err := beaconapi.BlockV2(ctx, c.client, blockID, &signedBeaconBlock)
+	if !exists {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
 type headEvent struct {
 	Slot  string     `json:"slot"`
 	Block types.Root `json:"block"`
@@ -353,8 +519,12 @@ func (c *Client) StreamHeads(ctx context.Context) <-chan types.Coordinate {
 	return ch
 }
 
+// `FetchValidators` loads the whole validator set from the consensus client, replacing the cached validator set
 // TODO handle reorgs
 func (c *Client) FetchValidators(ctx context.Context) error {
+	now := time.Now()
+	epoch := c.currentEpoch(now)
+
 	var response []eth2api.ValidatorResponse
 	exists, err := beaconapi.StateValidators(ctx, c.client, eth2api.StateHead, nil, nil, &response)
 	if err != nil {
@@ -364,21 +534,32 @@ func (c *Client) FetchValidators(ctx context.Context) error {
 		return fmt.Errorf("could not fetch validators from remote endpoint because they do not exist")
 	}
 
+	validatorCache := make(map[types.PublicKey]*validatorEntry, len(response))
+	validatorIndexCache := make(map[types.ValidatorIndex]*types.PublicKey, len(response))
+	for i := range response {
+		validator := &response[i]
+		key := types.PublicKey(validator.Validator.Pubkey)
+		validatorCache[key] = &validatorEntry{
+			validator: validator,
+			fetchedAt: now,
+			epoch:     epoch,
+			bulk:      true,
+		}
+		validatorIndexCache[uint64(validator.Index)] = &key
+	}
+
 	c.validatorLock.Lock()
 	defer c.validatorLock.Unlock()
 
-	for _, validator := range response {
-		publicKey := validator.Validator.Pubkey
-		key := types.PublicKey(publicKey)
-		c.validatorCache[key] = &validator
-		c.validatorIndexCache[uint64(validator.Index)] = &key
-	}
+	c.validatorCache = validatorCache
+	c.validatorIndexCache = validatorIndexCache
+	metrics.ValidatorSetSize.Set(float64(len(response)))
 
 	return nil
 }
 
-func (c *Client) GetValidatorStatus(publicKey *types.PublicKey) (ValidatorStatus, error) {
-	validator, err := c.GetValidator(publicKey)
+func (c *Client) GetValidatorStatus(ctx context.Context, publicKey *types.PublicKey) (ValidatorStatus, error) {
+	validator, err := c.GetValidator(ctx, publicKey)
 	if err != nil {
 		return StatusValidatorUnknown, err
 	}
@@ -392,17 +573,16 @@ func (c *Client) GetValidatorStatus(publicKey *types.PublicKey) (ValidatorStatus
 	}
 }
 
-func (c *Client) GetRandomnessForProposal(slot types.Slot /*, proposerPublicKey *types.PublicKey */) (types.Hash, error) {
+func (c *Client) GetRandomnessForProposal(ctx context.Context, slot types.Slot /*, proposerPublicKey *types.PublicKey */) (types.Hash, error) {
 	targetSlot := slot - 1
 	// TODO support branches w/ proposer public key
-	// TODO pipe in context
 	// TODO or consider getting for each head and caching locally...
-	return FetchRandao(context.Background(), c.client, targetSlot)
+	return FetchRandao(ctx, c.client, targetSlot)
 }
 
-func (c *Client) GetBlockNumberForProposal(slot types.Slot /*, proposerPublicKey *types.PublicKey */) (uint64, error) {
+func (c *Client) GetBlockNumberForProposal(ctx context.Context, slot types.Slot /*, proposerPublicKey *types.PublicKey */) (uint64, error) {
 	// TODO support branches w/ proposer public key
-	parentBlock, err := c.GetBlock(slot - 1)
+	parentBlock, err := c.GetBlock(ctx, slot-1)
 	if err != nil {
 		return 0, err
 	}
@@ -438,9 +618,9 @@ func computeBaseFee(parentGasTarget, parentGasUsed uint64, parentBaseFee *big.In
 	return result
 }
 
-func (c *Client) GetBaseFeeForProposal(slot types.Slot /*, proposerPublicKey *types.PublicKey */) (*types.Uint256, error) {
+func (c *Client) GetBaseFeeForProposal(ctx context.Context, slot types.Slot /*, proposerPublicKey *types.PublicKey */) (*types.Uint256, error) {
 	// TODO support multiple branches of block tree
-	parentBlock, err := c.GetBlock(slot - 1)
+	parentBlock, err := c.GetBlock(ctx, slot-1)
 	if err != nil {
 		return nil, err
 	}
@@ -453,21 +633,46 @@ func (c *Client) GetBaseFeeForProposal(slot types.Slot /*, proposerPublicKey *ty
 	return computeBaseFee(parentGasTarget, parentGasUsed, parentBaseFeeAsInt), nil
 }
 
+// `GetCanonicalBlockByNumber` returns the slot and the hash of the canonical execution block with the given `blockNumber`
+func (c *Client) GetCanonicalBlockByNumber(ctx context.Context, blockNumber uint64) (types.Slot, types.Hash, error) {
+	slot, ok := c.blockNumberToSlotIndex.slotFor(blockNumber)
+	if !ok {
+		return 0, types.Hash{}, fmt.Errorf("missing block for block number %d", blockNumber)
+	}
+	block, err := c.GetBlock(ctx, slot)
+	if err != nil {
+		return 0, types.Hash{}, err
+	}
+	payload := block.Message.Body.ExecutionPayload
+	if uint64(payload.BlockNumber) != blockNumber {
+		return 0, types.Hash{}, fmt.Errorf("block in slot %d has block number %d but expected %d", slot, payload.BlockNumber, blockNumber)
+	}
+	return slot, types.Hash(payload.BlockHash), nil
+}
+
+// `GetParentGasLimit` returns the gas limit of the parent of the execution block with the given `blockNumber`
 func (c *Client) GetParentGasLimit(ctx context.Context, blockNumber uint64) (uint64, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "consensus.getParentGasLimit")
+	defer span.End()
+
 	// TODO support branches w/ proposer public key
-	slotValue, ok := c.blockNumberToSlotIndex.Get(blockNumber)
-	if !ok {
-		return 0, fmt.Errorf("missing block for block number %d", blockNumber)
+	if blockNumber == 0 {
+		return 0, fmt.Errorf("block number %d has no parent", blockNumber)
 	}
-	slot, ok := slotValue.(uint64)
+	parentBlockNumber := blockNumber - 1
+	slot, ok := c.blockNumberToSlotIndex.slotFor(parentBlockNumber)
 	if !ok {
-		return 0, fmt.Errorf("internal: unexpected type %T in block number to slot index", slotValue)
+		return 0, fmt.Errorf("missing block for block number %d", parentBlockNumber)
 	}
-	parentBlock, err := c.GetBlock(slot - 1)
+	parentBlock, err := c.GetBlock(ctx, slot)
 	if err != nil {
 		return 0, err
 	}
-	return uint64(parentBlock.Message.Body.ExecutionPayload.GasLimit), nil
+	parentExecutionPayload := parentBlock.Message.Body.ExecutionPayload
+	if uint64(parentExecutionPayload.BlockNumber) != parentBlockNumber {
+		return 0, fmt.Errorf("block in slot %d has block number %d but expected %d", slot, parentExecutionPayload.BlockNumber, parentBlockNumber)
+	}
+	return uint64(parentExecutionPayload.GasLimit), nil
 }
 
 func (c *Client) GetPublicKeyForIndex(ctx context.Context, validatorIndex types.ValidatorIndex) (*types.PublicKey, error) {
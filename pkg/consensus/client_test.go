@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"testing"
+	"time"
 
 	"github.com/holiman/uint256"
 )
@@ -51,3 +52,45 @@ func TestComputeBaseF(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatorEntryIsFresh(t *testing.T) {
+	client := &Client{SecondsPerSlot: 12, SlotsPerEpoch: 32, GenesisTime: 1000}
+	fetchedAt := time.Unix(1000+384*10+100, 0)
+	entry := &validatorEntry{fetchedAt: fetchedAt, epoch: client.currentEpoch(fetchedAt)}
+	if entry.epoch != 10 {
+		t.Fatalf("expected entry of epoch 10 but got %d", entry.epoch)
+	}
+
+	for _, tc := range []struct {
+		elapsed time.Duration
+		fresh   bool
+	}{
+		{elapsed: 0, fresh: true},
+		{elapsed: 30 * time.Second, fresh: true},
+		// older than the TTL
+		{elapsed: 2 * time.Minute, fresh: false},
+	} {
+		now := fetchedAt.Add(tc.elapsed)
+		if fresh := entry.isFresh(now, client.currentEpoch(now), DefaultValidatorTTL); fresh != tc.fresh {
+			t.Errorf("expected fresh %t after %s but got %t", tc.fresh, tc.elapsed, fresh)
+		}
+	}
+
+	// loaded with the whole validator set
+	bulkEntry := &validatorEntry{fetchedAt: fetchedAt, epoch: entry.epoch, bulk: true}
+	now := fetchedAt.Add(4 * time.Minute)
+	if !bulkEntry.isFresh(now, client.currentEpoch(now), DefaultValidatorTTL) {
+		t.Error("expected entry of the validator set to be fresh within its epoch")
+	}
+	now = time.Unix(1000+384*11, 0)
+	if bulkEntry.isFresh(now, client.currentEpoch(now), DefaultValidatorTTL) {
+		t.Error("expected entry of the validator set to be stale in the next epoch")
+	}
+
+	// fetched just before an epoch boundary
+	lateEntry := &validatorEntry{fetchedAt: time.Unix(1000+384*11-1, 0), epoch: 10}
+	now = lateEntry.fetchedAt.Add(2 * time.Second)
+	if lateEntry.isFresh(now, client.currentEpoch(now), DefaultValidatorTTL) {
+		t.Error("expected entry to be stale in the next epoch")
+	}
+}
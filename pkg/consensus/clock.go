@@ -34,10 +34,22 @@ func (c *Clock) CurrentSlot(currentTime int64) types.Slot {
 	return types.Slot(diff / int64(c.secondsPerSlot))
 }
 
+func (c *Clock) SlotsPerEpoch() uint64 {
+	return c.slotsPerEpoch
+}
+
+func (c *Clock) SecondsPerSlot() uint64 {
+	return c.secondsPerSlot
+}
+
 func (c *Clock) EpochForSlot(slot types.Slot) types.Epoch {
 	return slot / c.slotsPerEpoch
 }
 
+func (c *Clock) StartSlotOfEpoch(epoch types.Epoch) types.Slot {
+	return epoch * c.slotsPerEpoch
+}
+
 func (c *Clock) TickSlots(ctx context.Context) chan types.Slot {
 	ch := make(chan types.Slot, 1)
 	go func() {
@@ -0,0 +1,223 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/flashbots/go-boost-utils/types"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+const (
+	// How long the first verification of a batch waits for further verifications to join it
+	DefaultBatchInterval = 5 * time.Millisecond
+	// Number of verifications after which a batch is verified without waiting for the end of its interval
+	DefaultMaxBatchSize = 256
+)
+
+// domain separation tag of the signatures of the builder API, as used by `VerifySignature`
+var signatureDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+// bits of randomness of the scalars weighting each signature of a batch
+const randomBits = 64
+
+type HashTreeRoot = types.HashTreeRoot
+
+// `SignedMessage` is a message with a signature over it by a public key in a signing domain
+type SignedMessage struct {
+	Message   HashTreeRoot
+	Domain    Domain
+	PublicKey []byte
+	Signature []byte
+}
+
+// `verification` is a parsed signed message in a batch, whose validity is sent on `result` once the batch is verified
+type verification struct {
+	signingRoot [32]byte
+	publicKey   *bls.PublicKey
+	signature   *bls.Signature
+	result      chan bool
+}
+
+func newVerification(message *SignedMessage) (*verification, error) {
+	signingRoot, err := types.ComputeSigningRoot(message.Message, types.Domain(message.Domain))
+	if err != nil {
+		return nil, err
+	}
+	signature, err := bls.SignatureFromBytes(message.Signature)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := bls.PublicKeyFromBytes(message.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &verification{
+		signingRoot: signingRoot,
+		publicKey:   publicKey,
+		signature:   signature,
+		result:      make(chan bool, 1),
+	}, nil
+}
+
+func randomScalar(scalar *blst.Scalar) {
+	var scalarBytes [blst.BLST_SCALAR_BYTES]byte
+	randomBytes := scalarBytes[blst.BLST_SCALAR_BYTES-randomBits/8:]
+	for {
+		_, err := rand.Read(randomBytes)
+		// NOTE: a zero scalar would drop its signature from the batch
+		if err == nil && scalarBytes != [blst.BLST_SCALAR_BYTES]byte{} {
+			break
+		}
+	}
+	scalar.Deserialize(scalarBytes[:])
+}
+
+// `verifyBatch` verifies the signatures of `batch` together, weighting each by a random scalar so invalid signatures
+// can not cancel out. If the batch is invalid, each half is verified the same way to find the invalid signatures,
+// so a few invalid signatures only cost a few verifications per level of halving.
+func verifyBatch(batch []*verification) []bool {
+	results := make([]bool, len(batch))
+	bisectBatch(batch, results)
+	return results
+}
+
+// `bisectBatch` sets the validity of each signature of `batch` in `results`
+func bisectBatch(batch []*verification, results []bool) {
+	switch len(batch) {
+	case 0:
+		return
+	case 1:
+		v := batch[0]
+		results[0] = bls.VerifySignature(v.signature, v.publicKey, v.signingRoot[:])
+		return
+	}
+
+	signatures := make([]*bls.Signature, len(batch))
+	publicKeys := make([]*bls.PublicKey, len(batch))
+	messages := make([]blst.Message, len(batch))
+	for i, v := range batch {
+		signatures[i] = v.signature
+		publicKeys[i] = v.publicKey
+		messages[i] = v.signingRoot[:]
+	}
+	if new(bls.Signature).MultipleAggregateVerify(signatures, true, publicKeys, false, messages, signatureDST, randomScalar, randomBits) {
+		for i := range results {
+			results[i] = true
+		}
+		return
+	}
+	half := len(batch) / 2
+	bisectBatch(batch[:half], results[:half])
+	bisectBatch(batch[half:], results[half:])
+}
+
+// `BatchVerifier` verifies the signatures submitted concurrently within each interval as a single batch,
+// which costs much less than verifying each signature on its own
+type BatchVerifier struct {
+	interval time.Duration
+	maxSize  int
+
+	pending []*verification
+	timer   *time.Timer
+	lock    sync.Mutex
+}
+
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{
+		interval: DefaultBatchInterval,
+		maxSize:  DefaultMaxBatchSize,
+	}
+}
+
+// `flush` verifies the pending batch, if any
+func (v *BatchVerifier) flush() {
+	v.lock.Lock()
+	batch := v.pending
+	v.pending = nil
+	if v.timer != nil {
+		v.timer.Stop()
+		v.timer = nil
+	}
+	v.lock.Unlock()
+
+	for i, valid := range verifyBatch(batch) {
+		batch[i].result <- valid
+	}
+}
+
+// `submit` adds `verifications` to the pending batch, verifying the batch once it is full
+func (v *BatchVerifier) submit(verifications []*verification) {
+	if len(verifications) == 0 {
+		return
+	}
+
+	v.lock.Lock()
+	v.pending = append(v.pending, verifications...)
+	full := len(v.pending) >= v.maxSize
+	if !full && v.timer == nil {
+		v.timer = time.AfterFunc(v.interval, v.flush)
+	}
+	v.lock.Unlock()
+
+	if full {
+		v.flush()
+	}
+}
+
+// `wait` returns the result of each of `verifications` once its batch is verified
+func wait(ctx context.Context, verifications []*verification) ([]bool, error) {
+	results := make([]bool, len(verifications))
+	for i, pending := range verifications {
+		select {
+		case results[i] = <-pending.result:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return results, nil
+}
+
+// `Verify` verifies the signature of `message` with the batch of the current interval, with the same results as `VerifySignature`
+func (v *BatchVerifier) Verify(ctx context.Context, message *SignedMessage) (bool, error) {
+	pending, err := newVerification(message)
+	if err != nil {
+		return false, err
+	}
+	v.submit([]*verification{pending})
+
+	results, err := wait(ctx, []*verification{pending})
+	if err != nil {
+		return false, err
+	}
+	return results[0], nil
+}
+
+// `VerifyAll` verifies the signatures of `messages` with the batch of the current interval, returning whether each is valid,
+// where a message with a malformed public key or signature is invalid
+func (v *BatchVerifier) VerifyAll(ctx context.Context, messages []SignedMessage) ([]bool, error) {
+	results := make([]bool, len(messages))
+	verifications := make([]*verification, 0, len(messages))
+	indices := make([]int, 0, len(messages))
+	for i := range messages {
+		pending, err := newVerification(&messages[i])
+		if err != nil {
+			continue
+		}
+		verifications = append(verifications, pending)
+		indices = append(indices, i)
+	}
+	v.submit(verifications)
+
+	verified, err := wait(ctx, verifications)
+	if err != nil {
+		return nil, err
+	}
+	for i, valid := range verified {
+		results[indices[i]] = valid
+	}
+	return results, nil
+}
@@ -1,6 +1,7 @@
 package crypto_test
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"testing"
@@ -48,3 +49,114 @@ func TestSignatureVerification(t *testing.T) {
 		t.Fatal("signature did not verify")
 	}
 }
+
+func BenchmarkSignatureVerification(b *testing.B) {
+	var registration types.SignedValidatorRegistration
+	err := json.Unmarshal([]byte(sepoliaSignedValidatorRegistration), &registration)
+	if err != nil {
+		b.Fatal(err)
+	}
+	domain := boostTypes.ComputeDomain(boostTypes.DomainTypeAppBuilder, sepoliaGenesisForkVersion, types.Root{})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		valid, err := crypto.VerifySignature(registration.Message, domain, registration.Message.Pubkey[:], registration.Signature[:])
+		if err != nil {
+			b.Fatal(err)
+		}
+		if !valid {
+			b.Fatal("signature did not verify")
+		}
+	}
+}
+
+func TestBatchVerification(t *testing.T) {
+	var registration types.SignedValidatorRegistration
+	err := json.Unmarshal([]byte(sepoliaSignedValidatorRegistration), &registration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain := boostTypes.ComputeDomain(boostTypes.DomainTypeAppBuilder, sepoliaGenesisForkVersion, types.Root{})
+
+	tampered := *registration.Message
+	tampered.GasLimit += 1
+	messages := []crypto.SignedMessage{
+		{Message: registration.Message, Domain: domain, PublicKey: registration.Message.Pubkey[:], Signature: registration.Signature[:]},
+		{Message: &tampered, Domain: domain, PublicKey: tampered.Pubkey[:], Signature: registration.Signature[:]},
+		{Message: registration.Message, Domain: domain, PublicKey: registration.Message.Pubkey[:], Signature: []byte{0x1}},
+	}
+
+	verifier := crypto.NewBatchVerifier()
+	results, err := verifier.VerifyAll(context.Background(), messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []bool{true, false, false} {
+		if results[i] != expected {
+			t.Errorf("expected validity %t of message %d but got %t", expected, i, results[i])
+		}
+	}
+
+	valid, err := verifier.Verify(context.Background(), &messages[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid {
+		t.Fatal("signature did not verify")
+	}
+}
+
+func TestBatchVerificationWithInvalidSignature(t *testing.T) {
+	var registration types.SignedValidatorRegistration
+	err := json.Unmarshal([]byte(sepoliaSignedValidatorRegistration), &registration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain := boostTypes.ComputeDomain(boostTypes.DomainTypeAppBuilder, sepoliaGenesisForkVersion, types.Root{})
+
+	tampered := *registration.Message
+	tampered.GasLimit += 1
+	invalid := 97
+	messages := make([]crypto.SignedMessage, crypto.DefaultMaxBatchSize)
+	for i := range messages {
+		messages[i] = crypto.SignedMessage{Message: registration.Message, Domain: domain, PublicKey: registration.Message.Pubkey[:], Signature: registration.Signature[:]}
+	}
+	messages[invalid].Message = &tampered
+
+	results, err := crypto.NewBatchVerifier().VerifyAll(context.Background(), messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, valid := range results {
+		if valid != (i != invalid) {
+			t.Errorf("expected validity %t of message %d but got %t", i != invalid, i, valid)
+		}
+	}
+}
+
+func BenchmarkBatchVerification(b *testing.B) {
+	var registration types.SignedValidatorRegistration
+	err := json.Unmarshal([]byte(sepoliaSignedValidatorRegistration), &registration)
+	if err != nil {
+		b.Fatal(err)
+	}
+	domain := boostTypes.ComputeDomain(boostTypes.DomainTypeAppBuilder, sepoliaGenesisForkVersion, types.Root{})
+	messages := make([]crypto.SignedMessage, crypto.DefaultMaxBatchSize)
+	for i := range messages {
+		messages[i] = crypto.SignedMessage{Message: registration.Message, Domain: domain, PublicKey: registration.Message.Pubkey[:], Signature: registration.Signature[:]}
+	}
+	verifier := crypto.NewBatchVerifier()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		results, err := verifier.VerifyAll(context.Background(), messages)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, valid := range results {
+			if !valid {
+				b.Fatal("signature did not verify")
+			}
+		}
+	}
+}
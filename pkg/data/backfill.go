@@ -0,0 +1,82 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/builder"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `backfillSlots` collects the canonical blocks and the payloads each relay reports it delivered
+// for the slots in `config`, so the analyzer can process slots from before the monitor started
+// NOTE: bids can not be requested for past slots so only data available from the relay Data API
+// and the consensus client is backfilled
+func (c *Collector) backfillSlots(ctx context.Context, config *BackfillConfig) {
+	logger := c.logger.Sugar()
+
+	// NOTE: live collection covers the slots from the current slot onwards
+	lastSlot := c.clock.CurrentSlot(time.Now().Unix()) - 1
+	endSlot := config.EndSlot
+	if endSlot == 0 || endSlot > lastSlot {
+		endSlot = lastSlot
+	}
+	if config.StartSlot > endSlot {
+		logger.Warnf("not backfilling as start slot %d is after end slot %d", config.StartSlot, endSlot)
+		return
+	}
+
+	logger.Infof("backfilling slots %d to %d", config.StartSlot, endSlot)
+	relays := c.getRelays()
+	for slot := config.StartSlot; slot <= endSlot; slot++ {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		c.backfillBlock(ctx, slot)
+
+		for _, relay := range relays {
+			c.backfillDeliveredPayloads(relay, slot)
+		}
+	}
+	logger.Infof("finished backfilling slots %d to %d", config.StartSlot, endSlot)
+}
+
+func (c *Collector) backfillBlock(ctx context.Context, slot types.Slot) {
+	logger := c.logger.Sugar()
+
+	err := c.consensusClient.FetchBlock(ctx, slot)
+	if err != nil {
+		logger.Warnf("could not fetch block for backfilled slot %d: %v", slot, err)
+		return
+	}
+	missed, err := c.consensusClient.IsSlotMissed(ctx, slot)
+	if err != nil {
+		logger.Warnf("could not check for block in backfilled slot %d: %v", slot, err)
+		return
+	}
+	if missed {
+		return
+	}
+	c.events.Push(Event{Payload: BlockEvent{Slot: slot}})
+}
+
+func (c *Collector) backfillDeliveredPayloads(relay *builder.Client, slot types.Slot) {
+	logger := c.logger.Sugar()
+
+	relayID := relay.PublicKey
+	traces, err := relay.GetDeliveredPayloads(slot)
+	if err != nil {
+		logger.Warnw("could not get delivered payloads from relay for backfilled slot", "error", err, "relayPublicKey", relayID, "slot", slot)
+		return
+	}
+	for i := range traces {
+		trace := &traces[i]
+		if trace.Slot != slot {
+			continue
+		}
+		c.events.Push(Event{Payload: DeliveredPayloadEvent{Relay: relayID, BidTrace: trace}})
+	}
+}
@@ -2,32 +2,62 @@ package data
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/ralexstokes/relay-monitor/pkg/builder"
 	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/tracing"
 	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 type Collector struct {
-	logger          *zap.Logger
-	relays          []*builder.Client
+	config *Config
+	logger *zap.Logger
+
+	relays []*builder.Client
+	// cancels the collection from each running relay
+	relayCancels map[*builder.Client]context.CancelFunc
+	relaysLock   sync.Mutex
+
 	clock           *consensus.Clock
 	consensusClient *consensus.Client
-	events          chan<- Event
+	// `nil` if the collector runs without a store, as the only component of the process
+	store  store.Storer
+	events *Queue
+	// validator registrations accepted by the monitor to forward to the relays
+	registrations <-chan []types.SignedValidatorRegistration
+	// proposers whose slots are sampled multiple times, all proposers if empty
+	sampleAllowList map[types.PublicKey]struct{}
 }
 
-func NewCollector(zapLogger *zap.Logger, relays []*builder.Client, clock *consensus.Clock, consensusClient *consensus.Client, events chan<- Event) *Collector {
+func NewCollector(config *Config, zapLogger *zap.Logger, relays []*builder.Client, clock *consensus.Clock, consensusClient *consensus.Client, store store.Storer, events *Queue, registrations <-chan []types.SignedValidatorRegistration) *Collector {
+	sampleAllowList := make(map[types.PublicKey]struct{})
+	if config != nil && config.Sampling != nil {
+		for _, proposer := range config.Sampling.ProposerAllowList {
+			sampleAllowList[proposer] = struct{}{}
+		}
+	}
 	return &Collector{
+		config:          config,
 		logger:          zapLogger,
 		relays:          relays,
+		relayCancels:    make(map[*builder.Client]context.CancelFunc),
 		clock:           clock,
 		consensusClient: consensusClient,
+		store:           store,
 		events:          events,
+		registrations:   registrations,
+		sampleAllowList: sampleAllowList,
 	}
 }
 
-func (c *Collector) collectBidFromRelay(ctx context.Context, relay *builder.Client, slot types.Slot) (*BidEvent, error) {
+func (c *Collector) collectBidFromRelay(ctx context.Context, relay *builder.Client, slot types.Slot, sample uint) (*BidEvent, error) {
 	parentHash, err := c.consensusClient.GetParentHash(ctx, slot)
 	if err != nil {
 		return nil, err
@@ -36,24 +66,132 @@ func (c *Collector) collectBidFromRelay(ctx context.Context, relay *builder.Clie
 	if err != nil {
 		return nil, err
 	}
-	bid, err := relay.GetBid(slot, parentHash, *publicKey)
-	if err != nil {
-		return nil, err
-	}
-	if bid == nil {
-		return nil, nil
-	}
+	_, span := tracing.Tracer().Start(ctx, "relay.getHeader")
+	requestedAt := time.Now()
+	bid, response, err := relay.GetBidWithResponse(slot, parentHash, *publicKey)
+	receivedAt := time.Now()
 	bidCtx := types.BidContext{
 		Slot:              slot,
 		ParentHash:        parentHash,
 		ProposerPublicKey: *publicKey,
 		RelayPublicKey:    relay.PublicKey,
 	}
-	event := &BidEvent{Context: &bidCtx, Bid: bid}
+	if response != nil && c.config.provenance() {
+		c.events.Push(Event{Payload: BidProvenanceEvent{
+			Provenance: &types.BidProvenance{
+				Context:     bidCtx,
+				Sample:      sample,
+				RequestedAt: requestedAt,
+				ReceivedAt:  receivedAt,
+				StatusCode:  response.StatusCode,
+				Header:      response.Header,
+				Body:        response.Body,
+			},
+		}})
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, err
+	}
+	span.SetAttributes(attribute.Bool("has_bid", bid != nil))
+	span.End()
+	event := &BidEvent{
+		Context:    &bidCtx,
+		Bid:        bid,
+		Latency:    receivedAt.Sub(requestedAt),
+		ReceivedAt: receivedAt,
+	}
 	return event, nil
 }
 
+// `sendBidFromRelay` collects a bid from the relay for the slot and forwards it for analysis
+func (c *Collector) sendBidFromRelay(ctx context.Context, relay *builder.Client, slot types.Slot, sample uint) *BidEvent {
+	logger := c.logger.Sugar()
+
+	relayID := relay.PublicKey
+
+	ctx, span := tracing.Tracer().Start(ctx, "collectBid", trace.WithAttributes(
+		attribute.String("relay", relayID.String()),
+		attribute.Int64("slot", int64(slot)),
+		attribute.Int64("sample", int64(sample)),
+	))
+	defer span.End()
+
+	payload, err := c.collectBidFromRelay(ctx, relay, slot, sample)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Warnw("could not get bid from relay", "error", err, "relayPublicKey", relayID, "slot", slot)
+		// TODO implement some retry logic...
+		return nil
+	}
+	payload.Sample = sample
+	payload.SpanContext = span.SpanContext()
+	if payload.Bid == nil {
+		// No bid for this slot, forward the absence for analysis
+		// TODO consider trying again...
+		logger.Debugw("got no bid", "relay", relayID, "context", payload.Context, "latency", payload.Latency)
+	} else {
+		logger.Debugw("got bid", "relay", relayID, "context", payload.Context, "bid", payload.Bid, "latency", payload.Latency)
+	}
+	c.events.Push(Event{Payload: payload})
+	return payload
+}
+
+// `samplesForProposer` returns the number of bids to request from the relay in a slot of the proposer
+func (c *Collector) samplesForProposer(relay *builder.Client, proposer *types.PublicKey) uint {
+	samples := relay.Config().Samples
+	if samples == 0 && c.config != nil && c.config.Sampling != nil {
+		samples = c.config.Sampling.Samples
+	}
+	if samples <= 1 {
+		return 1
+	}
+	if len(c.sampleAllowList) > 0 {
+		if _, ok := c.sampleAllowList[*proposer]; !ok {
+			return 1
+		}
+	}
+	return samples
+}
+
+func (c *Collector) resampleBidsFromRelay(ctx context.Context, relay *builder.Client, slot types.Slot, samples uint) {
+	interval := c.config.samplingInterval()
+	for sample := uint(1); sample < samples; sample++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+			c.sendBidFromRelay(ctx, relay, slot, sample)
+		}
+	}
+}
+
 func (c *Collector) collectFromRelay(ctx context.Context, relay *builder.Client) {
+	slots := c.clock.TickSlots(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case slot, ok := <-slots:
+			if !ok {
+				return
+			}
+			payload := c.sendBidFromRelay(ctx, relay, slot, 0)
+			if payload == nil {
+				continue
+			}
+			samples := c.samplesForProposer(relay, &payload.Context.ProposerPublicKey)
+			if samples > 1 {
+				go c.resampleBidsFromRelay(ctx, relay, slot, samples)
+			}
+		}
+	}
+}
+
+func (c *Collector) collectDeliveredPayloadsFromRelay(ctx context.Context, relay *builder.Client) {
 	logger := c.logger.Sugar()
 
 	relayID := relay.PublicKey
@@ -63,21 +201,25 @@ func (c *Collector) collectFromRelay(ctx context.Context, relay *builder.Client)
 		select {
 		case <-ctx.Done():
 			return
-		case slot := <-slots:
-			payload, err := c.collectBidFromRelay(ctx, relay, slot)
+		case slot, ok := <-slots:
+			if !ok {
+				return
+			}
+			// NOTE: query the previous slot as the auction for the current slot is still underway
+			targetSlot := slot - 1
+			traces, err := relay.GetDeliveredPayloads(targetSlot)
 			if err != nil {
-				logger.Warnw("could not get bid from relay", "error", err, "relayPublicKey", relayID, "slot", slot)
-				// TODO implement some retry logic...
+				logger.Warnw("could not get delivered payloads from relay", "error", err, "relayPublicKey", relayID, "slot", targetSlot)
 				continue
 			}
-			if payload == nil {
-				// No bid for this slot, continue
-				// TODO consider trying again...
-				continue
+			for i := range traces {
+				trace := &traces[i]
+				if trace.Slot != targetSlot {
+					continue
+				}
+				logger.Debugw("got delivered payload", "relay", relayID, "bidTrace", trace)
+				c.events.Push(Event{Payload: DeliveredPayloadEvent{Relay: relayID, BidTrace: trace}})
 			}
-			logger.Debugw("got bid", "relay", relayID, "context", payload.Context, "bid", payload.Bid)
-			// TODO what if this is slow
-			c.events <- Event{Payload: payload}
 		}
 	}
 }
@@ -94,7 +236,9 @@ func (c *Collector) syncBlocks(ctx context.Context) {
 			err := c.consensusClient.FetchBlock(ctx, head.Slot)
 			if err != nil {
 				logger.Warnf("could not fetch latest execution hash for slot %d: %v", head.Slot, err)
+				continue
 			}
+			c.events.Push(Event{Payload: BlockEvent{Slot: head.Slot}})
 		}
 	}
 }
@@ -108,10 +252,12 @@ func (c *Collector) syncProposers(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case epoch := <-epochs:
-			err := c.consensusClient.FetchProposers(ctx, epoch+1)
+			err := c.consensusClient.PrefetchProposers(ctx, epoch)
 			if err != nil {
 				logger.Warnf("could not load consensus state for epoch %d: %v", epoch, err)
+				continue
 			}
+			c.collectUpcomingSlots(ctx, epoch+1)
 		}
 	}
 }
@@ -133,6 +279,86 @@ func (c *Collector) syncValidators(ctx context.Context) {
 	}
 }
 
+// `collectRegistrationCoverageFromRelay` asks `relay` for the registration of each of `publicKeys`,
+// with a bounded number of requests in flight so the check of a large validator set neither floods the relay nor takes the whole epoch
+func (c *Collector) collectRegistrationCoverageFromRelay(ctx context.Context, relay *builder.Client, publicKeys []types.PublicKey, epoch types.Epoch) (*RegistrationCoverageEvent, error) {
+	var lock sync.Mutex
+	var relayRegistrations uint
+	var failure error
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := uint(0); i < c.config.registrationCoverageWorkers(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				registration, err := relay.GetValidatorRegistration(&publicKeys[index])
+
+				lock.Lock()
+				if err != nil && failure == nil {
+					failure = err
+				}
+				if registration != nil {
+					relayRegistrations += 1
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	for i := range publicKeys {
+		lock.Lock()
+		failed := failure != nil
+		lock.Unlock()
+		// NOTE: the coverage is not reported once a request failed, so the remaining validators are not requested
+		if failed || ctx.Err() != nil {
+			break
+		}
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	if failure != nil {
+		return nil, failure
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	event := &RegistrationCoverageEvent{
+		Relay:                relay.PublicKey,
+		Epoch:                epoch,
+		MonitorRegistrations: uint(len(publicKeys)),
+		RelayRegistrations:   relayRegistrations,
+	}
+	return event, nil
+}
+
+func (c *Collector) syncRegistrationCoverage(ctx context.Context) {
+	logger := c.logger.Sugar()
+
+	epochs := c.clock.TickEpochs(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case epoch := <-epochs:
+			publicKeys, err := c.store.GetRegisteredValidators(ctx)
+			if err != nil {
+				logger.Warnf("could not load registered validators in epoch %d: %v", epoch, err)
+				continue
+			}
+			for _, relay := range c.getRelays() {
+				payload, err := c.collectRegistrationCoverageFromRelay(ctx, relay, publicKeys, epoch)
+				if err != nil {
+					logger.Warnw("could not get registration coverage from relay", "error", err, "relayPublicKey", relay.PublicKey, "epoch", epoch)
+					continue
+				}
+				c.events.Push(Event{Payload: *payload})
+			}
+		}
+	}
+}
+
 // TODO refactor this into a separate component as the list of duties is growing outside the "collector" abstraction
 func (c *Collector) collectConsensusData(ctx context.Context) {
 	go c.syncBlocks(ctx)
@@ -140,16 +366,65 @@ func (c *Collector) collectConsensusData(ctx context.Context) {
 	go c.syncValidators(ctx)
 }
 
-func (c *Collector) Run(ctx context.Context) error {
+func (c *Collector) getRelays() []*builder.Client {
+	c.relaysLock.Lock()
+	defer c.relaysLock.Unlock()
+
+	return c.relays
+}
+
+// `startRelay` starts collecting from the relay, the caller must hold the relays lock
+func (c *Collector) startRelay(ctx context.Context, relay *builder.Client) {
 	logger := c.logger.Sugar()
 
-	for _, relay := range c.relays {
-		relayID := relay.PublicKey
-		logger.Infof("monitoring relay %s", relayID)
+	logger.Infof("monitoring relay %s", relay.PublicKey)
+	relayCtx, cancel := context.WithCancel(ctx)
+	c.relayCancels[relay] = cancel
+	go c.collectFromRelay(relayCtx, relay)
+	go c.collectDeliveredPayloadsFromRelay(relayCtx, relay)
+	go c.probeRelayStatus(relayCtx, relay)
+}
 
-		go c.collectFromRelay(ctx, relay)
+// `SetRelays` replaces the relays the collector collects from, stopping any running relay not in `relays`
+// and starting any relay in `relays` which is not yet running.
+func (c *Collector) SetRelays(ctx context.Context, relays []*builder.Client) {
+	logger := c.logger.Sugar()
+
+	c.relaysLock.Lock()
+	defer c.relaysLock.Unlock()
+
+	next := make(map[*builder.Client]struct{})
+	for _, relay := range relays {
+		next[relay] = struct{}{}
+	}
+	for relay, cancel := range c.relayCancels {
+		if _, ok := next[relay]; !ok {
+			logger.Infof("no longer monitoring relay %s", relay.PublicKey)
+			cancel()
+			delete(c.relayCancels, relay)
+		}
+	}
+	for _, relay := range relays {
+		if _, ok := c.relayCancels[relay]; !ok {
+			c.startRelay(ctx, relay)
+		}
 	}
+	c.relays = relays
+}
+
+func (c *Collector) Run(ctx context.Context) error {
+	c.SetRelays(ctx, c.getRelays())
 	go c.collectConsensusData(ctx)
+	// NOTE: registration coverage is relative to the registrations accepted by the monitor, which only the store knows
+	if c.store != nil {
+		go c.syncRegistrationCoverage(ctx)
+	}
+	if c.config.forwardRegistrations() {
+		go c.forwardRegistrations(ctx)
+	}
+	if backfill := c.config.backfill(); backfill != nil {
+		go c.backfillSlots(ctx, backfill)
+	}
 
 	<-ctx.Done()
 	return nil
@@ -0,0 +1,92 @@
+package data
+
+import (
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	DefaultRegistrationPropagationDelay = 12 * time.Second
+	DefaultSamplingInterval             = 1 * time.Second
+	DefaultStatusInterval               = 12 * time.Second
+	// Number of concurrent requests to each relay when checking its registration coverage
+	DefaultRegistrationCoverageWorkers = 8
+)
+
+type SamplingConfig struct {
+	// Number of bids requested from each relay per slot, spaced by `Interval` from the start of the slot
+	Samples uint `yaml:"samples"`
+	// Time between samples in a slot
+	Interval time.Duration `yaml:"interval"`
+	// If given, only slots of these proposers get multiple samples and others get a single sample
+	ProposerAllowList []types.PublicKey `yaml:"proposer_allow_list"`
+}
+
+// `BackfillConfig` is a historical range of slots to collect when the monitor starts
+type BackfillConfig struct {
+	StartSlot types.Slot `yaml:"start_slot"`
+	// Last slot to collect, defaults to the slot before the monitor started
+	EndSlot types.Slot `yaml:"end_slot"`
+}
+
+type Config struct {
+	// Forward validator registrations accepted by the monitor to the configured relays
+	ForwardRegistrations bool `yaml:"forward_registrations"`
+	// Time to wait after forwarding registrations before checking each relay has them
+	RegistrationPropagationDelay time.Duration `yaml:"registration_propagation_delay"`
+	// Sampling of bids from relays within each slot, by default a single bid is requested at the start of the slot
+	Sampling *SamplingConfig `yaml:"sampling"`
+	// Time between probes of the status of each relay
+	StatusInterval time.Duration `yaml:"status_interval"`
+	// Number of concurrent requests to each relay when checking its coverage of the validators registered with the monitor,
+	// `DefaultRegistrationCoverageWorkers` if unset
+	RegistrationCoverageWorkers uint `yaml:"registration_coverage_workers"`
+	// If given, collect the delivered payloads and canonical blocks of these past slots for analysis
+	Backfill *BackfillConfig `yaml:"backfill"`
+	// Whether to store the raw response of every `getHeader` request, so the analyses of bids can be replayed byte-for-byte
+	Provenance bool `yaml:"provenance"`
+}
+
+func (c *Config) provenance() bool {
+	return c != nil && c.Provenance
+}
+
+func (c *Config) forwardRegistrations() bool {
+	return c != nil && c.ForwardRegistrations
+}
+
+func (c *Config) registrationPropagationDelay() time.Duration {
+	if c == nil || c.RegistrationPropagationDelay == 0 {
+		return DefaultRegistrationPropagationDelay
+	}
+	return c.RegistrationPropagationDelay
+}
+
+func (c *Config) samplingInterval() time.Duration {
+	if c == nil || c.Sampling == nil || c.Sampling.Interval == 0 {
+		return DefaultSamplingInterval
+	}
+	return c.Sampling.Interval
+}
+
+func (c *Config) registrationCoverageWorkers() uint {
+	if c == nil || c.RegistrationCoverageWorkers == 0 {
+		return DefaultRegistrationCoverageWorkers
+	}
+	return c.RegistrationCoverageWorkers
+}
+
+func (c *Config) statusInterval() time.Duration {
+	if c == nil || c.StatusInterval == 0 {
+		return DefaultStatusInterval
+	}
+	return c.StatusInterval
+}
+
+func (c *Config) backfill() *BackfillConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Backfill
+}
@@ -1,6 +1,11 @@
 package data
 
-import "github.com/ralexstokes/relay-monitor/pkg/types"
+import (
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.opentelemetry.io/otel/trace"
+)
 
 type Event struct {
 	Payload any
@@ -10,6 +15,19 @@ type BidEvent struct {
 	Context *types.BidContext
 	// A `nil` `Bid` indicates absence for the given `Context`
 	Bid *types.Bid
+	// Round-trip time of the `getHeader` request
+	Latency time.Duration
+	// Time the response to the `getHeader` request was received
+	ReceivedAt time.Time
+	// Index of the sample of the relay in the slot, starting from 0
+	Sample uint
+	// Span of the collection of the bid, so analysis can be traced as part of the same bid lifecycle
+	SpanContext trace.SpanContext
+}
+
+// `BidProvenanceEvent` carries the raw response of a relay to a `getHeader` request, including requests which failed
+type BidProvenanceEvent struct {
+	Provenance *types.BidProvenance
 }
 
 type ValidatorRegistrationEvent struct {
@@ -19,3 +37,41 @@ type ValidatorRegistrationEvent struct {
 type AuctionTranscriptEvent struct {
 	Transcript *types.AuctionTranscript
 }
+
+type RegistrationCoverageEvent struct {
+	Relay types.PublicKey
+	Epoch types.Epoch
+	// Number of distinct validators registered with the monitor
+	MonitorRegistrations uint
+	// Number of those validators the relay reports a registration for
+	RelayRegistrations uint
+}
+
+// `RegistrationPropagationEvent` reports how many of the validator registrations forwarded to `Relay` it failed to make available
+type RegistrationPropagationEvent struct {
+	Relay     types.PublicKey
+	Forwarded uint
+	Ignored   uint
+}
+
+type DeliveredPayloadEvent struct {
+	Relay    types.PublicKey
+	BidTrace *types.BidTrace
+}
+
+// `UpcomingSlotEvent` reports the proposer of an upcoming slot and the relays with a registration for it
+type UpcomingSlotEvent struct {
+	UpcomingSlot *types.UpcomingSlot
+}
+
+// `RelayStatusEvent` reports whether a probe of the relay's `status` endpoint in `Slot` found it up
+type RelayStatusEvent struct {
+	Relay types.PublicKey
+	Slot  types.Slot
+	Up    bool
+}
+
+// `BlockEvent` signals a new canonical block at `Slot` is available from the consensus client
+type BlockEvent struct {
+	Slot types.Slot
+}
@@ -0,0 +1,224 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// `KafkaConfig` is a Kafka topic carrying the events of remote collectors to an analyzer
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+	// Consumer group of the analyzer, so consumption resumes from the committed offset after a restart
+	GroupID string `yaml:"group_id"`
+}
+
+func (c *KafkaConfig) Validate() error {
+	if len(c.Brokers) == 0 {
+		return fmt.Errorf("no kafka brokers given")
+	}
+	if c.Topic == "" {
+		return fmt.Errorf("no kafka topic given")
+	}
+	return nil
+}
+
+// `EncodeEvent` encodes `event` as a message for the Kafka topic
+func EncodeEvent(event Event) ([]byte, error) {
+	var b bytes.Buffer
+	encoded := encodable(event)
+	err := gob.NewEncoder(&b).Encode(&encoded)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// `DecodeEvent` decodes an event from a message of the Kafka topic
+func DecodeEvent(message []byte) (Event, error) {
+	var event Event
+	err := gob.NewDecoder(bytes.NewReader(message)).Decode(&event)
+	if err != nil {
+		return event, err
+	}
+	return decoded(event), nil
+}
+
+// `KafkaConsumer` pushes the events of remote collectors from a Kafka topic to the queue of the analyzer
+type KafkaConsumer struct {
+	config *KafkaConfig
+	logger *zap.Logger
+	reader *kafka.Reader
+	events *Queue
+}
+
+func NewKafkaConsumer(config *KafkaConfig, zapLogger *zap.Logger, events *Queue) (*KafkaConsumer, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: config.Brokers,
+		Topic:   config.Topic,
+		GroupID: config.GroupID,
+	})
+	return &KafkaConsumer{
+		config: config,
+		logger: zapLogger,
+		reader: reader,
+		events: events,
+	}, nil
+}
+
+func (k *KafkaConsumer) Run(ctx context.Context) error {
+	logger := k.logger.Sugar()
+
+	defer k.reader.Close()
+	logger.Infof("consuming events from kafka topic %s", k.config.Topic)
+	for {
+		message, err := k.reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("could not read from kafka: %v", err)
+		}
+		event, err := DecodeEvent(message.Value)
+		if err != nil {
+			logger.Warnw("dropping undecodable event from kafka", "error", err, "partition", message.Partition, "offset", message.Offset)
+			metrics.EventsDropped.WithLabelValues("undecodable").Inc()
+			continue
+		}
+		k.events.Push(event)
+	}
+}
+
+// `eventRelay` returns the relay `event` concerns, if any, so the events of each relay are published in order to the same partition
+func eventRelay(event Event) []byte {
+	switch payload := event.Payload.(type) {
+	case *BidEvent:
+		return payload.Context.RelayPublicKey[:]
+	case BidProvenanceEvent:
+		return payload.Provenance.Context.RelayPublicKey[:]
+	case DeliveredPayloadEvent:
+		return payload.Relay[:]
+	case RegistrationCoverageEvent:
+		return payload.Relay[:]
+	case RegistrationPropagationEvent:
+		return payload.Relay[:]
+	case RelayStatusEvent:
+		return payload.Relay[:]
+	default:
+		return nil
+	}
+}
+
+const (
+	// NOTE: the writer otherwise waits a second for a batch to fill before each write
+	kafkaBatchTimeout = 10 * time.Millisecond
+	kafkaMaxBatchSize = 100
+)
+
+// `messageWriter` is the part of `kafka.Writer` used by a `KafkaProducer`
+type messageWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+// `KafkaProducer` publishes the events of a collector to a Kafka topic for a remote analyzer
+type KafkaProducer struct {
+	config *KafkaConfig
+	logger *zap.Logger
+	writer messageWriter
+}
+
+func NewKafkaProducer(config *KafkaConfig, zapLogger *zap.Logger) (*KafkaProducer, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(config.Brokers...),
+		Topic:        config.Topic,
+		Balancer:     &kafka.Hash{},
+		BatchSize:    kafkaMaxBatchSize,
+		BatchTimeout: kafkaBatchTimeout,
+	}
+	return &KafkaProducer{
+		config: config,
+		logger: zapLogger,
+		writer: writer,
+	}, nil
+}
+
+// `Run` publishes the events received from `events` until `ctx` is done, writing the events
+// already queued together so that the rate of events is not bounded by the latency of a write
+func (k *KafkaProducer) Run(ctx context.Context, events <-chan Event) {
+	logger := k.logger.Sugar()
+
+	defer k.writer.Close()
+	logger.Infof("publishing events to kafka topic %s", k.config.Topic)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			batch := []Event{event}
+		drain:
+			for len(batch) < kafkaMaxBatchSize {
+				select {
+				case event := <-events:
+					batch = append(batch, event)
+				default:
+					break drain
+				}
+			}
+			if !k.publish(ctx, batch) {
+				return
+			}
+		}
+	}
+}
+
+// `publish` writes `batch` to the topic, returning false if `ctx` is done
+func (k *KafkaProducer) publish(ctx context.Context, batch []Event) bool {
+	logger := k.logger.Sugar()
+
+	published := make([]Event, 0, len(batch))
+	messages := make([]kafka.Message, 0, len(batch))
+	for _, event := range batch {
+		message, err := EncodeEvent(event)
+		if err != nil {
+			logger.Warnw("could not encode event, dropping it", "error", err, "type", eventType(event))
+			metrics.EventsDropped.WithLabelValues(eventType(event)).Inc()
+			continue
+		}
+		published = append(published, event)
+		messages = append(messages, kafka.Message{
+			Key:   eventRelay(event),
+			Value: message,
+		})
+	}
+	if len(messages) == 0 {
+		return true
+	}
+
+	err := k.writer.WriteMessages(ctx, messages...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		logger.Warnw("could not publish events to kafka, dropping them", "error", err, "count", len(published))
+		for _, event := range published {
+			metrics.EventsDropped.WithLabelValues(eventType(event)).Inc()
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+func TestEncodeEvent(t *testing.T) {
+	bidEvent := &BidEvent{
+		Context: &types.BidContext{
+			Slot:           10,
+			RelayPublicKey: types.PublicKey{0x01},
+		},
+		Latency:    150 * time.Millisecond,
+		ReceivedAt: time.Unix(1_700_000_000, 0).UTC(),
+		Sample:     2,
+	}
+	for _, event := range []Event{
+		{Payload: bidEvent},
+		{Payload: BlockEvent{Slot: 10}},
+		{Payload: DeliveredPayloadEvent{Relay: types.PublicKey{0x02}, BidTrace: &types.BidTrace{Slot: 9}}},
+	} {
+		message, err := EncodeEvent(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := DecodeEvent(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(decoded, event) {
+			t.Errorf("expected %+v but decoded %+v", event.Payload, decoded.Payload)
+		}
+	}
+}
+
+func TestEventRelay(t *testing.T) {
+	relay := types.PublicKey{0x01}
+	for _, tc := range []struct {
+		event    Event
+		expected []byte
+	}{
+		{event: Event{Payload: &BidEvent{Context: &types.BidContext{RelayPublicKey: relay}}}, expected: relay[:]},
+		{event: Event{Payload: RelayStatusEvent{Relay: relay, Slot: 10, Up: true}}, expected: relay[:]},
+		{event: Event{Payload: BlockEvent{Slot: 10}}, expected: nil},
+	} {
+		key := eventRelay(tc.event)
+		if !reflect.DeepEqual(key, tc.expected) {
+			t.Errorf("%+v: expected key %x but got %x", tc.event.Payload, tc.expected, key)
+		}
+	}
+}
+
+// `slowWriter` takes `latency` for each write, like a `kafka.Writer` waiting on its batch timeout
+type slowWriter struct {
+	latency time.Duration
+	written chan struct{}
+}
+
+func (w *slowWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	time.Sleep(w.latency)
+	for range messages {
+		w.written <- struct{}{}
+	}
+	return nil
+}
+
+func (w *slowWriter) Close() error {
+	return nil
+}
+
+func TestKafkaProducerBatchesEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventCount := 500
+	writer := &slowWriter{latency: 50 * time.Millisecond, written: make(chan struct{}, eventCount)}
+	producer := &KafkaProducer{config: &KafkaConfig{Topic: "events"}, logger: zap.NewNop(), writer: writer}
+	events := make(chan Event, eventCount)
+	go producer.Run(ctx, events)
+
+	start := time.Now()
+	for i := 0; i < eventCount; i++ {
+		events <- Event{Payload: BlockEvent{Slot: types.Slot(i)}}
+	}
+	timeout := time.After(5 * time.Second)
+	for i := 0; i < eventCount; i++ {
+		select {
+		case <-writer.written:
+		case <-timeout:
+			t.Fatalf("only %d of %d events were published after %s", i, eventCount, time.Since(start))
+		}
+	}
+	// NOTE: writing one event at a time would take `eventCount` times the latency of a write
+	if elapsed := time.Since(start); elapsed > time.Duration(eventCount/10)*writer.latency {
+		t.Fatalf("publishing %d events took %s", eventCount, elapsed)
+	}
+}
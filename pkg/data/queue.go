@@ -0,0 +1,329 @@
+package data
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultQueueCapacity = 32
+
+	// Senders wait until the queue has capacity
+	BlockOverflowPolicy = "block"
+	// Events pushed to a full queue are dropped
+	DropOverflowPolicy = "drop"
+	// Events pushed to a full queue are written to a file and pushed again once the queue has capacity
+	SpillOverflowPolicy = "spill"
+)
+
+// `QueueConfig` bounds the queue of events from the collector and the API to the analyzer
+type QueueConfig struct {
+	Capacity uint `yaml:"capacity"`
+	// What happens to events pushed to a full queue: `block` (default), `drop` or `spill`
+	Overflow string `yaml:"overflow"`
+	// File events are spilled to with the `spill` policy, which is truncated whenever all spilled events were pushed again
+	SpillFile string `yaml:"spill_file"`
+}
+
+func (c *QueueConfig) capacity() uint {
+	if c == nil || c.Capacity == 0 {
+		return DefaultQueueCapacity
+	}
+	return c.Capacity
+}
+
+func (c *QueueConfig) overflow() string {
+	if c == nil || c.Overflow == "" {
+		return BlockOverflowPolicy
+	}
+	return c.Overflow
+}
+
+func (c *QueueConfig) Validate() error {
+	switch c.overflow() {
+	case BlockOverflowPolicy, DropOverflowPolicy:
+		return nil
+	case SpillOverflowPolicy:
+		if c.SpillFile == "" {
+			return fmt.Errorf("a spill file is required with the %s overflow policy", SpillOverflowPolicy)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown overflow policy %s", c.Overflow)
+	}
+}
+
+// `spilledBidEvent` is the encoding of a `BidEvent` outside of the process, as the span context of a bid cannot be encoded
+type spilledBidEvent struct {
+	Context    *types.BidContext
+	Bid        *types.Bid
+	Latency    time.Duration
+	ReceivedAt time.Time
+	Sample     uint
+}
+
+// `encodable` returns `event` with a payload which can be encoded with gob
+func encodable(event Event) Event {
+	if bidEvent, ok := event.Payload.(*BidEvent); ok {
+		return Event{Payload: &spilledBidEvent{
+			Context:    bidEvent.Context,
+			Bid:        bidEvent.Bid,
+			Latency:    bidEvent.Latency,
+			ReceivedAt: bidEvent.ReceivedAt,
+			Sample:     bidEvent.Sample,
+		}}
+	}
+	return event
+}
+
+// `decoded` returns the event given by the gob decoding of an `encodable` event
+func decoded(event Event) Event {
+	if bidEvent, ok := event.Payload.(*spilledBidEvent); ok {
+		return Event{Payload: &BidEvent{
+			Context:    bidEvent.Context,
+			Bid:        bidEvent.Bid,
+			Latency:    bidEvent.Latency,
+			ReceivedAt: bidEvent.ReceivedAt,
+			Sample:     bidEvent.Sample,
+		}}
+	}
+	return event
+}
+
+func init() {
+	gob.Register(&spilledBidEvent{})
+	gob.Register(BidProvenanceEvent{})
+	gob.Register(ValidatorRegistrationEvent{})
+	gob.Register(AuctionTranscriptEvent{})
+	gob.Register(RegistrationCoverageEvent{})
+	gob.Register(RegistrationPropagationEvent{})
+	gob.Register(DeliveredPayloadEvent{})
+	gob.Register(UpcomingSlotEvent{})
+	gob.Register(BlockEvent{})
+	gob.Register(RelayStatusEvent{})
+}
+
+// `Queue` is a bounded queue of events which applies its overflow policy to events pushed while it is full
+type Queue struct {
+	logger   *zap.Logger
+	overflow string
+	events   chan Event
+
+	// state of the spill file, if events are spilled
+	lock    sync.Mutex
+	file    *os.File
+	reader  *os.File
+	encoder *gob.Encoder
+	decoder *gob.Decoder
+	// Number of spilled events which were not yet pushed again to `events`
+	spilled uint
+	// Signals that an event was spilled
+	spills chan struct{}
+}
+
+func NewQueue(config *QueueConfig, zapLogger *zap.Logger) (*Queue, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	q := &Queue{
+		logger:   zapLogger,
+		overflow: config.overflow(),
+		events:   make(chan Event, config.capacity()),
+		spills:   make(chan struct{}, 1),
+	}
+	if q.overflow == SpillOverflowPolicy {
+		file, err := os.OpenFile(config.SpillFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("could not open spill file: %v", err)
+		}
+		q.file = file
+		q.resetSpillFile()
+	}
+	return q, nil
+}
+
+// `Events` is the channel to receive the events of the queue from
+func (q *Queue) Events() <-chan Event {
+	return q.events
+}
+
+// `Push` adds `event` to the queue, applying the overflow policy if the queue is full
+func (q *Queue) Push(event Event) {
+	defer metrics.EventChannelDepth.Set(float64(len(q.events)))
+
+	switch q.overflow {
+	case DropOverflowPolicy:
+		select {
+		case q.events <- event:
+		default:
+			metrics.EventsDropped.WithLabelValues(eventType(event)).Inc()
+		}
+	case SpillOverflowPolicy:
+		q.lock.Lock()
+		defer q.lock.Unlock()
+
+		// NOTE: events are spilled while any spilled event is pending to keep the order of events
+		if q.spilled == 0 {
+			select {
+			case q.events <- event:
+				return
+			default:
+			}
+		}
+		q.spill(event)
+	default:
+		q.events <- event
+	}
+}
+
+// `spill` writes `event` to the spill file, dropping it if it cannot be written
+// NOTE: must be called with `lock` held
+func (q *Queue) spill(event Event) {
+	logger := q.logger.Sugar()
+
+	spilled := encodable(event)
+	err := q.encoder.Encode(&spilled)
+	if err != nil {
+		logger.Warnw("could not spill event, dropping it", "error", err, "type", eventType(event))
+		metrics.EventsDropped.WithLabelValues(eventType(event)).Inc()
+		return
+	}
+	q.spilled += 1
+	metrics.EventsSpilled.Set(float64(q.spilled))
+	select {
+	case q.spills <- struct{}{}:
+	default:
+	}
+}
+
+// `unspill` reads the next spilled event from the spill file
+// NOTE: must be called with `lock` held
+func (q *Queue) unspill() (Event, error) {
+	var event Event
+	err := q.decoder.Decode(&event)
+	if err != nil {
+		return event, err
+	}
+	return decoded(event), nil
+}
+
+// `resetSpillFile` truncates the spill file once every spilled event was pushed again
+// NOTE: must be called with `lock` held
+func (q *Queue) resetSpillFile() {
+	logger := q.logger.Sugar()
+
+	err := q.file.Truncate(0)
+	if err != nil {
+		logger.Warnw("could not truncate spill file", "error", err)
+	}
+	_, err = q.file.Seek(0, 0)
+	if err != nil {
+		logger.Warnw("could not rewind spill file", "error", err)
+	}
+	if q.reader != nil {
+		q.reader.Close()
+	}
+	// NOTE: spilled events are read from a second handle so reads do not move the offset of writes
+	reader, err := os.Open(q.file.Name())
+	if err != nil {
+		logger.Warnw("could not open spill file for reading", "error", err)
+		return
+	}
+	q.reader = reader
+	q.encoder = gob.NewEncoder(q.file)
+	q.decoder = gob.NewDecoder(reader)
+}
+
+// `Run` pushes spilled events to the queue again as it has capacity until `ctx` is done
+func (q *Queue) Run(ctx context.Context) {
+	if q.overflow != SpillOverflowPolicy {
+		return
+	}
+	logger := q.logger.Sugar()
+
+	defer func() {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+
+		q.reader.Close()
+		q.file.Close()
+	}()
+	for {
+		q.lock.Lock()
+		pending := q.spilled
+		q.lock.Unlock()
+
+		if pending == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.spills:
+				continue
+			}
+		}
+
+		q.lock.Lock()
+		event, err := q.unspill()
+		q.lock.Unlock()
+		if err != nil {
+			logger.Warnw("could not read spilled events, dropping them", "error", err, "count", pending)
+			metrics.EventsDropped.WithLabelValues("spilled").Add(float64(pending))
+			q.lock.Lock()
+			q.spilled = 0
+			q.resetSpillFile()
+			q.lock.Unlock()
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case q.events <- event:
+		}
+
+		q.lock.Lock()
+		q.spilled -= 1
+		if q.spilled == 0 {
+			q.resetSpillFile()
+		}
+		metrics.EventsSpilled.Set(float64(q.spilled))
+		q.lock.Unlock()
+	}
+}
+
+// `eventType` names the type of the payload of `event` for metrics
+func eventType(event Event) string {
+	switch event.Payload.(type) {
+	case *BidEvent:
+		return "bid"
+	case BidProvenanceEvent:
+		return "bid_provenance"
+	case ValidatorRegistrationEvent:
+		return "validator_registration"
+	case AuctionTranscriptEvent:
+		return "auction_transcript"
+	case RegistrationCoverageEvent:
+		return "registration_coverage"
+	case RegistrationPropagationEvent:
+		return "registration_propagation"
+	case DeliveredPayloadEvent:
+		return "delivered_payload"
+	case UpcomingSlotEvent:
+		return "upcoming_slot"
+	case BlockEvent:
+		return "block"
+	case RelayStatusEvent:
+		return "relay_status"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,90 @@
+package data
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+func TestQueueDrop(t *testing.T) {
+	q, err := NewQueue(&QueueConfig{Capacity: 1, Overflow: DropOverflowPolicy}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Push(Event{Payload: BlockEvent{Slot: 1}})
+	q.Push(Event{Payload: BlockEvent{Slot: 2}})
+
+	event := <-q.Events()
+	if event.Payload.(BlockEvent).Slot != 1 {
+		t.Fatalf("expected first event to be kept, got %+v", event)
+	}
+	select {
+	case event := <-q.Events():
+		t.Fatalf("expected event pushed to full queue to be dropped, got %+v", event)
+	default:
+	}
+}
+
+func TestQueueSpill(t *testing.T) {
+	config := &QueueConfig{
+		Capacity:  1,
+		Overflow:  SpillOverflowPolicy,
+		SpillFile: filepath.Join(t.TempDir(), "events.spill"),
+	}
+	q, err := NewQueue(config, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+
+	bidCtx := &types.BidContext{Slot: 3}
+	q.Push(Event{Payload: BlockEvent{Slot: 1}})
+	q.Push(Event{Payload: BlockEvent{Slot: 2}})
+	q.Push(Event{Payload: &BidEvent{Context: bidCtx, Sample: 1}})
+
+	for round := 0; round < 2; round++ {
+		for _, expected := range []types.Slot{1, 2, 3} {
+			select {
+			case event := <-q.Events():
+				var slot types.Slot
+				switch payload := event.Payload.(type) {
+				case BlockEvent:
+					slot = payload.Slot
+				case *BidEvent:
+					slot = payload.Context.Slot
+					if payload.Sample != 1 {
+						t.Fatalf("expected spilled bid event to keep its sample, got %+v", payload)
+					}
+				}
+				if slot != expected {
+					t.Fatalf("expected event of slot %d, got %+v", expected, event.Payload)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for event of slot %d", expected)
+			}
+		}
+		// events are spilled again once the spill file was truncated
+		q.Push(Event{Payload: BlockEvent{Slot: 1}})
+		q.Push(Event{Payload: BlockEvent{Slot: 2}})
+		q.Push(Event{Payload: &BidEvent{Context: bidCtx, Sample: 1}})
+	}
+}
+
+func TestQueueConfigValidate(t *testing.T) {
+	var config *QueueConfig
+	if err := config.Validate(); err != nil {
+		t.Fatalf("expected defaults to be valid: %v", err)
+	}
+	if err := (&QueueConfig{Overflow: SpillOverflowPolicy}).Validate(); err == nil {
+		t.Fatal("expected spill policy without a spill file to be invalid")
+	}
+	if err := (&QueueConfig{Overflow: "unknown"}).Validate(); err == nil {
+		t.Fatal("expected unknown policy to be invalid")
+	}
+}
@@ -0,0 +1,67 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/builder"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `checkRegistrationPropagation` returns the number of `registrations` the relay does not report, or only reports an older registration for
+func (c *Collector) checkRegistrationPropagation(relay *builder.Client, registrations []types.SignedValidatorRegistration) (uint, error) {
+	var ignored uint
+	for _, registration := range registrations {
+		publicKey := registration.Message.Pubkey
+		relayRegistration, err := relay.GetValidatorRegistration(&publicKey)
+		if err != nil {
+			return 0, err
+		}
+		if relayRegistration == nil || relayRegistration.Message.Timestamp < registration.Message.Timestamp {
+			ignored += 1
+		}
+	}
+	return ignored, nil
+}
+
+func (c *Collector) forwardRegistrationsToRelay(ctx context.Context, relay *builder.Client, registrations []types.SignedValidatorRegistration) {
+	logger := c.logger.Sugar()
+
+	relayID := relay.PublicKey
+
+	err := relay.RegisterValidators(registrations)
+	if err != nil {
+		// NOTE: the relay may still have accepted some of the registrations so check propagation regardless
+		logger.Warnw("could not forward validator registrations to relay", "error", err, "relayPublicKey", relayID, "count", len(registrations))
+	}
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(c.config.registrationPropagationDelay()):
+	}
+
+	ignored, err := c.checkRegistrationPropagation(relay, registrations)
+	if err != nil {
+		logger.Warnw("could not check propagation of validator registrations to relay", "error", err, "relayPublicKey", relayID)
+		return
+	}
+	c.events.Push(Event{Payload: RegistrationPropagationEvent{
+		Relay:     relayID,
+		Forwarded: uint(len(registrations)),
+		Ignored:   ignored,
+	}})
+}
+
+func (c *Collector) forwardRegistrations(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case registrations := <-c.registrations:
+			for _, relay := range c.getRelays() {
+				go c.forwardRegistrationsToRelay(ctx, relay, registrations)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/builder"
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+)
+
+// `probeRelayStatus` probes the `status` endpoint of the relay every status interval, reporting whether the relay was up in the current slot
+func (c *Collector) probeRelayStatus(ctx context.Context, relay *builder.Client) {
+	logger := c.logger.Sugar()
+
+	relayID := relay.PublicKey
+
+	ticker := time.NewTicker(c.config.statusInterval())
+	defer ticker.Stop()
+	for {
+		err := relay.GetStatus()
+		up := err == nil
+		if !up {
+			logger.Warnw("relay status was not healthy", "error", err, "relayPublicKey", relayID)
+		}
+		upValue := 0.0
+		if up {
+			upValue = 1.0
+		}
+		metrics.RelayUp.WithLabelValues(relayID.String()).Set(upValue)
+
+		c.events.Push(Event{Payload: RelayStatusEvent{
+			Relay: relayID,
+			Slot:  c.clock.CurrentSlot(time.Now().Unix()),
+			Up:    up,
+		}})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
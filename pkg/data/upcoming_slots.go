@@ -0,0 +1,47 @@
+package data
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `collectUpcomingSlots` sends the proposer of each slot in `epoch` along with the relays which have a registration for it,
+// so that relays and builders can verify their targeting ahead of the slot.
+func (c *Collector) collectUpcomingSlots(ctx context.Context, epoch types.Epoch) {
+	logger := c.logger.Sugar()
+
+	startSlot := c.clock.StartSlotOfEpoch(epoch)
+	endSlot := c.clock.StartSlotOfEpoch(epoch + 1)
+	for slot := startSlot; slot < endSlot; slot++ {
+		proposer, err := c.consensusClient.GetProposerPublicKey(ctx, slot)
+		if err != nil {
+			logger.Warnw("could not get proposer of upcoming slot", "error", err, "slot", slot)
+			continue
+		}
+		upcomingSlot := &types.UpcomingSlot{
+			Slot:              slot,
+			ProposerPublicKey: *proposer,
+			Relays:            []types.PublicKey{},
+		}
+		if c.store != nil {
+			registration, err := store.GetLatestValidatorRegistration(ctx, c.store, proposer)
+			if err != nil {
+				logger.Warnw("could not get registration of proposer of upcoming slot", "error", err, "slot", slot, "proposer", proposer)
+			}
+			upcomingSlot.Registered = registration != nil
+		}
+		for _, relay := range c.getRelays() {
+			relayRegistration, err := relay.GetValidatorRegistration(proposer)
+			if err != nil {
+				logger.Debugw("could not get registration of proposer of upcoming slot from relay", "error", err, "slot", slot, "relay", relay.PublicKey)
+				continue
+			}
+			if relayRegistration != nil {
+				upcomingSlot.Relays = append(upcomingSlot.Relays, relay.PublicKey)
+			}
+		}
+		c.events.Push(Event{Payload: UpcomingSlotEvent{UpcomingSlot: upcomingSlot}})
+	}
+}
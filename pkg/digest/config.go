@@ -0,0 +1,23 @@
+package digest
+
+import (
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/email"
+)
+
+const DefaultInterval = 7 * 24 * time.Hour
+
+type Config struct {
+	// Time between digests, also the span covered by each digest
+	Interval time.Duration `yaml:"interval"`
+	// If given, email each digest, otherwise digests are only logged
+	Email *email.Config `yaml:"email"`
+}
+
+func (c *Config) interval() time.Duration {
+	if c == nil || c.Interval == 0 {
+		return DefaultInterval
+	}
+	return c.Interval
+}
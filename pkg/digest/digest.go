@@ -0,0 +1,138 @@
+// Package digest periodically summarizes the findings of the monitor for operators
+package digest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+	"github.com/ralexstokes/relay-monitor/pkg/email"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"go.uber.org/zap"
+)
+
+var markdownTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"percent": func(value float64) string {
+		return fmt.Sprintf("%.1f%%", 100*value)
+	},
+	"delta": func(current, previous float64) string {
+		return fmt.Sprintf("%+.3f", current-previous)
+	},
+}).Parse(`# Relay monitor digest for {{ .Network }}
+
+Slots {{ .Digest.StartSlot }} to {{ .Digest.EndSlot }}.
+
+## Top faults
+{{ range .Faulty }}
+### ` + "`{{ .RelayPublicKey }}`" + `
+
+{{ .TotalFaults }} faults out of {{ .AnalyzedBids }} analyzed bids:
+{{ range $category, $count := .Faults }}
+- {{ $category }}: {{ $count }}{{ end }}
+{{ else }}
+No faults were recorded.
+{{ end }}
+## Scores and availability
+
+| Relay | Score | Change | Availability |
+| --- | --- | --- | --- |
+{{ range .Digest.Relays }}| ` + "`{{ .RelayPublicKey }}`" + ` | {{ printf "%.3f" .Score }} | {{ delta .Score .PreviousScore }} | {{ percent .Availability }} |
+{{ end }}`))
+
+// `Render` formats the digest as Markdown
+func Render(network string, digest *reporter.Digest) (string, error) {
+	var faulty []reporter.RelayDigest
+	for _, relay := range digest.Relays {
+		if relay.TotalFaults > 0 {
+			faulty = append(faulty, relay)
+		}
+	}
+
+	var b strings.Builder
+	err := markdownTemplate.Execute(&b, struct {
+		Network string
+		Digest  *reporter.Digest
+		Faulty  []reporter.RelayDigest
+	}{
+		Network: network,
+		Digest:  digest,
+		Faulty:  faulty,
+	})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// `Generator` composes a digest of the monitor's findings every interval and delivers it to operators
+type Generator struct {
+	config  *Config
+	logger  *zap.Logger
+	network string
+
+	reporter *reporter.Reporter
+	clock    *consensus.Clock
+}
+
+func New(config *Config, zapLogger *zap.Logger, network string, reporter *reporter.Reporter, clock *consensus.Clock) *Generator {
+	return &Generator{
+		config:   config,
+		logger:   zapLogger,
+		network:  network,
+		reporter: reporter,
+		clock:    clock,
+	}
+}
+
+// `Compose` renders the digest for the interval preceding `now`
+func (g *Generator) Compose(ctx context.Context, now time.Time) (string, error) {
+	startSlot := g.clock.CurrentSlot(now.Add(-g.config.interval()).Unix())
+	endSlot := g.clock.CurrentSlot(now.Unix())
+	if endSlot > startSlot {
+		// NOTE: exclude the current slot as its auction is still underway
+		endSlot -= 1
+	}
+	digest, err := g.reporter.GetDigest(ctx, startSlot, endSlot)
+	if err != nil {
+		return "", fmt.Errorf("could not compute digest: %v", err)
+	}
+	return Render(g.network, digest)
+}
+
+func (g *Generator) deliver(ctx context.Context, now time.Time) {
+	logger := g.logger.Sugar()
+
+	body, err := g.Compose(ctx, now)
+	if err != nil {
+		logger.Warnf("could not compose digest: %v", err)
+		return
+	}
+
+	if g.config.Email == nil {
+		logger.Infof("digest of the relay monitor:\n%s", body)
+		return
+	}
+	subject := fmt.Sprintf("Relay monitor digest for %s (%s)", g.network, now.UTC().Format("2006-01-02"))
+	err = email.Send(g.config.Email, subject, body)
+	if err != nil {
+		logger.Warnf("could not email digest: %v", err)
+		return
+	}
+	logger.Infof("emailed digest to %s", strings.Join(g.config.Email.To, ", "))
+}
+
+func (g *Generator) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.config.interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			g.deliver(ctx, now)
+		}
+	}
+}
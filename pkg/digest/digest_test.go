@@ -0,0 +1,53 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestRender(t *testing.T) {
+	digest := &reporter.Digest{
+		StartSlot: 100,
+		EndSlot:   150,
+		Relays: []reporter.RelayDigest{
+			{
+				RelayPublicKey: types.PublicKey{0x01},
+				AnalyzedBids:   40,
+				TotalFaults:    2,
+				Faults:         map[string]uint{"consensus_invalid": 2},
+				Score:          0.95,
+				PreviousScore:  0.99,
+				Availability:   0.8,
+			},
+			{
+				RelayPublicKey: types.PublicKey{0x02},
+				AnalyzedBids:   50,
+				Score:          1,
+				PreviousScore:  1,
+				Availability:   1,
+			},
+		},
+	}
+	body, err := Render("mainnet", digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"# Relay monitor digest for mainnet",
+		"Slots 100 to 150.",
+		"2 faults out of 40 analyzed bids",
+		"- consensus_invalid: 2",
+		"| 0.950 | -0.040 | 80.0% |",
+		"| 1.000 | +0.000 | 100.0% |",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Fatalf("expected digest to contain %q", expected)
+		}
+	}
+	if strings.Count(body, "###") != 1 {
+		t.Fatal("expected only relays with faults to be listed under the top faults")
+	}
+}
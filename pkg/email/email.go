@@ -0,0 +1,127 @@
+// Package email delivers messages from the monitor to operators over SMTP
+package email
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const (
+	// Upgrade the connection with `STARTTLS` if the server supports it
+	StartTLSMode = "starttls"
+	// Connect over TLS, e.g. to port 465
+	ImplicitTLSMode = "tls"
+
+	dialTimeout = 10 * time.Second
+)
+
+type Config struct {
+	// Address of the SMTP server as `host:port`
+	Server string `yaml:"server"`
+	// One of `starttls` (the default) or `tls`
+	TLS string `yaml:"tls"`
+	// If given, authenticate to the server with these credentials
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+func (c *Config) Validate() error {
+	_, _, err := net.SplitHostPort(c.Server)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP server %s: %v", c.Server, err)
+	}
+	switch c.TLS {
+	case "", StartTLSMode, ImplicitTLSMode:
+	default:
+		return fmt.Errorf("unknown TLS mode %s", c.TLS)
+	}
+	if c.From == "" || len(c.To) == 0 {
+		return fmt.Errorf("sender and recipients are required")
+	}
+	return nil
+}
+
+func buildMessage(from string, to []string, subject, body string) []byte {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/markdown; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(msg.String())
+}
+
+// `Send` emails `body` under `subject` to the recipients of `config`
+func Send(config *Config, subject, body string) error {
+	host, _, err := net.SplitHostPort(config.Server)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP server %s: %v", config.Server, err)
+	}
+	tlsConfig := &tls.Config{ServerName: host}
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	if config.TLS == ImplicitTLSMode {
+		conn, err = tls.DialWithDialer(dialer, "tcp", config.Server, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", config.Server)
+	}
+	if err != nil {
+		return fmt.Errorf("could not connect to SMTP server: %v", err)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("could not start SMTP session: %v", err)
+	}
+	defer client.Close()
+
+	if config.TLS != ImplicitTLSMode {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			err = client.StartTLS(tlsConfig)
+			if err != nil {
+				return fmt.Errorf("could not start TLS: %v", err)
+			}
+		}
+	}
+	if config.Username != "" {
+		// NOTE: `PlainAuth` refuses to send credentials over an unencrypted connection to a remote server
+		err = client.Auth(smtp.PlainAuth("", config.Username, config.Password, host))
+		if err != nil {
+			return fmt.Errorf("could not authenticate to SMTP server: %v", err)
+		}
+	}
+
+	err = client.Mail(config.From)
+	if err != nil {
+		return err
+	}
+	for _, recipient := range config.To {
+		err = client.Rcpt(recipient)
+		if err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(buildMessage(config.From, config.To, subject, body))
+	if err != nil {
+		return err
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,33 @@
+package email
+
+import "testing"
+
+func TestBuildMessage(t *testing.T) {
+	msg := buildMessage("monitor@example.com", []string{"a@example.com", "b@example.com"}, "Faults", "line one\nline two")
+	expected := "From: monitor@example.com\r\n" +
+		"To: a@example.com, b@example.com\r\n" +
+		"Subject: Faults\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/markdown; charset=UTF-8\r\n" +
+		"\r\n" +
+		"line one\r\nline two"
+	if string(msg) != expected {
+		t.Fatalf("unexpected message: %q", msg)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	config := &Config{Server: "smtp.example.com:587", From: "monitor@example.com", To: []string{"ops@example.com"}}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	config.TLS = "ssl"
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected unknown TLS mode to be invalid")
+	}
+	config.TLS = ImplicitTLSMode
+	config.Server = "smtp.example.com"
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected server without a port to be invalid")
+	}
+}
@@ -0,0 +1,299 @@
+// Package execution queries the state of the execution layer from an execution client over its JSON-RPC API
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ralexstokes/relay-monitor/pkg/cache"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	DefaultTimeout   = 10 * time.Second
+	DefaultCacheSize = 1024
+	// Expiry of entries cached in redis, the data of past blocks is only queried while bids of recent slots are verified
+	cacheTTL = 4 * time.Hour
+)
+
+type Config struct {
+	// JSON-RPC endpoint of an execution client with the state of recent blocks, e.g. `http://127.0.0.1:8545`
+	Endpoint string `yaml:"endpoint"`
+	// Timeout of each request to the execution client, `DefaultTimeout` if unset
+	Timeout time.Duration `yaml:"timeout"`
+	// Number of entries of each cache of the client kept in memory, `DefaultCacheSize` if unset
+	CacheSize int `yaml:"cache_size"`
+}
+
+func (c *Config) timeout() time.Duration {
+	if c == nil || c.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return c.Timeout
+}
+
+func (c *Config) cacheSize() int {
+	if c == nil || c.CacheSize == 0 {
+		return DefaultCacheSize
+	}
+	return c.CacheSize
+}
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.Endpoint == "" {
+		return fmt.Errorf("missing endpoint of execution client")
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout of execution client must not be negative")
+	}
+	if c.CacheSize < 0 {
+		return fmt.Errorf("cache size of execution client must not be negative")
+	}
+	return nil
+}
+
+// `balanceKey` identifies the balance of an account after a block
+type balanceKey struct {
+	Address     types.Address
+	BlockNumber uint64
+}
+
+// `Client` serves the execution state the analyzer verifies bids against, caching the data of past blocks,
+// which does not change once the blocks are final
+type Client struct {
+	rpc     *rpc.Client
+	client  *ethclient.Client
+	timeout time.Duration
+
+	balanceCache  cache.Cache[balanceKey, *big.Int]
+	headerCache   cache.Cache[uint64, *gethTypes.Header]
+	receiptCache  cache.Cache[types.Hash, *gethTypes.Receipt]
+	receiptsCache cache.Cache[uint64, []*gethTypes.Receipt]
+}
+
+func NewClient(ctx context.Context, config *Config, cacheBackend *cache.Backend) (*Client, error) {
+	rpcClient, err := rpc.DialContext(ctx, config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	size := config.cacheSize()
+	balanceCache, err := cache.New[balanceKey, *big.Int](cacheBackend, "execution_balances", size, cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	headerCache, err := cache.New[uint64, *gethTypes.Header](cacheBackend, "execution_headers", size, cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	receiptCache, err := cache.New[types.Hash, *gethTypes.Receipt](cacheBackend, "execution_receipts", size, cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	receiptsCache, err := cache.New[uint64, []*gethTypes.Receipt](cacheBackend, "execution_block_receipts", size, cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		rpc:           rpcClient,
+		client:        ethclient.NewClient(rpcClient),
+		timeout:       config.timeout(),
+		balanceCache:  balanceCache,
+		headerCache:   headerCache,
+		receiptCache:  receiptCache,
+		receiptsCache: receiptsCache,
+	}, nil
+}
+
+func (c *Client) Close() {
+	c.client.Close()
+}
+
+// `GetBalance` returns the balance of `address` after the block with `blockNumber`, in wei
+func (c *Client) GetBalance(ctx context.Context, address types.Address, blockNumber uint64) (*big.Int, error) {
+	key := balanceKey{Address: address, BlockNumber: blockNumber}
+	if balance, ok := c.balanceCache.Get(key); ok {
+		return new(big.Int).Set(balance), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	balance, err := c.client.BalanceAt(ctx, common.Address(address), new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("could not get balance of %s after block %d: %w", common.Address(address), blockNumber, err)
+	}
+	c.balanceCache.Add(key, balance)
+	return new(big.Int).Set(balance), nil
+}
+
+// `GetBalanceDelta` returns the change in the balance of `address` over the block with `blockNumber`, in wei
+func (c *Client) GetBalanceDelta(ctx context.Context, address types.Address, blockNumber uint64) (*big.Int, error) {
+	if blockNumber == 0 {
+		return nil, fmt.Errorf("genesis block has no parent state")
+	}
+	before, err := c.GetBalance(ctx, address, blockNumber-1)
+	if err != nil {
+		return nil, err
+	}
+	after, err := c.GetBalance(ctx, address, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return after.Sub(after, before), nil
+}
+
+// `GetHeader` returns the header of the block with `blockNumber`
+func (c *Client) GetHeader(ctx context.Context, blockNumber uint64) (*gethTypes.Header, error) {
+	if header, ok := c.headerCache.Get(blockNumber); ok {
+		return header, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	header, err := c.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("could not get header of block %d: %w", blockNumber, err)
+	}
+	c.headerCache.Add(blockNumber, header)
+	return header, nil
+}
+
+// `GetBlock` returns the block with `blockNumber`, including its transactions
+// NOTE: blocks are not cached as their transactions are given by the execution payloads of the consensus client
+func (c *Client) GetBlock(ctx context.Context, blockNumber uint64) (*gethTypes.Block, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	block, err := c.client.BlockByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("could not get block %d: %w", blockNumber, err)
+	}
+	return block, nil
+}
+
+// `GetTransaction` returns the transaction with `hash`
+func (c *Client) GetTransaction(ctx context.Context, hash types.Hash) (*gethTypes.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	transaction, _, err := c.client.TransactionByHash(ctx, common.Hash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("could not get transaction %s: %w", common.Hash(hash), err)
+	}
+	return transaction, nil
+}
+
+// `GetReceipt` returns the receipt of the transaction with `hash`
+func (c *Client) GetReceipt(ctx context.Context, hash types.Hash) (*gethTypes.Receipt, error) {
+	if receipt, ok := c.receiptCache.Get(hash); ok {
+		return receipt, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	receipt, err := c.client.TransactionReceipt(ctx, common.Hash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("could not get receipt of transaction %s: %w", common.Hash(hash), err)
+	}
+	c.receiptCache.Add(hash, receipt)
+	return receipt, nil
+}
+
+// `GetBlockReceipts` returns the receipts of the transactions of the block with `blockNumber`, in the order of the block
+func (c *Client) GetBlockReceipts(ctx context.Context, blockNumber uint64) ([]*gethTypes.Receipt, error) {
+	if receipts, ok := c.receiptsCache.Get(blockNumber); ok {
+		return receipts, nil
+	}
+
+	hashes, err := c.getTransactionHashes(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	receipts := make([]*gethTypes.Receipt, 0, len(hashes))
+	for _, hash := range hashes {
+		receipt, err := c.GetReceipt(ctx, types.Hash(hash))
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	c.receiptsCache.Add(blockNumber, receipts)
+	return receipts, nil
+}
+
+// `getTransactionHashes` returns the hashes of the transactions of the block with `blockNumber`,
+// without decoding the transactions themselves
+func (c *Client) getTransactionHashes(ctx context.Context, blockNumber uint64) ([]common.Hash, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var block *struct {
+		Transactions []common.Hash `json:"transactions"`
+	}
+	err := c.rpc.CallContext(ctx, &block, "eth_getBlockByNumber", hexutil.EncodeUint64(blockNumber), false)
+	if err != nil {
+		return nil, fmt.Errorf("could not get transactions of block %d: %w", blockNumber, err)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("could not find block %d", blockNumber)
+	}
+	return block.Transactions, nil
+}
+
+// `GetNonce` returns the nonce of `address` after the block with `blockNumber`
+func (c *Client) GetNonce(ctx context.Context, address types.Address, blockNumber uint64) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	nonce, err := c.client.NonceAt(ctx, common.Address(address), new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return 0, fmt.Errorf("could not get nonce of %s after block %d: %w", common.Address(address), blockNumber, err)
+	}
+	return nonce, nil
+}
+
+// `PendingTransaction` is a transaction of the mempool of the execution client with its sender
+type PendingTransaction struct {
+	From        types.Address
+	Transaction *gethTypes.Transaction
+}
+
+// `GetPendingTransactions` returns the executable transactions of the mempool of the execution client,
+// which must serve the `txpool` namespace of the JSON-RPC API
+// NOTE: pending transactions are not cached as the mempool changes with every block
+func (c *Client) GetPendingTransactions(ctx context.Context) ([]PendingTransaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var content struct {
+		Pending map[common.Address]map[string]*gethTypes.Transaction `json:"pending"`
+	}
+	err := c.rpc.CallContext(ctx, &content, "txpool_content")
+	if err != nil {
+		return nil, fmt.Errorf("could not get pending transactions: %w", err)
+	}
+	var transactions []PendingTransaction
+	for from, pending := range content.Pending {
+		for _, transaction := range pending {
+			transactions = append(transactions, PendingTransaction{
+				From:        types.Address(from),
+				Transaction: transaction,
+			})
+		}
+	}
+	return transactions, nil
+}
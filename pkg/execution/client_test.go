@@ -0,0 +1,56 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestGetBalanceDelta(t *testing.T) {
+	balances := map[string]string{"0x9": "0x64", "0xa": "0x96"}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params []string        `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil || request.Method != "eth_getBalance" || len(request.Params) != 2 {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		requests += 1
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  balances[request.Params[1]],
+		})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, &Config{Endpoint: server.URL}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		delta, err := client.GetBalanceDelta(ctx, types.Address{1}, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if delta.Int64() != 50 {
+			t.Errorf("expected a delta of 50 but got %s", delta)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected the balances to be requested once but got %d requests", requests)
+	}
+}
@@ -0,0 +1,127 @@
+// Package logging builds the logger of each command from its config
+package logging
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	ConsoleFormat = "console"
+	JSONFormat    = "json"
+
+	DefaultFormat = ConsoleFormat
+	DefaultLevel  = "debug"
+)
+
+// `SamplingConfig` bounds the number of identical log entries written each second
+type SamplingConfig struct {
+	// Number of entries with the same level and message written each second before sampling
+	Initial int `yaml:"initial"`
+	// Every `Thereafter`th of the further entries is written
+	Thereafter int `yaml:"thereafter"`
+}
+
+// `FileConfig` writes logs to a file rotated by size
+type FileConfig struct {
+	Path string `yaml:"path"`
+	// Size in megabytes the file may grow to before it is rotated, defaults to 100
+	MaxSizeMB int `yaml:"max_size_mb"`
+	// Number of rotated files to keep, all of them by default
+	MaxBackups int `yaml:"max_backups"`
+	// Number of days to keep rotated files for, forever by default
+	MaxAgeDays int  `yaml:"max_age_days"`
+	Compress   bool `yaml:"compress"`
+}
+
+type Config struct {
+	// One of `console` (the default) or `json`
+	Format string `yaml:"format"`
+	// One of `debug` (the default), `info`, `warn` or `error`
+	Level string `yaml:"level"`
+	// Whether levels are colored in the `console` format, defaults to `true` unless logging to a file
+	Color    *bool           `yaml:"color"`
+	Sampling *SamplingConfig `yaml:"sampling"`
+	// If given, logs are written to this file instead of standard error
+	File *FileConfig `yaml:"file"`
+}
+
+func (c *Config) format() string {
+	if c == nil || c.Format == "" {
+		return DefaultFormat
+	}
+	return c.Format
+}
+
+func (c *Config) level() string {
+	if c == nil || c.Level == "" {
+		return DefaultLevel
+	}
+	return c.Level
+}
+
+func (c *Config) color() bool {
+	if c == nil {
+		return true
+	}
+	if c.Color != nil {
+		return *c.Color
+	}
+	return c.File == nil
+}
+
+func (c *Config) encoder() (zapcore.Encoder, error) {
+	switch c.format() {
+	case ConsoleFormat:
+		encoderConfig := zap.NewDevelopmentEncoderConfig()
+		if c.color() {
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	case JSONFormat:
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %s, expected one of %s or %s", c.Format, ConsoleFormat, JSONFormat)
+	}
+}
+
+func (c *Config) writer() zapcore.WriteSyncer {
+	if c == nil || c.File == nil {
+		return zapcore.Lock(os.Stderr)
+	}
+	return zapcore.AddSync(&lumberjack.Logger{
+		Filename:   c.File.Path,
+		MaxSize:    c.File.MaxSizeMB,
+		MaxBackups: c.File.MaxBackups,
+		MaxAge:     c.File.MaxAgeDays,
+		Compress:   c.File.Compress,
+	})
+}
+
+// `New` returns a logger as configured, where a `nil` config logs everything to standard error with colored levels
+func New(config *Config) (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(config.level())
+	if err != nil {
+		return nil, err
+	}
+	encoder, err := config.encoder()
+	if err != nil {
+		return nil, err
+	}
+	if config != nil && config.File != nil && config.File.Path == "" {
+		return nil, fmt.Errorf("a path is required to log to a file")
+	}
+
+	core := zapcore.NewCore(encoder, config.writer(), zap.NewAtomicLevelAt(level))
+	if config != nil && config.Sampling != nil {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, config.Sampling.Initial, config.Sampling.Thereafter)
+	}
+	return zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)), nil
+}
@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		config *Config
+		level  zapcore.Level
+		err    bool
+	}{
+		{config: nil, level: zapcore.DebugLevel},
+		{config: &Config{Format: JSONFormat, Level: "info"}, level: zapcore.InfoLevel},
+		{config: &Config{Level: "warn", Sampling: &SamplingConfig{Initial: 10, Thereafter: 100}}, level: zapcore.WarnLevel},
+		{config: &Config{Format: "xml"}, err: true},
+		{config: &Config{Level: "verbose"}, err: true},
+		{config: &Config{File: &FileConfig{}}, err: true},
+	} {
+		logger, err := New(tc.config)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%+v: expected an error", tc.config)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: %v", tc.config, err)
+			continue
+		}
+		if !logger.Core().Enabled(tc.level) || (tc.level > zapcore.DebugLevel && logger.Core().Enabled(tc.level-1)) {
+			t.Errorf("%+v: expected logging from level %s", tc.config, tc.level)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Package metrics defines the Prometheus metrics of the relay monitor
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "relay_monitor"
+
+// Category of the `BidFaults` counter for bids received after the late bid deadline
+const LateBidCategory = "late"
+
+// Encodings of the `BidEncodings` and `BidDecodeErrors` counters
+const (
+	JSONEncoding = "json"
+	SSZEncoding  = "ssz"
+)
+
+// Faults of the `RelayFaults` counter, named as in the fault stats
+const (
+	NoBidsFault              = "no_bids"
+	RegistrationIgnoredFault = "registration_ignored"
+	MissedSlotFault          = "missed_slots"
+	UnavailablePayloadFault  = "unavailable_payloads"
+	BidValueDivergenceFault  = "bid_value_divergences"
+	CancellationFault        = "cancellations"
+	CensoredTransactionFault = "censored_transactions"
+)
+
+// Reasons of the `InvalidTranscripts` counter
+const (
+	InvalidSignatureReason = "invalid_signature"
+	NotProposerReason      = "not_proposer"
+	HeaderMismatchReason   = "header_mismatch"
+	MalformedReason        = "malformed"
+	StaleSlotReason        = "stale_slot"
+	DuplicateReason        = "duplicate"
+	RateLimitedReason      = "rate_limited"
+)
+
+var (
+	BidsCollected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bids_collected_total",
+		Help:      "Number of bids collected from each relay",
+	}, []string{"relay"})
+
+	BidEncodings = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bid_encodings_total",
+		Help:      "Number of bids decoded from each relay by the encoding the relay served",
+	}, []string{"relay", "encoding"})
+
+	BidDecodeErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bid_decode_errors_total",
+		Help:      "Number of bids from each relay which could not be decoded by the encoding the relay served",
+	}, []string{"relay", "encoding"})
+
+	BidFaults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bid_faults_total",
+		Help:      "Number of faulty bids from each relay by fault category",
+	}, []string{"relay", "category"})
+
+	BidFaultReasons = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bid_fault_reasons_total",
+		Help:      "Number of invalid bids from each relay by fault category and reason",
+	}, []string{"relay", "category", "reason"})
+
+	RelayFaults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "relay_faults_total",
+		Help:      "Number of faults of each relay found outside of bid analysis by fault",
+	}, []string{"relay", "fault"})
+
+	RelayUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "relay_up",
+		Help:      "Whether the last probe of the status of each relay found it up",
+	}, []string{"relay"})
+
+	RegistrationCoverage = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "registration_coverage",
+		Help:      "Share of the validators registered with the monitor each relay reported a registration for in the last check",
+	}, []string{"relay"})
+
+	AnalysisLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "bid_analysis_duration_seconds",
+		Help:      "Time taken to analyze a bid",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12),
+	})
+
+	EventChannelDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "event_channel_depth",
+		Help:      "Number of events waiting to be processed by the analyzer",
+	})
+
+	AnalyzerWorkerEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "analyzer_worker_events_total",
+		Help:      "Number of events processed by each worker of the analyzer",
+	}, []string{"worker"})
+
+	AnalyzerWorkerDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "analyzer_worker_depth",
+		Help:      "Number of events waiting to be processed by each worker of the analyzer",
+	}, []string{"worker"})
+
+	EventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_dropped_total",
+		Help:      "Number of events dropped as the queue to the analyzer was full, by type of event",
+	}, []string{"event"})
+
+	EventsSpilled = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "events_spilled",
+		Help:      "Number of events spilled to disk waiting to be pushed to the queue to the analyzer again",
+	})
+
+	InvalidTranscripts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invalid_transcripts_total",
+		Help:      "Number of auction transcripts rejected without being stored by reason",
+	}, []string{"reason"})
+
+	StoreErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "store_errors_total",
+		Help:      "Number of failed writes to the store by operation",
+	}, []string{"operation"})
+
+	APIRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_requests_total",
+		Help:      "Number of requests served by the API by route, method and status code",
+	}, []string{"route", "method", "code"})
+
+	APIRequestLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "api_request_duration_seconds",
+		Help:      "Time taken to serve requests to the API by route",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"route"})
+
+	RowsPruned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "store_rows_pruned_total",
+		Help:      "Number of entries deleted from the store by the pruner by table",
+	}, []string{"table"})
+
+	ValidatorFetches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "validator_fetches_total",
+		Help:      "Number of validators fetched from the consensus client as their cached entry was missing or stale by result",
+	}, []string{"result"})
+
+	RelayConnections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "relay_connections_total",
+		Help:      "Number of requests to each relay by whether they reused a pooled connection",
+	}, []string{"relay", "reused"})
+
+	RelayTLSHandshakes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "relay_tls_handshakes_total",
+		Help:      "Number of TLS handshakes with each relay by whether they resumed a cached session",
+	}, []string{"relay", "resumed"})
+
+	RelayOpenConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "relay_open_connections",
+		Help:      "Number of connections open to each host of a relay",
+	}, []string{"host"})
+
+	RelayDialErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "relay_dial_errors_total",
+		Help:      "Number of connections to each host of a relay which could not be opened",
+	}, []string{"host"})
+
+	ProposerCacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "proposer_cache_misses_total",
+		Help:      "Number of lookups of the proposer of a slot missing from the proposer cache by the result of fetching the duties of its epoch",
+	}, []string{"result"})
+
+	ValidatorSetSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "validator_set_size",
+		Help:      "Number of validators of the last validator set loaded from the consensus client",
+	})
+)
+
+// `Handler` serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
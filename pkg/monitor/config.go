@@ -1,7 +1,21 @@
 package monitor
 
 import (
+	"github.com/ralexstokes/relay-monitor/pkg/alerts"
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
 	"github.com/ralexstokes/relay-monitor/pkg/api"
+	"github.com/ralexstokes/relay-monitor/pkg/builder"
+	"github.com/ralexstokes/relay-monitor/pkg/cache"
+	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/digest"
+	"github.com/ralexstokes/relay-monitor/pkg/execution"
+	"github.com/ralexstokes/relay-monitor/pkg/logging"
+	"github.com/ralexstokes/relay-monitor/pkg/registry"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/reports"
+	"github.com/ralexstokes/relay-monitor/pkg/rpc"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/tracing"
 )
 
 type NetworkConfig struct {
@@ -10,11 +24,38 @@ type NetworkConfig struct {
 
 type ConsensusConfig struct {
 	Endpoint string `yaml:"endpoint"`
+	// Additional consensus clients which must agree on expected values before a consensus fault is recorded
+	QuorumEndpoints []string `yaml:"quorum_endpoints"`
 }
 
 type Config struct {
 	Network   *NetworkConfig   `yaml:"network"`
 	Consensus *ConsensusConfig `yaml:"consensus"`
-	Relays    []string         `yaml:"relays"`
-	Api       *api.Config      `yaml:"api"`
+	// Optional execution client to verify payments, block values and censorship against execution state
+	Execution *execution.Config `yaml:"execution"`
+	Relays    []*builder.Config `yaml:"relays"`
+	// Optional external registry of relays to monitor in addition to `Relays`
+	Registry *registry.Config `yaml:"registry"`
+	Api      *api.Config      `yaml:"api"`
+	// Optional gRPC server alongside the REST API
+	Grpc      *rpc.Config  `yaml:"grpc"`
+	Collector *data.Config `yaml:"collector"`
+	// If given, the analyzer consumes the events of remote collectors from this Kafka topic rather than collecting from the relays itself
+	Kafka *data.KafkaConfig `yaml:"kafka"`
+	// Queue of events from the collector and the API to the analyzer
+	Queue    *data.QueueConfig       `yaml:"queue"`
+	Analysis *analysis.Config        `yaml:"analysis"`
+	Scoring  *reporter.ScoringConfig `yaml:"scoring"`
+	Cache    *cache.Config           `yaml:"cache"`
+	Store    *store.Config           `yaml:"store"`
+	// Format, level and destination of the logs of the monitor, where changes require a restart
+	Logging *logging.Config `yaml:"logging"`
+	// Optional export of traces of the processing of each bid to an OTLP endpoint
+	Tracing *tracing.Config `yaml:"tracing"`
+	// Optional periodic digest of the monitor's findings for operators
+	Digest *digest.Config `yaml:"digest"`
+	// Optional daily and weekly reports of the faults and scores of each relay, published as a canonical summary
+	Reports *reports.Config `yaml:"reports"`
+	// Optional email alerts of the faults recorded by the monitor
+	Alerts *alerts.Config `yaml:"alerts"`
 }
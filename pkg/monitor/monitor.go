@@ -3,31 +3,70 @@ package monitor
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sync"
 	"time"
 
+	"github.com/ralexstokes/relay-monitor/pkg/alerts"
 	"github.com/ralexstokes/relay-monitor/pkg/analysis"
 	"github.com/ralexstokes/relay-monitor/pkg/api"
 	"github.com/ralexstokes/relay-monitor/pkg/builder"
+	"github.com/ralexstokes/relay-monitor/pkg/cache"
 	"github.com/ralexstokes/relay-monitor/pkg/consensus"
 	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/digest"
+	"github.com/ralexstokes/relay-monitor/pkg/execution"
+	"github.com/ralexstokes/relay-monitor/pkg/registry"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/reports"
+	"github.com/ralexstokes/relay-monitor/pkg/rpc"
 	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/tracing"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
 	"go.uber.org/zap"
 )
 
-const eventBufferSize uint = 32
+const (
+	registrationBufferSize uint = 32
+)
 
 type Monitor struct {
 	logger *zap.Logger
 
-	api       *api.Server
+	api *api.Server
+	rpc *rpc.Server
+	// `nil` if events are consumed from Kafka
 	collector *data.Collector
+	consumer  *data.KafkaConsumer
+	events    *data.Queue
 	analyzer  *analysis.Analyzer
+	reporter  *reporter.Reporter
+	digest    *digest.Generator
+	reports   *reports.Generator
+	alerts    *alerts.Notifier
+	store     store.Storer
+	clock     *consensus.Clock
+
+	// configured relays, scoring strategies and the external registry of relays, if any
+	relayConfigs []*builder.Config
+	scoring      *reporter.ScoringConfig
+	registry     *registry.Registry
+	// relays being collected from and relays which are only reported as they were delisted from the registry
+	relays         []*builder.Client
+	inactiveRelays []*builder.Client
+	relaysLock     sync.Mutex
+	// retention of data in the store, if pruning is enabled
+	retention *store.RetentionConfig
+
+	// flushes and stops the export of traces, if enabled
+	stopTracing func(context.Context) error
 }
 
-func parseRelaysFromEndpoint(logger *zap.SugaredLogger, relayEndpoints []string) []*builder.Client {
+func parseRelays(logger *zap.SugaredLogger, relayConfigs []*builder.Config) []*builder.Client {
 	var relays []*builder.Client
-	for _, endpoint := range relayEndpoints {
-		relay, err := builder.NewClient(endpoint)
+	for _, relayConfig := range relayConfigs {
+		endpoint := relayConfig.Endpoint
+		relay, err := builder.NewClientFromConfig(relayConfig)
 		if err != nil {
 			logger.Warnf("could not instantiate relay at %s: %v", endpoint, err)
 			continue
@@ -47,16 +86,200 @@ func parseRelaysFromEndpoint(logger *zap.SugaredLogger, relayEndpoints []string)
 	return relays
 }
 
+// `resolveRelays` returns the clients of the configured relays and of the relays of the registry, if any,
+// which are active and of the relays of the registry which are inactive. Configured relays take precedence over
+// relays of the registry with the same public key, and clients in `existing` whose configuration is unchanged are reused.
+func resolveRelays(logger *zap.SugaredLogger, relayConfigs []*builder.Config, relayRegistry *registry.Registry, existing []*builder.Client) ([]*builder.Client, []*builder.Client) {
+	activeConfigs := relayConfigs
+	var inactiveConfigs []*builder.Config
+	if relayRegistry != nil {
+		configured := make(map[types.PublicKey]struct{})
+		for _, relayConfig := range relayConfigs {
+			publicKey, err := registry.PublicKeyFromEndpoint(relayConfig.Endpoint)
+			if err == nil {
+				configured[publicKey] = struct{}{}
+			}
+		}
+		listed, delisted := relayRegistry.Relays()
+		for _, relayConfig := range listed {
+			publicKey, _ := registry.PublicKeyFromEndpoint(relayConfig.Endpoint)
+			if _, ok := configured[publicKey]; !ok {
+				activeConfigs = append(activeConfigs, relayConfig)
+			}
+		}
+		for _, relayConfig := range delisted {
+			publicKey, _ := registry.PublicKeyFromEndpoint(relayConfig.Endpoint)
+			if _, ok := configured[publicKey]; !ok {
+				inactiveConfigs = append(inactiveConfigs, relayConfig)
+			}
+		}
+	}
+
+	findExisting := func(relayConfig *builder.Config) *builder.Client {
+		for _, relay := range existing {
+			if reflect.DeepEqual(relay.Config(), relayConfig) {
+				return relay
+			}
+		}
+		return nil
+	}
+
+	var active []*builder.Client
+	var newConfigs []*builder.Config
+	for _, relayConfig := range activeConfigs {
+		if relay := findExisting(relayConfig); relay != nil {
+			active = append(active, relay)
+		} else {
+			newConfigs = append(newConfigs, relayConfig)
+		}
+	}
+	if len(newConfigs) > 0 {
+		active = append(active, parseRelays(logger, newConfigs)...)
+	}
+
+	var inactive []*builder.Client
+	for _, relayConfig := range inactiveConfigs {
+		relay := findExisting(relayConfig)
+		if relay == nil {
+			var err error
+			// NOTE: the status of inactive relays is not checked as they are not collected from
+			relay, err = builder.NewClientFromConfig(relayConfig)
+			if err != nil {
+				logger.Warnf("could not instantiate relay at %s: %v", relayConfig.Endpoint, err)
+				continue
+			}
+		}
+		inactive = append(inactive, relay)
+	}
+	return active, inactive
+}
+
+func relayPublicKeys(relays []*builder.Client) []types.PublicKey {
+	publicKeys := make([]types.PublicKey, len(relays))
+	for i, relay := range relays {
+		publicKeys[i] = relay.PublicKey
+	}
+	return publicKeys
+}
+
+// `replayBidAnalyses` loads the bid analyses in the file at `path` into the store
+// NOTE: allows replaying from within `New` where the store shadows its package
+func replayBidAnalyses(ctx context.Context, s store.Storer, path string) (int, error) {
+	return store.ReplayBidAnalysesFromFile(ctx, s, path)
+}
+
+// `putRelays` stores the settings of each active and inactive relay, and the tags and metadata of each relay which has them in its configuration
+func putRelays(ctx context.Context, store store.Storer, active []*builder.Client, inactive []*builder.Client) error {
+	for i, relay := range append(append([]*builder.Client{}, active...), inactive...) {
+		settings := relay.Settings()
+		settings.Active = i < len(active)
+		err := store.PutRelaySettings(ctx, &relay.PublicKey, settings)
+		if err != nil {
+			return err
+		}
+		if metadata := relay.Metadata(); metadata != nil {
+			err = store.PutRelayMetadata(ctx, &relay.PublicKey, metadata)
+			if err != nil {
+				return err
+			}
+		}
+		tags := relay.Config().Tags
+		if len(tags) == 0 {
+			continue
+		}
+		err = store.PutRelayTags(ctx, &relay.PublicKey, tags)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func New(ctx context.Context, config *Config, zapLogger *zap.Logger) (*Monitor, error) {
 	logger := zapLogger.Sugar()
 
-	relays := parseRelaysFromEndpoint(logger, config.Relays)
+	var stopTracing func(context.Context) error
+	if config.Tracing != nil {
+		var err error
+		stopTracing, err = tracing.Start(ctx, config.Tracing)
+		if err != nil {
+			return nil, err
+		}
+		logger.Infof("exporting traces to %s", config.Tracing.Endpoint)
+	}
 
-	consensusClient, err := consensus.NewClient(ctx, config.Consensus.Endpoint, zapLogger)
+	err := config.Api.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid API config: %v", err)
+	}
+	err = config.Analysis.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid analysis config: %v", err)
+	}
+	err = config.Execution.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid execution config: %v", err)
+	}
+	err = validateRelays(config.Relays)
+	if err != nil {
+		return nil, fmt.Errorf("invalid relay config: %v", err)
+	}
+	err = config.Registry.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry config: %v", err)
+	}
+	var apiSpans *api.SpanConfig
+	if config.Api != nil {
+		apiSpans = config.Api.Spans
+	}
+	err = config.Scoring.Validate(apiSpans.MaxSlots())
+	if err != nil {
+		return nil, fmt.Errorf("invalid scoring config: %v", err)
+	}
+
+	var relayRegistry *registry.Registry
+	if config.Registry != nil {
+		relayRegistry = registry.New(config.Registry, zapLogger)
+		_, err = relayRegistry.Sync(ctx)
+		if err != nil {
+			logger.Warnf("could not sync relays from registry at %s: %v", config.Registry.URL, err)
+		}
+	}
+	relays, inactiveRelays := resolveRelays(logger, config.Relays, relayRegistry, nil)
+	allRelays := append(append([]*builder.Client{}, relays...), inactiveRelays...)
+
+	cacheBackend, err := cache.NewBackend(ctx, config.Cache)
+	if err != nil {
+		return nil, fmt.Errorf("could not instantiate cache backend: %v", err)
+	}
+
+	consensusClient, err := consensus.NewClient(ctx, config.Consensus.Endpoint, zapLogger, cacheBackend)
 	if err != nil {
 		return nil, fmt.Errorf("could not instantiate consensus client: %v", err)
 	}
 
+	var quorumClients []*consensus.Client
+	for _, endpoint := range config.Consensus.QuorumEndpoints {
+		// NOTE: keep caches of quorum clients in-process so they are independent of the primary client
+		quorumClient, err := consensus.NewClient(ctx, endpoint, zapLogger, nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not instantiate quorum consensus client at %s: %v", endpoint, err)
+		}
+		quorumClients = append(quorumClients, quorumClient)
+	}
+	if len(quorumClients) > 0 {
+		logger.Infof("requiring agreement of %d additional consensus clients before recording consensus faults", len(quorumClients))
+	}
+
+	var executionClient *execution.Client
+	if config.Execution != nil {
+		executionClient, err = execution.NewClient(ctx, config.Execution, cacheBackend.ForNetwork(consensusClient.GenesisForkVersion()))
+		if err != nil {
+			return nil, fmt.Errorf("could not instantiate execution client: %v", err)
+		}
+		logger.Infof("verifying bids against the execution state of the client at %s", config.Execution.Endpoint)
+	}
+
 	clock := consensus.NewClock(consensusClient.GenesisTime, consensusClient.SecondsPerSlot, consensusClient.SlotsPerEpoch)
 	now := time.Now().Unix()
 	currentSlot := clock.CurrentSlot(now)
@@ -67,29 +290,222 @@ func New(ctx context.Context, config *Config, zapLogger *zap.Logger) (*Monitor,
 		logger.Warn("could not load the current context from the consensus client")
 	}
 
-	events := make(chan data.Event, eventBufferSize)
-	collector := data.NewCollector(zapLogger, relays, clock, consensusClient, events)
-	store := store.NewMemoryStore()
-	analyzer := analysis.NewAnalyzer(zapLogger, relays, events, store, consensusClient, clock)
+	events, err := data.NewQueue(config.Queue, zapLogger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create event queue: %v", err)
+	}
+	var registrations chan []types.SignedValidatorRegistration
+	if config.Collector != nil && config.Collector.ForwardRegistrations {
+		registrations = make(chan []types.SignedValidatorRegistration, registrationBufferSize)
+	}
+	var retention *store.RetentionConfig
+	if config.Store != nil {
+		retention = config.Store.Retention
+	}
+
+	store, err := store.NewMemoryStoreFromConfig(config.Store)
+	if err != nil {
+		return nil, fmt.Errorf("could not instantiate store: %v", err)
+	}
+	if config.Store != nil && config.Store.ReplayFile != "" {
+		count, err := replayBidAnalyses(ctx, store, config.Store.ReplayFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not replay bid analyses: %v", err)
+		}
+		logger.Infof("replayed %d bid analyses from %s", count, config.Store.ReplayFile)
+	}
+	err = putRelays(ctx, store, relays, inactiveRelays)
+	if err != nil {
+		return nil, fmt.Errorf("could not store relays: %v", err)
+	}
+	var collector *data.Collector
+	var consumer *data.KafkaConsumer
+	if config.Kafka != nil {
+		consumer, err = data.NewKafkaConsumer(config.Kafka, zapLogger, events)
+		if err != nil {
+			return nil, fmt.Errorf("could not instantiate kafka consumer: %v", err)
+		}
+		// NOTE: registrations are only forwarded by a local collector
+		registrations = nil
+	} else {
+		collector = data.NewCollector(config.Collector, zapLogger, relays, clock, consensusClient, store, events, registrations)
+	}
+	analyzer := analysis.NewAnalyzer(config.Analysis, zapLogger, allRelays, events.Events(), store, consensusClient, quorumClients, clock, executionClient)
+
+	reporter, err := reporter.NewReporter(config.Scoring, relayPublicKeys(allRelays), store, config.Cache.ReportCacheTTL())
+	if err != nil {
+		return nil, fmt.Errorf("could not instantiate reporter: %v", err)
+	}
+
+	var rpcServer *rpc.Server
+	if config.Grpc != nil {
+		rpcServer = rpc.New(config.Grpc, zapLogger, apiSpans, analyzer, reporter, clock, store)
+	}
+
+	var digestGenerator *digest.Generator
+	if config.Digest != nil {
+		if config.Digest.Email != nil {
+			err = config.Digest.Email.Validate()
+			if err != nil {
+				return nil, fmt.Errorf("invalid email config for digest: %v", err)
+			}
+		}
+		digestGenerator = digest.New(config.Digest, zapLogger, config.Network.Name, reporter, clock)
+	}
+
+	var reportGenerator *reports.Generator
+	if config.Reports != nil {
+		err = config.Reports.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid reports config: %v", err)
+		}
+		reportGenerator = reports.New(config.Reports, zapLogger, config.Network.Name, reporter, clock)
+	}
 
-	apiServer := api.New(config.Api, zapLogger, analyzer, events, clock, store, consensusClient)
+	var notifier *alerts.Notifier
+	if config.Alerts != nil {
+		notifier, err = alerts.New(config.Alerts, zapLogger, config.Network.Name, analyzer)
+		if err != nil {
+			return nil, fmt.Errorf("could not instantiate alerts: %v", err)
+		}
+	}
+
+	apiServer := api.New(config.Api, zapLogger, analyzer, reporter, events, registrations, clock, store, consensusClient)
 	return &Monitor{
-		logger:    zapLogger,
-		api:       apiServer,
-		collector: collector,
-		analyzer:  analyzer,
+		logger:         zapLogger,
+		api:            apiServer,
+		rpc:            rpcServer,
+		collector:      collector,
+		consumer:       consumer,
+		events:         events,
+		analyzer:       analyzer,
+		reporter:       reporter,
+		digest:         digestGenerator,
+		reports:        reportGenerator,
+		alerts:         notifier,
+		store:          store,
+		clock:          clock,
+		relayConfigs:   config.Relays,
+		scoring:        config.Scoring,
+		registry:       relayRegistry,
+		relays:         relays,
+		inactiveRelays: inactiveRelays,
+		retention:      retention,
+		stopTracing:    stopTracing,
 	}, nil
 }
 
-func (s *Monitor) Run(ctx context.Context) {
+// `validateRelays` checks the configuration of each relay in `relayConfigs`
+func validateRelays(relayConfigs []*builder.Config) error {
+	for _, relayConfig := range relayConfigs {
+		err := analysis.ValidateDisabledChecks(relayConfig.DisabledChecks)
+		if err != nil {
+			return fmt.Errorf("invalid disabled checks of relay %s: %v", relayConfig.Endpoint, err)
+		}
+	}
+	return nil
+}
+
+// `Reload` applies the relays and scoring strategies of `config` without restarting the monitor.
+// Relays whose configuration is unchanged keep running, other changes to the configuration require a restart.
+func (s *Monitor) Reload(ctx context.Context, config *Config) error {
+	err := validateRelays(config.Relays)
+	if err != nil {
+		return err
+	}
+
+	s.relaysLock.Lock()
+	defer s.relaysLock.Unlock()
+
+	err = s.applyRelays(ctx, config.Relays, config.Scoring)
+	if err != nil {
+		return err
+	}
+	s.relayConfigs = config.Relays
+	s.scoring = config.Scoring
+	return nil
+}
+
+// `applyRelays` monitors the configured relays and the relays of the registry, if any, with the given scoring strategies
+// NOTE: must be called with `relaysLock` held
+func (s *Monitor) applyRelays(ctx context.Context, relayConfigs []*builder.Config, scoring *reporter.ScoringConfig) error {
 	logger := s.logger.Sugar()
 
-	go func() {
-		err := s.collector.Run(ctx)
+	existing := append(append([]*builder.Client{}, s.relays...), s.inactiveRelays...)
+	relays, inactiveRelays := resolveRelays(logger, relayConfigs, s.registry, existing)
+	allRelays := append(append([]*builder.Client{}, relays...), inactiveRelays...)
+
+	err := s.reporter.Reload(scoring, relayPublicKeys(allRelays))
+	if err != nil {
+		return fmt.Errorf("could not reload scoring: %v", err)
+	}
+	err = putRelays(ctx, s.store, relays, inactiveRelays)
+	if err != nil {
+		return fmt.Errorf("could not store relays: %v", err)
+	}
+	s.analyzer.SetRelays(allRelays)
+	if s.collector != nil {
+		s.collector.SetRelays(ctx, relays)
+	}
+	s.relays = relays
+	s.inactiveRelays = inactiveRelays
+
+	logger.Infof("monitoring %d relays with %d inactive relays", len(relays), len(inactiveRelays))
+	return nil
+}
+
+// `syncRegistry` periodically syncs the relays of the registry, applying any change to the monitored relays
+func (s *Monitor) syncRegistry(ctx context.Context) {
+	logger := s.logger.Sugar()
+
+	ticker := time.NewTicker(s.registry.Interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := s.registry.Sync(ctx)
 		if err != nil {
-			logger.Warn("error running collector: %v", err)
+			logger.Warnf("could not sync relays from registry: %v", err)
+			continue
 		}
-	}()
+		if !changed {
+			continue
+		}
+		s.relaysLock.Lock()
+		err = s.applyRelays(ctx, s.relayConfigs, s.scoring)
+		s.relaysLock.Unlock()
+		if err != nil {
+			logger.Warnf("could not apply relays from registry: %v", err)
+		}
+	}
+}
+
+func (s *Monitor) Run(ctx context.Context) {
+	logger := s.logger.Sugar()
+
+	go s.events.Run(ctx)
+	if s.registry != nil {
+		go s.syncRegistry(ctx)
+	}
+	if s.consumer != nil {
+		go func() {
+			err := s.consumer.Run(ctx)
+			if err != nil {
+				logger.Warnf("error consuming events from kafka: %v", err)
+			}
+		}()
+	} else {
+		go func() {
+			err := s.collector.Run(ctx)
+			if err != nil {
+				logger.Warn("error running collector: %v", err)
+			}
+		}()
+	}
 	go func() {
 		err := s.analyzer.Run(ctx)
 		if err != nil {
@@ -97,6 +513,45 @@ func (s *Monitor) Run(ctx context.Context) {
 		}
 	}()
 
+	if s.retention != nil {
+		if pruner, ok := s.store.(store.Pruner); ok {
+			go store.RunPruner(ctx, pruner, s.retention, s.logger, s.clock.CurrentSlot)
+		} else {
+			logger.Warn("store does not support pruning, ignoring retention config")
+		}
+	}
+
+	if s.digest != nil {
+		go s.digest.Run(ctx)
+	}
+
+	if s.reports != nil {
+		go s.reports.Run(ctx)
+	}
+
+	if s.alerts != nil {
+		go s.alerts.Run(ctx)
+	}
+
+	if s.stopTracing != nil {
+		go func() {
+			<-ctx.Done()
+			err := s.stopTracing(context.Background())
+			if err != nil {
+				logger.Warnf("error stopping export of traces: %v", err)
+			}
+		}()
+	}
+
+	if s.rpc != nil {
+		go func() {
+			err := s.rpc.Run(ctx)
+			if err != nil {
+				logger.Warnf("error running gRPC server: %v", err)
+			}
+		}()
+	}
+
 	err := s.api.Run(ctx)
 	if err != nil {
 		logger.Warn("error running API server: %v", err)
@@ -0,0 +1,277 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/builder"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultSyncInterval = 1 * time.Hour
+	// NOTE: a registry delisting most relays at once is more likely broken than the relays
+	DefaultMaxDelistedFraction = 0.5
+
+	fetchTimeout = 10 * time.Second
+)
+
+// `Config` is an external registry of relays to monitor in addition to the configured relays
+type Config struct {
+	// URL of the registry serving a JSON list of relays
+	URL string `yaml:"url"`
+	// Time between syncs of the monitored relays with the registry
+	Interval time.Duration `yaml:"interval"`
+	// Relays which stay active when they are delisted from the registry
+	Pin []types.PublicKey `yaml:"pin"`
+	// Relays which are never monitored from the registry
+	Exclude []types.PublicKey `yaml:"exclude"`
+	// Largest fraction of the listed relays a sync may delist, beyond which the listing is ignored;
+	// `DefaultMaxDelistedFraction` if unset
+	MaxDelistedFraction *float64 `yaml:"max_delisted_fraction"`
+}
+
+func (c *Config) interval() time.Duration {
+	if c == nil || c.Interval == 0 {
+		return DefaultSyncInterval
+	}
+	return c.Interval
+}
+
+func (c *Config) maxDelistedFraction() float64 {
+	if c == nil || c.MaxDelistedFraction == nil {
+		return DefaultMaxDelistedFraction
+	}
+	return *c.MaxDelistedFraction
+}
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if fraction := c.maxDelistedFraction(); math.IsNaN(fraction) || fraction < 0 || fraction > 1 {
+		return fmt.Errorf("invalid max delisted fraction %g: must be between 0 and 1", fraction)
+	}
+	return nil
+}
+
+// `Entry` is a relay listed by the registry, given either as an endpoint or as an object with the endpoint and metadata of the relay
+type Entry struct {
+	// URL of the relay where the user info holds the relay's public key
+	Endpoint    string `json:"endpoint"`
+	Operator    string `json:"operator"`
+	Network     string `json:"network"`
+	Description string `json:"description"`
+	Region      string `json:"region"`
+}
+
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &e.Endpoint)
+	}
+	type entry Entry
+	return json.Unmarshal(data, (*entry)(e))
+}
+
+// `relayConfig` returns the configuration to monitor the relay of the entry with
+func (e *Entry) relayConfig() *builder.Config {
+	config := &builder.Config{
+		Endpoint: e.Endpoint,
+	}
+	if e.Operator != "" || e.Network != "" || e.Description != "" || e.Region != "" {
+		config.Metadata = &builder.MetadataConfig{
+			Operator:    e.Operator,
+			Network:     e.Network,
+			Description: e.Description,
+			Region:      e.Region,
+		}
+	}
+	return config
+}
+
+// `PublicKeyFromEndpoint` parses the public key of a relay from the user info of its endpoint
+func PublicKeyFromEndpoint(endpoint string) (types.PublicKey, error) {
+	var publicKey types.PublicKey
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return publicKey, err
+	}
+	err = publicKey.UnmarshalText([]byte(u.User.Username()))
+	return publicKey, err
+}
+
+// `Registry` tracks the relays listed by an external registry, remembering delisted relays as inactive
+type Registry struct {
+	config *Config
+	logger *zap.Logger
+	client http.Client
+
+	pinned   map[types.PublicKey]struct{}
+	excluded map[types.PublicKey]struct{}
+
+	lock sync.Mutex
+	// every relay listed by the registry since the monitor started, with its latest listing
+	relays map[types.PublicKey]*builder.Config
+	// relays listed by the registry in the latest sync
+	listed map[types.PublicKey]struct{}
+}
+
+func New(config *Config, zapLogger *zap.Logger) *Registry {
+	pinned := make(map[types.PublicKey]struct{})
+	for _, relay := range config.Pin {
+		pinned[relay] = struct{}{}
+	}
+	excluded := make(map[types.PublicKey]struct{})
+	for _, relay := range config.Exclude {
+		excluded[relay] = struct{}{}
+	}
+	return &Registry{
+		config: config,
+		logger: zapLogger,
+		client: http.Client{
+			Timeout: fetchTimeout,
+		},
+		pinned:   pinned,
+		excluded: excluded,
+		relays:   make(map[types.PublicKey]*builder.Config),
+		listed:   make(map[types.PublicKey]struct{}),
+	}
+}
+
+func (r *Registry) Interval() time.Duration {
+	return r.config.interval()
+}
+
+func (r *Registry) fetch(ctx context.Context) ([]Entry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.config.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry responded with HTTP status code %d", resp.StatusCode)
+	}
+	var entries []Entry
+	err = json.NewDecoder(resp.Body).Decode(&entries)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode registry: %v", err)
+	}
+	return entries, nil
+}
+
+// `Sync` fetches the registry and applies its listing, returning whether the relays of the registry changed.
+// A listing which is empty or delists more than the maximum fraction of the listed relays is ignored with a warning,
+// so a broken registry does not stop the monitoring of its relays.
+func (r *Registry) Sync(ctx context.Context) (bool, error) {
+	logger := r.logger.Sugar()
+
+	entries, err := r.fetch(ctx)
+	if err != nil {
+		return false, err
+	}
+	relays := make(map[types.PublicKey]*builder.Config)
+	for i := range entries {
+		entry := &entries[i]
+		publicKey, err := PublicKeyFromEndpoint(entry.Endpoint)
+		if err != nil {
+			logger.Warnw("ignoring relay with invalid endpoint in registry", "error", err, "endpoint", entry.Endpoint)
+			continue
+		}
+		if _, ok := r.excluded[publicKey]; ok {
+			continue
+		}
+		relays[publicKey] = entry.relayConfig()
+	}
+	return r.apply(relays), nil
+}
+
+// `apply` records `relays` as the latest listing of the registry, returning whether the relays of the registry changed
+func (r *Registry) apply(relays map[types.PublicKey]*builder.Config) bool {
+	logger := r.logger.Sugar()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(relays) == 0 {
+		logger.Warnw("ignoring empty listing of registry", "url", r.config.URL, "listed", len(r.listed))
+		return false
+	}
+	delisted := 0
+	for publicKey := range r.listed {
+		if _, ok := relays[publicKey]; !ok {
+			delisted += 1
+		}
+	}
+	if len(r.listed) > 0 && float64(delisted)/float64(len(r.listed)) > r.config.maxDelistedFraction() {
+		logger.Warnw("ignoring listing of registry delisting too many relays", "url", r.config.URL, "listed", len(r.listed), "delisted", delisted, "maxDelistedFraction", r.config.maxDelistedFraction())
+		return false
+	}
+
+	changed := len(relays) != len(r.listed)
+	for publicKey, config := range relays {
+		existing, ok := r.relays[publicKey]
+		if _, listed := r.listed[publicKey]; !listed {
+			changed = true
+			if ok {
+				logger.Infof("relay %s was listed again by the registry", publicKey)
+			} else {
+				logger.Infof("relay %s was listed by the registry", publicKey)
+			}
+		}
+		if ok && !reflect.DeepEqual(existing, config) {
+			changed = true
+		}
+		r.relays[publicKey] = config
+	}
+	for publicKey := range r.listed {
+		if _, ok := relays[publicKey]; !ok {
+			logger.Infof("relay %s was delisted by the registry", publicKey)
+		}
+	}
+	r.listed = make(map[types.PublicKey]struct{})
+	for publicKey := range relays {
+		r.listed[publicKey] = struct{}{}
+	}
+	return changed
+}
+
+// `Relays` returns the configurations of the relays of the registry which are active, as they are listed or pinned,
+// and of those which are inactive, as they were delisted, ordered by public key
+func (r *Registry) Relays() ([]*builder.Config, []*builder.Config) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	publicKeys := make([]types.PublicKey, 0, len(r.relays))
+	for publicKey := range r.relays {
+		publicKeys = append(publicKeys, publicKey)
+	}
+	sort.Slice(publicKeys, func(i, j int) bool {
+		return publicKeys[i].String() < publicKeys[j].String()
+	})
+
+	var active, inactive []*builder.Config
+	for _, publicKey := range publicKeys {
+		_, listed := r.listed[publicKey]
+		_, pinned := r.pinned[publicKey]
+		if listed || pinned {
+			active = append(active, r.relays[publicKey])
+		} else {
+			inactive = append(inactive, r.relays[publicKey])
+		}
+	}
+	return active, inactive
+}
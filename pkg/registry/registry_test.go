@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+func endpointFor(publicKey types.PublicKey) string {
+	return fmt.Sprintf("https://%s@relay-%x.example.com", publicKey, publicKey[0])
+}
+
+func TestSync(t *testing.T) {
+	relayA := types.PublicKey{0x01}
+	relayB := types.PublicKey{0x02}
+	relayC := types.PublicKey{0x03}
+	excluded := types.PublicKey{0x04}
+
+	listing := []interface{}{
+		endpointFor(relayA),
+		map[string]string{"endpoint": endpointFor(relayB), "operator": "Example"},
+		endpointFor(excluded),
+		"not a relay",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(listing)
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{
+		URL:     server.URL,
+		Pin:     []types.PublicKey{relayB},
+		Exclude: []types.PublicKey{excluded},
+	}
+	registry := New(config, zap.NewNop())
+	ctx := context.Background()
+
+	changed, err := registry.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the first sync to change the relays")
+	}
+	active, inactive := registry.Relays()
+	if len(active) != 2 || len(inactive) != 0 {
+		t.Fatalf("expected 2 active relays but got %d active and %d inactive", len(active), len(inactive))
+	}
+	if active[1].Metadata == nil || active[1].Metadata.Operator != "Example" {
+		t.Fatalf("expected metadata of listed relay but got %+v", active[1].Metadata)
+	}
+
+	changed, err = registry.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Fatal("expected an unchanged listing to not change the relays")
+	}
+
+	// an empty listing and a listing delisting more than half of the relays are ignored
+	for _, ignored := range [][]interface{}{{}, {endpointFor(relayC)}} {
+		listing = ignored
+		changed, err = registry.Sync(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changed {
+			t.Fatalf("expected listing %v to be ignored", ignored)
+		}
+		active, inactive = registry.Relays()
+		if len(active) != 2 || len(inactive) != 0 {
+			t.Fatalf("expected the relays of the previous listing to stay active but got %d active and %d inactive", len(active), len(inactive))
+		}
+	}
+
+	// delist every relay and list a new one
+	maxDelistedFraction := 1.0
+	config.MaxDelistedFraction = &maxDelistedFraction
+	changed, err = registry.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected a new listing to change the relays")
+	}
+	active, inactive = registry.Relays()
+	if len(active) != 2 || active[0].Endpoint != endpointFor(relayB) || active[1].Endpoint != endpointFor(relayC) {
+		t.Fatalf("expected the pinned and the new relay to be active but got %+v", active)
+	}
+	if len(inactive) != 1 || inactive[0].Endpoint != endpointFor(relayA) {
+		t.Fatalf("expected the delisted relay to be inactive but got %+v", inactive)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	for _, tc := range []struct {
+		fraction *float64
+		valid    bool
+	}{
+		{fraction: nil, valid: true},
+		{fraction: floatPtr(0), valid: true},
+		{fraction: floatPtr(1), valid: true},
+		{fraction: floatPtr(-0.1), valid: false},
+		{fraction: floatPtr(1.5), valid: false},
+	} {
+		err := (&Config{MaxDelistedFraction: tc.fraction}).Validate()
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("expected valid %t for fraction %v but got error %v", tc.valid, tc.fraction, err)
+		}
+	}
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
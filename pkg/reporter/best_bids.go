@@ -0,0 +1,93 @@
+package reporter
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `BestBid` is the most valuable bid observed by the monitor in a slot, with values in wei
+type BestBid struct {
+	Slot           types.Slot      `json:"slot,string"`
+	RelayPublicKey types.PublicKey `json:"relay_public_key"`
+	Value          string          `json:"value"`
+	// Value of the best bid from any other relay, or "0" if no other relay bid in the slot
+	SecondBestValue string `json:"second_best_value"`
+	Margin          string `json:"margin"`
+	// Number of relays which bid in the slot
+	Relays uint `json:"relays"`
+}
+
+// `computeBestBids` returns the best bid of each slot with a bid, ordered by slot
+func computeBestBids(values []types.BidValue) []BestBid {
+	// slot -> relay -> best value of the relay in the slot
+	relayValues := make(map[types.Slot]map[types.PublicKey]*big.Int)
+	for _, bidValue := range values {
+		slot := bidValue.Context.Slot
+		relay := bidValue.Context.RelayPublicKey
+		slotValues, ok := relayValues[slot]
+		if !ok {
+			slotValues = make(map[types.PublicKey]*big.Int)
+			relayValues[slot] = slotValues
+		}
+		value := bidValue.Value.BigInt()
+		if existing, ok := slotValues[relay]; !ok || value.Cmp(existing) > 0 {
+			slotValues[relay] = value
+		}
+	}
+
+	bestBids := make([]BestBid, 0, len(relayValues))
+	for slot, slotValues := range relayValues {
+		var bestRelay types.PublicKey
+		var best *big.Int
+		secondBest := new(big.Int)
+		for relay, value := range slotValues {
+			if best == nil || value.Cmp(best) > 0 {
+				if best != nil {
+					secondBest = best
+				}
+				best = value
+				bestRelay = relay
+			} else if value.Cmp(secondBest) > 0 {
+				secondBest = value
+			}
+		}
+		bestBids = append(bestBids, BestBid{
+			Slot:            slot,
+			RelayPublicKey:  bestRelay,
+			Value:           best.String(),
+			SecondBestValue: secondBest.String(),
+			Margin:          new(big.Int).Sub(best, secondBest).String(),
+			Relays:          uint(len(slotValues)),
+		})
+	}
+	sort.Slice(bestBids, func(i, j int) bool {
+		return bestBids[i].Slot < bestBids[j].Slot
+	})
+	return bestBids
+}
+
+// `GetBestBids` returns the best bid across relays of each slot with a bid in the inclusive slot range, ordered by slot
+func (r *Reporter) GetBestBids(ctx context.Context, startSlot, endSlot types.Slot) ([]BestBid, error) {
+	return shareReport(ctx, r, "best_bids", startSlot, endSlot, func(ctx context.Context) ([]BestBid, error) {
+		values, err := r.store.GetSlotBidValues(ctx, startSlot, endSlot)
+		if err != nil {
+			return nil, err
+		}
+		return computeBestBids(values), nil
+	})
+}
+
+// `GetBestBid` returns the best bid across relays in the `slot` or `nil` if there were no bids
+func (r *Reporter) GetBestBid(ctx context.Context, slot types.Slot) (*BestBid, error) {
+	bestBids, err := r.GetBestBids(ctx, slot, slot)
+	if err != nil {
+		return nil, err
+	}
+	if len(bestBids) == 0 {
+		return nil, nil
+	}
+	return &bestBids[0], nil
+}
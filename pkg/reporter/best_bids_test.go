@@ -0,0 +1,48 @@
+package reporter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestComputeBestBids(t *testing.T) {
+	relayA := types.PublicKey{0x01}
+	relayB := types.PublicKey{0x02}
+
+	bidValue := func(slot types.Slot, relay types.PublicKey, value int64) types.BidValue {
+		bidValue := types.BidValue{
+			Context: types.BidContext{
+				Slot:           slot,
+				RelayPublicKey: relay,
+			},
+		}
+		err := bidValue.Value.FromBig(big.NewInt(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bidValue
+	}
+
+	bestBids := computeBestBids([]types.BidValue{
+		bidValue(11, relayA, 4),
+		bidValue(10, relayA, 5),
+		bidValue(10, relayB, 9),
+		// NOTE: a lower bid from the same relay does not count as the second best bid
+		bidValue(10, relayB, 8),
+	})
+
+	expected := []BestBid{
+		{Slot: 10, RelayPublicKey: relayB, Value: "9", SecondBestValue: "5", Margin: "4", Relays: 2},
+		{Slot: 11, RelayPublicKey: relayA, Value: "4", SecondBestValue: "0", Margin: "4", Relays: 1},
+	}
+	if len(bestBids) != len(expected) {
+		t.Fatalf("unexpected best bids %+v", bestBids)
+	}
+	for i := range expected {
+		if bestBids[i] != expected[i] {
+			t.Fatalf("wrong best bid: %+v but expected %+v", bestBids[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package reporter
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `BidValueStats` summarizes the distribution of the values of a relay's bids, in wei
+type BidValueStats struct {
+	Samples uint   `json:"samples"`
+	Min     string `json:"min"`
+	Median  string `json:"median"`
+	P90     string `json:"p90"`
+	Max     string `json:"max"`
+}
+
+type BidValueStatsRecord = map[types.PublicKey]*BidValueStats
+
+func computeBidValueStats(values []types.U256Str) *BidValueStats {
+	if len(values) == 0 {
+		return &BidValueStats{}
+	}
+
+	sorted := make([]*big.Int, len(values))
+	for i := range values {
+		sorted[i] = values[i].BigInt()
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+
+	n := len(sorted)
+	return &BidValueStats{
+		Samples: uint(n),
+		Min:     sorted[0].String(),
+		Median:  sorted[percentileRank(n, 0.5)].String(),
+		P90:     sorted[percentileRank(n, 0.9)].String(),
+		Max:     sorted[n-1].String(),
+	}
+}
+
+// `GetBidValueStats` summarizes the values of the relay's bids observed in the inclusive slot range
+func (r *Reporter) GetBidValueStats(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (*BidValueStats, error) {
+	values, err := r.store.GetBidValues(ctx, relay, startSlot, endSlot)
+	if err != nil {
+		return nil, err
+	}
+	return computeBidValueStats(values), nil
+}
+
+// `GetBidValueStatsRecord` summarizes the values of the bids of each relay observed in the inclusive slot range
+func (r *Reporter) GetBidValueStatsRecord(ctx context.Context, startSlot, endSlot types.Slot) (BidValueStatsRecord, error) {
+	return shareReport(ctx, r, "bid_value_stats", startSlot, endSlot, func(ctx context.Context) (BidValueStatsRecord, error) {
+		stats := make(BidValueStatsRecord)
+		for _, relay := range r.Relays() {
+			relay := relay
+			relayStats, err := r.GetBidValueStats(ctx, &relay, startSlot, endSlot)
+			if err != nil {
+				return nil, err
+			}
+			stats[relay] = relayStats
+		}
+		return stats, nil
+	})
+}
@@ -0,0 +1,38 @@
+package reporter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestComputeBidValueStats(t *testing.T) {
+	var values []types.U256Str
+	// NOTE: insert out of order to exercise sorting
+	for _, value := range []int64{5, 1, 9, 3, 7, 2, 10, 4, 8, 6} {
+		var v types.U256Str
+		err := v.FromBig(big.NewInt(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, v)
+	}
+
+	stats := computeBidValueStats(values)
+	expected := &BidValueStats{
+		Samples: 10,
+		Min:     "1",
+		Median:  "5",
+		P90:     "9",
+		Max:     "10",
+	}
+	if *stats != *expected {
+		t.Fatalf("wrong bid value stats: %+v but expected %+v", stats, expected)
+	}
+
+	empty := computeBidValueStats(nil)
+	if empty.Samples != 0 {
+		t.Fatalf("expected no samples but got %+v", empty)
+	}
+}
@@ -0,0 +1,80 @@
+package reporter
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `BuilderStats` aggregates the payloads of a builder delivered across relays
+// and the analysis of the bids observed by the monitor for those same blocks
+type BuilderStats struct {
+	DeliveredPayloads uint `json:"delivered_payloads"`
+	// Total value of the delivered payloads, in wei
+	DeliveredValue string `json:"delivered_value"`
+	// Number of deliveries by each relay
+	Relays map[types.PublicKey]uint `json:"relays"`
+	// Number of delivered payloads with an analyzed bid for the same block
+	AnalyzedBids uint `json:"analyzed_bids"`
+	// Number of analyzed bids by category of fault
+	Faults map[string]uint `json:"faults"`
+	// Number of delivered payloads whose value differs from the observed bid for the same block
+	ValueDivergences uint `json:"value_divergences"`
+	// Share of analyzed bids which were valid, in [0, 1]
+	Score float64 `json:"score"`
+}
+
+// `GetBuilderStats` aggregates the faults and deliveries of `builder` across relays in the inclusive slot range
+func (r *Reporter) GetBuilderStats(ctx context.Context, builder *types.PublicKey, startSlot, endSlot types.Slot) (*BuilderStats, error) {
+	payloads, err := r.store.GetDeliveredPayloads(ctx, builder, startSlot, endSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &BuilderStats{
+		Relays: make(map[types.PublicKey]uint),
+		Faults: make(map[string]uint),
+	}
+	deliveredValue := big.NewInt(0)
+	var validBids uint
+	for _, payload := range payloads {
+		trace := payload.BidTrace
+		stats.DeliveredPayloads += 1
+		stats.Relays[payload.RelayPublicKey] += 1
+		deliveredValue.Add(deliveredValue, trace.Value.BigInt())
+
+		bidCtx := &types.BidContext{
+			Slot:              trace.Slot,
+			ParentHash:        trace.ParentHash,
+			ProposerPublicKey: trace.ProposerPubkey,
+			RelayPublicKey:    payload.RelayPublicKey,
+		}
+		bid, err := r.store.GetBid(ctx, bidCtx)
+		// NOTE: the monitor only samples the auction so the observed bid may be for another block
+		if err != nil || bid == nil || bid.Message.Header.BlockHash != trace.BlockHash {
+			continue
+		}
+		if bid.Message.Value != trace.Value {
+			stats.ValueDivergences += 1
+		}
+		analysis, err := r.store.GetBidAnalysis(ctx, bidCtx)
+		if err != nil {
+			return nil, err
+		}
+		if analysis == nil {
+			continue
+		}
+		stats.AnalyzedBids += 1
+		if analysis.Category == "" {
+			validBids += 1
+		} else {
+			stats.Faults[analysis.Category] += 1
+		}
+	}
+	stats.DeliveredValue = deliveredValue.String()
+	if stats.AnalyzedBids > 0 {
+		stats.Score = float64(validBids) / float64(stats.AnalyzedBids)
+	}
+	return stats, nil
+}
@@ -0,0 +1,134 @@
+package reporter
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `RelayDigest` summarizes the behavior of a relay over the span of a digest
+type RelayDigest struct {
+	RelayPublicKey types.PublicKey `json:"relay_public_key"`
+	AnalyzedBids   uint            `json:"analyzed_bids"`
+	TotalFaults    uint            `json:"total_faults"`
+	// Number of analyzed bids by category of fault
+	Faults map[string]uint `json:"faults"`
+	Score  float64         `json:"score"`
+	// Overall score of the relay over the span of the same length preceding the digest
+	PreviousScore float64 `json:"previous_score"`
+	// Share of the slots with a bid from any relay where the relay also had a bid, in [0, 1]
+	Availability float64 `json:"availability"`
+	// Annotations the operator of the relay attached to its faults, ordered by slot
+	Annotations []types.FaultAnnotation `json:"annotations,omitempty"`
+}
+
+type Digest struct {
+	StartSlot types.Slot `json:"start_slot,string"`
+	EndSlot   types.Slot `json:"end_slot,string"`
+	// Relays ordered by faults, most faults first
+	Relays []RelayDigest `json:"relays"`
+}
+
+func countFaults(analyses []types.BidAnalysis) (uint, map[string]uint) {
+	var total uint
+	faults := make(map[string]uint)
+	for _, analysis := range analyses {
+		if analysis.Category == "" {
+			continue
+		}
+		total += 1
+		faults[analysis.Category] += 1
+	}
+	return total, faults
+}
+
+// `computeAvailability` returns the share of the slots with any bid where each relay had a bid
+func computeAvailability(relays []types.PublicKey, values []types.BidValue) map[types.PublicKey]float64 {
+	slots := make(map[types.Slot]struct{})
+	relaySlots := make(map[types.PublicKey]map[types.Slot]struct{})
+	for _, value := range values {
+		slot := value.Context.Slot
+		relay := value.Context.RelayPublicKey
+		slots[slot] = struct{}{}
+		if _, ok := relaySlots[relay]; !ok {
+			relaySlots[relay] = make(map[types.Slot]struct{})
+		}
+		relaySlots[relay][slot] = struct{}{}
+	}
+
+	availability := make(map[types.PublicKey]float64)
+	for _, relay := range relays {
+		if len(slots) > 0 {
+			availability[relay] = float64(len(relaySlots[relay])) / float64(len(slots))
+		}
+	}
+	return availability
+}
+
+func sortRelayDigests(digests []RelayDigest) {
+	sort.SliceStable(digests, func(i, j int) bool {
+		if digests[i].TotalFaults != digests[j].TotalFaults {
+			return digests[i].TotalFaults > digests[j].TotalFaults
+		}
+		return digests[i].Score < digests[j].Score
+	})
+}
+
+// `GetDigest` summarizes the faults, score movement and availability of each relay over the inclusive slot range
+func (r *Reporter) GetDigest(ctx context.Context, startSlot, endSlot types.Slot) (*Digest, error) {
+	relays := r.Relays()
+
+	values, err := r.store.GetSlotBidValues(ctx, startSlot, endSlot)
+	if err != nil {
+		return nil, err
+	}
+	availability := computeAvailability(relays, values)
+
+	// NOTE: compare against the preceding span of the same length, truncated at genesis
+	length := endSlot - startSlot + 1
+	var previousStartSlot types.Slot
+	if startSlot > length {
+		previousStartSlot = startSlot - length
+	}
+
+	digest := &Digest{
+		StartSlot: startSlot,
+		EndSlot:   endSlot,
+	}
+	for _, relay := range relays {
+		relay := relay
+		analyses, err := r.store.GetBidAnalyses(ctx, &relay, startSlot, endSlot)
+		if err != nil {
+			return nil, err
+		}
+		totalFaults, faults := countFaults(analyses)
+		annotations, err := r.store.GetFaultAnnotations(ctx, &relay, startSlot, endSlot)
+		if err != nil {
+			return nil, err
+		}
+		score, err := r.GetOverallScore(ctx, &relay, startSlot, endSlot)
+		if err != nil {
+			return nil, err
+		}
+		relayDigest := RelayDigest{
+			RelayPublicKey: relay,
+			AnalyzedBids:   uint(len(analyses)),
+			TotalFaults:    totalFaults,
+			Faults:         faults,
+			Score:          score.Score,
+			Availability:   availability[relay],
+			Annotations:    annotations,
+		}
+		if startSlot > 0 {
+			previousScore, err := r.GetOverallScore(ctx, &relay, previousStartSlot, startSlot-1)
+			if err != nil {
+				return nil, err
+			}
+			relayDigest.PreviousScore = previousScore.Score
+		}
+		digest.Relays = append(digest.Relays, relayDigest)
+	}
+	sortRelayDigests(digest.Relays)
+	return digest, nil
+}
@@ -0,0 +1,58 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestComputeAvailability(t *testing.T) {
+	relayA := types.PublicKey{0x01}
+	relayB := types.PublicKey{0x02}
+
+	bidValue := func(slot types.Slot, relay types.PublicKey) types.BidValue {
+		return types.BidValue{Context: types.BidContext{Slot: slot, RelayPublicKey: relay}}
+	}
+
+	availability := computeAvailability([]types.PublicKey{relayA, relayB}, []types.BidValue{
+		bidValue(10, relayA),
+		bidValue(10, relayB),
+		bidValue(11, relayA),
+		bidValue(12, relayA),
+		bidValue(13, relayB),
+	})
+	if availability[relayA] != 0.75 {
+		t.Fatalf("unexpected availability for relay a: %f", availability[relayA])
+	}
+	if availability[relayB] != 0.5 {
+		t.Fatalf("unexpected availability for relay b: %f", availability[relayB])
+	}
+}
+
+func TestCountFaults(t *testing.T) {
+	analyses := []types.BidAnalysis{
+		{},
+		{Category: analysis.CategoryConsensusInvalid},
+		{Category: analysis.CategoryConsensusInvalid},
+		{Category: analysis.CategoryIgnoredPreferences},
+	}
+	total, faults := countFaults(analyses)
+	if total != 3 || faults[analysis.CategoryConsensusInvalid] != 2 || faults[analysis.CategoryIgnoredPreferences] != 1 {
+		t.Fatalf("unexpected fault counts: %d %v", total, faults)
+	}
+}
+
+func TestSortRelayDigests(t *testing.T) {
+	digests := []RelayDigest{
+		{RelayPublicKey: types.PublicKey{0x01}, TotalFaults: 1, Score: 0.9},
+		{RelayPublicKey: types.PublicKey{0x02}, TotalFaults: 3, Score: 0.5},
+		{RelayPublicKey: types.PublicKey{0x03}, TotalFaults: 1, Score: 0.7},
+	}
+	sortRelayDigests(digests)
+	for i, expected := range []byte{0x02, 0x03, 0x01} {
+		if digests[i].RelayPublicKey[0] != expected {
+			t.Fatalf("unexpected order of relay digests: %+v", digests)
+		}
+	}
+}
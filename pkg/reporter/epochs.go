@@ -0,0 +1,67 @@
+package reporter
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `EpochStats` aggregates the analyses of the bids of a relay in an epoch
+type EpochStats struct {
+	Epoch types.Epoch `json:"epoch,string"`
+	Bids  uint        `json:"bids"`
+	// Number of bids with a fault
+	Faults    uint    `json:"faults"`
+	FaultRate float64 `json:"fault_rate"`
+	// Share of valid bids, as scored by the count-weighted strategy, where `0` indicates no bids
+	Score float64 `json:"score"`
+}
+
+type EpochStatsRecord = map[types.PublicKey][]EpochStats
+
+// `computeEpochStats` aggregates `analyses` into the stats of each epoch in the inclusive epoch range, ordered by epoch
+func computeEpochStats(analyses []types.BidAnalysis, startEpoch, endEpoch types.Epoch, slotsPerEpoch uint64) []EpochStats {
+	stats := make([]EpochStats, 0, endEpoch-startEpoch+1)
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		stats = append(stats, EpochStats{Epoch: epoch})
+	}
+	for _, analysis := range analyses {
+		epoch := analysis.Context.Slot / slotsPerEpoch
+		if epoch < startEpoch || epoch > endEpoch {
+			continue
+		}
+		entry := &stats[epoch-startEpoch]
+		entry.Bids += 1
+		if analysis.Category != "" {
+			entry.Faults += 1
+		}
+	}
+	for i := range stats {
+		entry := &stats[i]
+		if entry.Bids == 0 {
+			continue
+		}
+		entry.FaultRate = float64(entry.Faults) / float64(entry.Bids)
+		entry.Score = 1 - entry.FaultRate
+	}
+	return stats
+}
+
+// `GetEpochStats` aggregates the analyses of the bids of each relay per epoch over the inclusive epoch range,
+// only counting the analyses by `ruleset` if given
+func (r *Reporter) GetEpochStats(ctx context.Context, startEpoch, endEpoch types.Epoch, slotsPerEpoch uint64, ruleset string) (EpochStatsRecord, error) {
+	startSlot := startEpoch * slotsPerEpoch
+	endSlot := (endEpoch+1)*slotsPerEpoch - 1
+	return shareReport(ctx, r, "epoch_stats/"+ruleset, startSlot, endSlot, func(ctx context.Context) (EpochStatsRecord, error) {
+		record := make(EpochStatsRecord)
+		for _, relay := range r.Relays() {
+			relay := relay
+			analyses, err := getBidAnalyses(ctx, r.store, &relay, startSlot, endSlot, ruleset)
+			if err != nil {
+				return nil, err
+			}
+			record[relay] = computeEpochStats(analyses, startEpoch, endEpoch, slotsPerEpoch)
+		}
+		return record, nil
+	})
+}
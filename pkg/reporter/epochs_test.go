@@ -0,0 +1,33 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestComputeEpochStats(t *testing.T) {
+	analyses := []types.BidAnalysis{
+		{Context: types.BidContext{Slot: 64}},
+		{Context: types.BidContext{Slot: 70}},
+		{Context: types.BidContext{Slot: 80}},
+		{Context: types.BidContext{Slot: 95}, Category: "consensus_invalid"},
+		{Context: types.BidContext{Slot: 128}, Category: "ignored_preferences"},
+		// outside of the range
+		{Context: types.BidContext{Slot: 10}},
+	}
+	stats := computeEpochStats(analyses, 2, 5, 32)
+	if len(stats) != 4 {
+		t.Fatalf("expected stats of 4 epochs but got %d", len(stats))
+	}
+	for i, expected := range []EpochStats{
+		{Epoch: 2, Bids: 4, Faults: 1, FaultRate: 0.25, Score: 0.75},
+		{Epoch: 3, Bids: 0},
+		{Epoch: 4, Bids: 1, Faults: 1, FaultRate: 1, Score: 0},
+		{Epoch: 5, Bids: 0},
+	} {
+		if stats[i] != expected {
+			t.Errorf("expected %+v but got %+v", expected, stats[i])
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package reporter
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// Latency at (or beyond) which a relay receives the lowest score,
+// matches the default timeout used when requesting bids from relays
+const LatencyScoreCeiling = 2 * time.Second
+
+type LatencyScore struct {
+	Samples uint `json:"samples"`
+	// Median `getHeader` latency, in milliseconds
+	P50 int64 `json:"p50_ms"`
+	// 95th percentile `getHeader` latency, in milliseconds
+	P95 int64 `json:"p95_ms"`
+	// Score in [0, 1] where 1 is best
+	Score float64 `json:"score"`
+}
+
+type LatencyScoreRecord = map[types.PublicKey]*LatencyScore
+
+// `percentileRank` returns the index of the nearest-rank percentile `p` in (0, 1] of `n` sorted samples
+func percentileRank(n int, p float64) int {
+	rank := int(math.Ceil(p*float64(n))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return rank
+}
+
+// `percentile` returns the nearest-rank percentile `p` in (0, 1] of the sorted `latencies`
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	return latencies[percentileRank(len(latencies), p)]
+}
+
+func computeLatencyScore(latencies []time.Duration) *LatencyScore {
+	if len(latencies) == 0 {
+		return &LatencyScore{}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	p50 := percentile(latencies, 0.5)
+	p95 := percentile(latencies, 0.95)
+
+	// weigh typical and tail latency equally
+	weighted := (float64(p50) + float64(p95)) / 2
+	score := 1 - weighted/float64(LatencyScoreCeiling)
+	score = math.Max(0, math.Min(1, score))
+
+	return &LatencyScore{
+		Samples: uint(len(latencies)),
+		P50:     p50.Milliseconds(),
+		P95:     p95.Milliseconds(),
+		Score:   score,
+	}
+}
+
+// `GetLatencyScore` scores the relay on its `getHeader` latency observed in the inclusive slot range
+func (r *Reporter) GetLatencyScore(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (*LatencyScore, error) {
+	latencies, err := r.store.GetBidLatencies(ctx, relay, startSlot, endSlot)
+	if err != nil {
+		return nil, err
+	}
+	return computeLatencyScore(latencies), nil
+}
+
+func (r *Reporter) GetLatencyScores(ctx context.Context, startSlot, endSlot types.Slot) (LatencyScoreRecord, error) {
+	return shareReport(ctx, r, "latency_scores", startSlot, endSlot, func(ctx context.Context) (LatencyScoreRecord, error) {
+		scores := make(LatencyScoreRecord)
+		for _, relay := range r.Relays() {
+			relay := relay
+			score, err := r.GetLatencyScore(ctx, &relay, startSlot, endSlot)
+			if err != nil {
+				return nil, err
+			}
+			scores[relay] = score
+		}
+		return scores, nil
+	})
+}
@@ -0,0 +1,53 @@
+package reporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeLatencyScore(t *testing.T) {
+	for _, tc := range []struct {
+		latencies []time.Duration
+		p50       int64
+		p95       int64
+		score     float64
+	}{
+		{
+			latencies: nil,
+			p50:       0,
+			p95:       0,
+			score:     0,
+		},
+		{
+			latencies: []time.Duration{100 * time.Millisecond},
+			p50:       100,
+			p95:       100,
+			score:     0.95,
+		},
+		{
+			latencies: []time.Duration{
+				400 * time.Millisecond,
+				100 * time.Millisecond,
+				300 * time.Millisecond,
+				200 * time.Millisecond,
+			},
+			p50:   200,
+			p95:   400,
+			score: 0.85,
+		},
+		{
+			latencies: []time.Duration{3 * time.Second, 5 * time.Second},
+			p50:       3000,
+			p95:       5000,
+			score:     0,
+		},
+	} {
+		result := computeLatencyScore(tc.latencies)
+		if result.P50 != tc.p50 || result.P95 != tc.p95 {
+			t.Fatal("wrong percentiles computed:", result.P50, result.P95, "but expected", tc.p50, tc.p95)
+		}
+		if diff := result.Score - tc.score; diff > 1e-9 || diff < -1e-9 {
+			t.Fatal("wrong score computed:", result.Score, "but expected", tc.score)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"golang.org/x/sync/singleflight"
+)
+
+// `reportTimeout` bounds each shared computation of a report, which outlives the caller who started it
+const reportTimeout = 30 * time.Second
+
+// `Reporter` derives summary reports about the configured relays from the data in the store
+type Reporter struct {
+	store store.Storer
+
+	relays  []types.PublicKey
+	scorers []weightedScorer
+	window  uint64
+	lock    sync.RWMutex
+
+	// deduplicates concurrent computations of the same report, e.g. from polls of the HTTP and gRPC servers
+	reports singleflight.Group
+
+	// computed reports are reused for `cacheTTL`, if positive
+	cacheTTL  time.Duration
+	cache     map[string]cachedReport
+	cacheLock sync.Mutex
+}
+
+type cachedReport struct {
+	report    interface{}
+	expiresAt time.Time
+}
+
+// `NewReporter` returns a reporter which reuses each computed report for `cacheTTL`, or computes each report on request if `cacheTTL` is zero
+func NewReporter(config *ScoringConfig, relays []types.PublicKey, store store.Storer, cacheTTL time.Duration) (*Reporter, error) {
+	scorers, err := newScorers(config, store)
+	if err != nil {
+		return nil, err
+	}
+	return &Reporter{
+		relays:   relays,
+		store:    store,
+		scorers:  scorers,
+		window:   config.window(),
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]cachedReport),
+	}, nil
+}
+
+func (r *Reporter) Relays() []types.PublicKey {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.relays
+}
+
+// `Window` is the number of slots scores cover by default, or zero if the default of the API applies
+func (r *Reporter) Window() uint64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.window
+}
+
+func (r *Reporter) getScorers() []weightedScorer {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.scorers
+}
+
+// `Reload` replaces the relays reported on, the scoring strategies and the default window of scores
+func (r *Reporter) Reload(config *ScoringConfig, relays []types.PublicKey) error {
+	scorers, err := newScorers(config, r.store)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.relays = relays
+	r.scorers = scorers
+	r.window = config.window()
+
+	r.cacheLock.Lock()
+	defer r.cacheLock.Unlock()
+
+	// NOTE: cached reports cover the previous relays and scoring
+	r.cache = make(map[string]cachedReport)
+	return nil
+}
+
+func (r *Reporter) getCachedReport(key string, now time.Time) (interface{}, bool) {
+	r.cacheLock.Lock()
+	defer r.cacheLock.Unlock()
+
+	cached, ok := r.cache[key]
+	if !ok || !now.Before(cached.expiresAt) {
+		return nil, false
+	}
+	return cached.report, true
+}
+
+func (r *Reporter) putCachedReport(key string, report interface{}, now time.Time) {
+	r.cacheLock.Lock()
+	defer r.cacheLock.Unlock()
+
+	for key, cached := range r.cache {
+		if !now.Before(cached.expiresAt) {
+			delete(r.cache, key)
+		}
+	}
+	r.cache[key] = cachedReport{
+		report:    report,
+		expiresAt: now.Add(r.cacheTTL),
+	}
+}
+
+// `shareReport` computes the `report` over the inclusive slot range with `compute`, sharing the result
+// with any concurrent callers requesting the same report and range and with later callers within the cache TTL
+// NOTE: shared results must not be modified by callers
+// NOTE: a caller returns once its `ctx` is done even while the shared computation, which runs on its own context
+// so that the other callers are not canceled with the caller who started it, is still in progress
+func shareReport[V any](ctx context.Context, r *Reporter, report string, startSlot, endSlot types.Slot, compute func(ctx context.Context) (V, error)) (V, error) {
+	key := fmt.Sprintf("%s/%d/%d", report, startSlot, endSlot)
+	if r.cacheTTL > 0 {
+		if cached, ok := r.getCachedReport(key, time.Now()); ok {
+			return cached.(V), nil
+		}
+	}
+	results := r.reports.DoChan(key, func() (interface{}, error) {
+		computeCtx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+		defer cancel()
+		result, err := compute(computeCtx)
+		if err != nil {
+			return nil, err
+		}
+		if r.cacheTTL > 0 {
+			r.putCachedReport(key, result, time.Now())
+		}
+		return result, nil
+	})
+	var zero V
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return zero, result.Err
+		}
+		return result.Val.(V), nil
+	}
+}
+
+// `getBidAnalyses` returns the analyses of the relay's bids in the inclusive slot range by `ruleset`,
+// or the latest analysis of each bid if `ruleset` is empty
+func getBidAnalyses(ctx context.Context, store store.Storer, relay *types.PublicKey, startSlot, endSlot types.Slot, ruleset string) ([]types.BidAnalysis, error) {
+	if ruleset == "" {
+		return store.GetBidAnalyses(ctx, relay, startSlot, endSlot)
+	}
+	return store.GetRulesetBidAnalyses(ctx, relay, startSlot, endSlot, ruleset)
+}
@@ -0,0 +1,150 @@
+package reporter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestShareReport(t *testing.T) {
+	r := &Reporter{}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	first := make(chan int)
+	go func() {
+		result, err := shareReport(context.Background(), r, "test", 10, 20, func(context.Context) (int, error) {
+			close(started)
+			<-release
+			return 42, nil
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		first <- result
+	}()
+	<-started
+
+	second := make(chan int)
+	go func() {
+		result, err := shareReport(context.Background(), r, "test", 10, 20, func(context.Context) (int, error) {
+			t.Error("expected in-flight computation to be shared")
+			return 0, nil
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		second <- result
+	}()
+	// NOTE: give the second caller time to join the in-flight computation
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	if result := <-first; result != 42 {
+		t.Fatalf("expected 42, got %d", result)
+	}
+	if result := <-second; result != 42 {
+		t.Fatalf("expected shared result 42, got %d", result)
+	}
+
+	result, err := shareReport(context.Background(), r, "test", 10, 21, func(context.Context) (int, error) { return 7, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != 7 {
+		t.Fatalf("expected distinct range to be computed separately, got %d", result)
+	}
+}
+
+func TestShareReportCache(t *testing.T) {
+	r, err := NewReporter(nil, nil, nil, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	computations := 0
+	compute := func(context.Context) (int, error) {
+		computations += 1
+		return computations, nil
+	}
+	for i := 0; i < 3; i++ {
+		result, err := shareReport(context.Background(), r, "test", 10, 20, compute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != 1 {
+			t.Fatalf("expected cached result 1, got %d", result)
+		}
+	}
+
+	// NOTE: expire the cached report
+	r.putCachedReport("test/10/20", 1, time.Now().Add(-2*time.Hour))
+	result, err := shareReport(context.Background(), r, "test", 10, 20, compute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != 2 {
+		t.Fatalf("expected expired report to be recomputed, got %d", result)
+	}
+}
+
+func TestShareReportCanceled(t *testing.T) {
+	r := &Reporter{}
+
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := shareReport(ctx, r, "test", 10, 20, func(context.Context) (int, error) {
+		<-release
+		return 42, nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("expected canceled caller to return with %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestShareReportOutlivesFirstCaller(t *testing.T) {
+	r := &Reporter{}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := shareReport(ctx, r, "test", 10, 20, func(ctx context.Context) (int, error) {
+			close(started)
+			<-release
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			return 42, nil
+		})
+		first <- err
+	}()
+	<-started
+
+	second := make(chan int)
+	go func() {
+		result, err := shareReport(context.Background(), r, "test", 10, 20, func(context.Context) (int, error) {
+			t.Error("expected in-flight computation to be shared")
+			return 0, nil
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		second <- result
+	}()
+	// NOTE: give the second caller time to join the in-flight computation
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if err := <-first; err != context.Canceled {
+		t.Fatalf("expected canceled caller to return with %v, got %v", context.Canceled, err)
+	}
+	close(release)
+	if result := <-second; result != 42 {
+		t.Fatalf("expected shared result 42 after the first caller was canceled, got %d", result)
+	}
+}
@@ -0,0 +1,365 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+const (
+	TimeWeightedStrategy     = "time-weighted"
+	CountWeightedStrategy    = "count-weighted"
+	CategoryWeightedStrategy = "category-weighted"
+	LatencyStrategy          = "latency"
+	UptimeStrategy           = "uptime"
+
+	// Decay per slot of the weight of an analysis for the time-weighted strategy
+	DefaultLambda = 0.0005
+	// Maximum decay per slot, under which the weight of an analysis halves in under a slot
+	MaxLambda = 1.0
+)
+
+var DefaultCategoryWeights = map[string]float64{
+	analysis.CategoryConsensusInvalid:   1.0,
+	analysis.CategoryIgnoredPreferences: 0.5,
+	analysis.CategoryUnknown:            1.0,
+}
+
+var DefaultSeverityWeights = map[string]float64{
+	analysis.SeverityCritical: 1.0,
+	analysis.SeverityMajor:    0.5,
+	analysis.SeverityMinor:    0.1,
+}
+
+// `Scorer` computes a score in [0, 1] for a relay over an inclusive slot range, where 1 is best
+type Scorer interface {
+	Score(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (float64, error)
+}
+
+type ScorerFactory func(config *StrategyConfig, store store.Storer) Scorer
+
+var scorerRegistry = map[string]ScorerFactory{
+	TimeWeightedStrategy:     newTimeWeightedScorer,
+	CountWeightedStrategy:    newCountWeightedScorer,
+	CategoryWeightedStrategy: newCategoryWeightedScorer,
+	LatencyStrategy:          newLatencyScorer,
+	UptimeStrategy:           newUptimeScorer,
+}
+
+// `RegisterScorer` makes a scoring strategy available to the configuration under `name`
+func RegisterScorer(name string, factory ScorerFactory) {
+	scorerRegistry[name] = factory
+}
+
+type StrategyConfig struct {
+	Name string `yaml:"name"`
+	// Relative weight of this strategy in the overall score
+	Weight float64 `yaml:"weight"`
+	// Decay per slot, used by the time-weighted strategy, where 0 disables the decay; `DefaultLambda` if unset
+	Lambda *float64 `yaml:"lambda"`
+	// Penalty per fault category, used by the category-weighted strategy
+	CategoryWeights map[string]float64 `yaml:"category_weights"`
+	// Factor of the penalty of a fault per severity, used by the category-weighted strategy
+	SeverityWeights map[string]float64 `yaml:"severity_weights"`
+}
+
+type ScoringConfig struct {
+	Strategies []StrategyConfig `yaml:"strategies"`
+	// Number of slots the scores of a request without an explicit `window` cover, the default slot window of the API if unset
+	Window uint64 `yaml:"window"`
+}
+
+func (c *ScoringConfig) window() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.Window
+}
+
+// `Validate` checks the scoring configuration against the maximum number of slots a request can cover
+func (c *ScoringConfig) Validate(maxWindow uint64) error {
+	if c.window() > maxWindow {
+		return fmt.Errorf("default scoring window of %d slots exceeds the maximum of %d slots", c.window(), maxWindow)
+	}
+	return nil
+}
+
+// `ValidateLambda` checks that `lambda` is a decay per slot within [0, `MaxLambda`], where 0 disables the decay
+func ValidateLambda(lambda float64) error {
+	if math.IsNaN(lambda) || lambda < 0 || lambda > MaxLambda {
+		return fmt.Errorf("invalid lambda %g: must be between 0 and %g", lambda, MaxLambda)
+	}
+	return nil
+}
+
+func (c *StrategyConfig) lambda() float64 {
+	if c == nil || c.Lambda == nil {
+		return DefaultLambda
+	}
+	return *c.Lambda
+}
+
+func defaultScoringConfig() *ScoringConfig {
+	return &ScoringConfig{
+		Strategies: []StrategyConfig{
+			{Name: TimeWeightedStrategy, Weight: 1},
+			{Name: CountWeightedStrategy, Weight: 1},
+			{Name: CategoryWeightedStrategy, Weight: 1},
+			{Name: LatencyStrategy, Weight: 1},
+			{Name: UptimeStrategy, Weight: 1},
+		},
+	}
+}
+
+type weightedScorer struct {
+	name   string
+	weight float64
+	scorer Scorer
+}
+
+// `decayingScorer` is implemented by scorers which weigh analyses by a decay with their age
+type decayingScorer interface {
+	// `withLambda` returns the scorer with a decay of `lambda` per slot
+	withLambda(lambda float64) Scorer
+}
+
+// `withLambda` returns `scorers` where each decaying scorer has a decay of `lambda` per slot
+func withLambda(scorers []weightedScorer, lambda float64) []weightedScorer {
+	result := make([]weightedScorer, 0, len(scorers))
+	for _, scorer := range scorers {
+		if decaying, ok := scorer.scorer.(decayingScorer); ok {
+			scorer.scorer = decaying.withLambda(lambda)
+		}
+		result = append(result, scorer)
+	}
+	return result
+}
+
+func newScorers(config *ScoringConfig, store store.Storer) ([]weightedScorer, error) {
+	if config == nil || len(config.Strategies) == 0 {
+		config = defaultScoringConfig()
+	}
+
+	var scorers []weightedScorer
+	for i := range config.Strategies {
+		strategy := &config.Strategies[i]
+		factory, ok := scorerRegistry[strategy.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown scoring strategy %s", strategy.Name)
+		}
+		if strategy.Weight < 0 {
+			return nil, fmt.Errorf("invalid weight %f for scoring strategy %s", strategy.Weight, strategy.Name)
+		}
+		err := ValidateLambda(strategy.lambda())
+		if err != nil {
+			return nil, fmt.Errorf("invalid scoring strategy %s: %v", strategy.Name, err)
+		}
+		scorers = append(scorers, weightedScorer{
+			name:   strategy.Name,
+			weight: strategy.Weight,
+			scorer: factory(strategy, store),
+		})
+	}
+	return scorers, nil
+}
+
+type OverallScore struct {
+	Score float64 `json:"score"`
+	// Score of each configured strategy
+	Components map[string]float64 `json:"components"`
+}
+
+type OverallScoreRecord = map[types.PublicKey]*OverallScore
+
+// `GetOverallScore` combines the scores of the configured strategies by their relative weights
+func (r *Reporter) GetOverallScore(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (*OverallScore, error) {
+	return overallScore(ctx, r.getScorers(), relay, startSlot, endSlot)
+}
+
+// `GetOverallScoreWithLambda` is `GetOverallScore` where the decaying strategies decay by `lambda` per slot
+// instead of their configured decay
+func (r *Reporter) GetOverallScoreWithLambda(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot, lambda float64) (*OverallScore, error) {
+	return overallScore(ctx, withLambda(r.getScorers(), lambda), relay, startSlot, endSlot)
+}
+
+func overallScore(ctx context.Context, scorers []weightedScorer, relay *types.PublicKey, startSlot, endSlot types.Slot) (*OverallScore, error) {
+	result := &OverallScore{
+		Components: make(map[string]float64),
+	}
+	var totalWeight float64
+	for _, scorer := range scorers {
+		score, err := scorer.scorer.Score(ctx, relay, startSlot, endSlot)
+		if err != nil {
+			return nil, err
+		}
+		result.Components[scorer.name] = score
+		result.Score += scorer.weight * score
+		totalWeight += scorer.weight
+	}
+	if totalWeight > 0 {
+		result.Score /= totalWeight
+	}
+	return result, nil
+}
+
+func (r *Reporter) GetOverallScores(ctx context.Context, startSlot, endSlot types.Slot) (OverallScoreRecord, error) {
+	return shareReport(ctx, r, "overall_scores", startSlot, endSlot, func(ctx context.Context) (OverallScoreRecord, error) {
+		return r.overallScores(ctx, r.getScorers(), startSlot, endSlot)
+	})
+}
+
+// `GetOverallScoresWithLambda` is `GetOverallScores` where the decaying strategies decay by `lambda` per slot
+// instead of their configured decay
+func (r *Reporter) GetOverallScoresWithLambda(ctx context.Context, startSlot, endSlot types.Slot, lambda float64) (OverallScoreRecord, error) {
+	report := fmt.Sprintf("overall_scores/lambda=%g", lambda)
+	return shareReport(ctx, r, report, startSlot, endSlot, func(ctx context.Context) (OverallScoreRecord, error) {
+		return r.overallScores(ctx, withLambda(r.getScorers(), lambda), startSlot, endSlot)
+	})
+}
+
+func (r *Reporter) overallScores(ctx context.Context, scorers []weightedScorer, startSlot, endSlot types.Slot) (OverallScoreRecord, error) {
+	scores := make(OverallScoreRecord)
+	for _, relay := range r.Relays() {
+		relay := relay
+		score, err := overallScore(ctx, scorers, &relay, startSlot, endSlot)
+		if err != nil {
+			return nil, err
+		}
+		scores[relay] = score
+	}
+	return scores, nil
+}
+
+// `timeWeightedScorer` scores the share of valid bids where the weight of each analysis decays exponentially with its age in slots
+type timeWeightedScorer struct {
+	store  store.Storer
+	lambda float64
+}
+
+func newTimeWeightedScorer(config *StrategyConfig, store store.Storer) Scorer {
+	return &timeWeightedScorer{store: store, lambda: config.lambda()}
+}
+
+func (s *timeWeightedScorer) withLambda(lambda float64) Scorer {
+	return &timeWeightedScorer{store: s.store, lambda: lambda}
+}
+
+func (s *timeWeightedScorer) Score(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (float64, error) {
+	analyses, err := s.store.GetBidAnalyses(ctx, relay, startSlot, endSlot)
+	if err != nil {
+		return 0, err
+	}
+	var total, valid float64
+	for _, analysis := range analyses {
+		age := float64(endSlot - analysis.Context.Slot)
+		weight := math.Exp(-s.lambda * age)
+		total += weight
+		if analysis.Category == "" {
+			valid += weight
+		}
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	return valid / total, nil
+}
+
+// `countWeightedScorer` scores the share of valid bids
+type countWeightedScorer struct {
+	store store.Storer
+}
+
+func newCountWeightedScorer(config *StrategyConfig, store store.Storer) Scorer {
+	return &countWeightedScorer{store: store}
+}
+
+func (s *countWeightedScorer) Score(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (float64, error) {
+	analyses, err := s.store.GetBidAnalyses(ctx, relay, startSlot, endSlot)
+	if err != nil {
+		return 0, err
+	}
+	if len(analyses) == 0 {
+		return 0, nil
+	}
+	var valid int
+	for _, analysis := range analyses {
+		if analysis.Category == "" {
+			valid += 1
+		}
+	}
+	return float64(valid) / float64(len(analyses)), nil
+}
+
+// `categoryWeightedScorer` penalizes each fault by the weight of its category, scaled by the weight of its severity
+type categoryWeightedScorer struct {
+	store           store.Storer
+	weights         map[string]float64
+	severityWeights map[string]float64
+}
+
+func newCategoryWeightedScorer(config *StrategyConfig, store store.Storer) Scorer {
+	weights := make(map[string]float64)
+	for category, weight := range DefaultCategoryWeights {
+		weights[category] = weight
+	}
+	for category, weight := range config.CategoryWeights {
+		weights[category] = weight
+	}
+	severityWeights := make(map[string]float64)
+	for severity, weight := range DefaultSeverityWeights {
+		severityWeights[severity] = weight
+	}
+	for severity, weight := range config.SeverityWeights {
+		severityWeights[severity] = weight
+	}
+	return &categoryWeightedScorer{store: store, weights: weights, severityWeights: severityWeights}
+}
+
+func (s *categoryWeightedScorer) penalty(analysis *types.BidAnalysis) float64 {
+	penalty := s.weights[analysis.Category]
+	// NOTE: analyses recorded before severities were introduced have none and are penalized in full
+	if weight, ok := s.severityWeights[analysis.Severity]; ok {
+		penalty *= weight
+	}
+	return penalty
+}
+
+func (s *categoryWeightedScorer) Score(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (float64, error) {
+	analyses, err := s.store.GetBidAnalyses(ctx, relay, startSlot, endSlot)
+	if err != nil {
+		return 0, err
+	}
+	if len(analyses) == 0 {
+		return 0, nil
+	}
+	var penalty float64
+	for i := range analyses {
+		analysis := &analyses[i]
+		if analysis.Category == "" {
+			continue
+		}
+		penalty += s.penalty(analysis)
+	}
+	score := 1 - penalty/float64(len(analyses))
+	return math.Max(0, score), nil
+}
+
+type latencyScorer struct {
+	store store.Storer
+}
+
+func newLatencyScorer(config *StrategyConfig, store store.Storer) Scorer {
+	return &latencyScorer{store: store}
+}
+
+func (s *latencyScorer) Score(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (float64, error) {
+	latencies, err := s.store.GetBidLatencies(ctx, relay, startSlot, endSlot)
+	if err != nil {
+		return 0, err
+	}
+	return computeLatencyScore(latencies).Score, nil
+}
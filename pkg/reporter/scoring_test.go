@@ -0,0 +1,93 @@
+package reporter
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestValidateLambda(t *testing.T) {
+	for _, tc := range []struct {
+		lambda float64
+		valid  bool
+	}{
+		{lambda: 0, valid: true},
+		{lambda: DefaultLambda, valid: true},
+		{lambda: MaxLambda, valid: true},
+		{lambda: -0.1, valid: false},
+		{lambda: MaxLambda + 0.1, valid: false},
+		{lambda: math.NaN(), valid: false},
+	} {
+		err := ValidateLambda(tc.lambda)
+		if (err == nil) != tc.valid {
+			t.Errorf("lambda %g: expected valid %t but got error %v", tc.lambda, tc.valid, err)
+		}
+	}
+}
+
+func TestOverallScoreWithLambda(t *testing.T) {
+	ctx := context.Background()
+	relay := types.PublicKey{1}
+	memoryStore := store.NewMemoryStore()
+	// an old fault followed by a recent valid bid
+	for slot, category := range map[types.Slot]string{90: analysis.CategoryConsensusInvalid, 100: ""} {
+		err := memoryStore.PutBidAnalysis(ctx, &types.BidAnalysis{
+			Context:  types.BidContext{Slot: slot, RelayPublicKey: relay},
+			Category: category,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := &ScoringConfig{Strategies: []StrategyConfig{{Name: TimeWeightedStrategy, Weight: 1}}}
+	r, err := NewReporter(config, []types.PublicKey{relay}, memoryStore, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	undecayed, err := r.GetOverallScoreWithLambda(ctx, &relay, 0, 100, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if undecayed.Score != 0.5 {
+		t.Errorf("expected score of 0.5 without decay but got %f", undecayed.Score)
+	}
+	scores, err := r.GetOverallScoresWithLambda(ctx, 0, 100, MaxLambda)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scores[relay].Score <= undecayed.Score {
+		t.Errorf("expected a decay of %g to discount the old fault but got score %f", MaxLambda, scores[relay].Score)
+	}
+	configured, err := r.GetOverallScore(ctx, &relay, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configured.Score <= undecayed.Score || configured.Score >= scores[relay].Score {
+		t.Errorf("expected the default decay to score between %f and %f but got %f", undecayed.Score, scores[relay].Score, configured.Score)
+	}
+}
+
+func TestTimeWeightedConfiguredLambda(t *testing.T) {
+	noDecay := 0.0
+	for _, tc := range []struct {
+		name     string
+		lambda   *float64
+		expected float64
+	}{
+		{name: "unset", lambda: nil, expected: DefaultLambda},
+		{name: "explicit zero", lambda: &noDecay, expected: 0},
+	} {
+		scorers, err := newScorers(&ScoringConfig{Strategies: []StrategyConfig{{Name: TimeWeightedStrategy, Weight: 1, Lambda: tc.lambda}}}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lambda := scorers[0].scorer.(*timeWeightedScorer).lambda; lambda != tc.expected {
+			t.Errorf("%s: expected lambda %g but got %g", tc.name, tc.expected, lambda)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package reporter
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `BlockUniqueness` summarizes how many of a relay's bids offered a block no other relay offered in the same slot,
+// indicating the independent value the relay adds over relays sharing the same builders
+type BlockUniqueness struct {
+	Bids uint `json:"bids"`
+	// Bids whose block was not offered by any other relay
+	UniqueBids       uint    `json:"unique_bids"`
+	UniqueBlockShare float64 `json:"unique_block_share"`
+}
+
+type BlockUniquenessRecord = map[types.PublicKey]*BlockUniqueness
+
+func computeBlockUniqueness(relays []types.PublicKey, values []types.BidValue) BlockUniquenessRecord {
+	// NOTE: a block hash commits to the slot so blocks need not be grouped by slot
+	blockRelays := make(map[types.Hash]map[types.PublicKey]struct{})
+	for _, value := range values {
+		if _, ok := blockRelays[value.BlockHash]; !ok {
+			blockRelays[value.BlockHash] = make(map[types.PublicKey]struct{})
+		}
+		blockRelays[value.BlockHash][value.Context.RelayPublicKey] = struct{}{}
+	}
+
+	record := make(BlockUniquenessRecord)
+	for _, relay := range relays {
+		record[relay] = &BlockUniqueness{}
+	}
+	for _, value := range values {
+		uniqueness, ok := record[value.Context.RelayPublicKey]
+		if !ok {
+			continue
+		}
+		uniqueness.Bids += 1
+		if len(blockRelays[value.BlockHash]) == 1 {
+			uniqueness.UniqueBids += 1
+		}
+	}
+	for _, uniqueness := range record {
+		if uniqueness.Bids > 0 {
+			uniqueness.UniqueBlockShare = float64(uniqueness.UniqueBids) / float64(uniqueness.Bids)
+		}
+	}
+	return record
+}
+
+// `GetBlockUniqueness` computes the share of each relay's bids with a block unique to the relay over the inclusive slot range
+func (r *Reporter) GetBlockUniqueness(ctx context.Context, startSlot, endSlot types.Slot) (BlockUniquenessRecord, error) {
+	return shareReport(ctx, r, "block_uniqueness", startSlot, endSlot, func(ctx context.Context) (BlockUniquenessRecord, error) {
+		values, err := r.store.GetSlotBidValues(ctx, startSlot, endSlot)
+		if err != nil {
+			return nil, err
+		}
+		return computeBlockUniqueness(r.Relays(), values), nil
+	})
+}
@@ -0,0 +1,38 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestComputeBlockUniqueness(t *testing.T) {
+	relayA := types.PublicKey{0x01}
+	relayB := types.PublicKey{0x02}
+	relayC := types.PublicKey{0x03}
+
+	bidValue := func(slot types.Slot, relay types.PublicKey, blockHash byte) types.BidValue {
+		return types.BidValue{
+			Context:   types.BidContext{Slot: slot, RelayPublicKey: relay},
+			BlockHash: types.Hash{blockHash},
+		}
+	}
+
+	// NOTE: relays a and b share a builder in slot 10
+	record := computeBlockUniqueness([]types.PublicKey{relayA, relayB, relayC}, []types.BidValue{
+		bidValue(10, relayA, 0x01),
+		bidValue(10, relayB, 0x01),
+		bidValue(10, relayC, 0x02),
+		bidValue(11, relayA, 0x03),
+		bidValue(11, relayB, 0x04),
+	})
+
+	a := record[relayA]
+	if a.Bids != 2 || a.UniqueBids != 1 || a.UniqueBlockShare != 0.5 {
+		t.Fatalf("unexpected block uniqueness for relay a: %+v", a)
+	}
+	c := record[relayC]
+	if c.Bids != 1 || c.UniqueBids != 1 || c.UniqueBlockShare != 1 {
+		t.Fatalf("unexpected block uniqueness for relay c: %+v", c)
+	}
+}
@@ -0,0 +1,76 @@
+package reporter
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+type Uptime struct {
+	// Number of slots in the range the relay was found up
+	UpSlots uint64 `json:"up_slots"`
+	// Number of slots in the range the relay was found down
+	DownSlots uint64 `json:"down_slots"`
+	// Share of the probed slots in the range the relay was up, where `0` indicates no probes
+	Uptime float64 `json:"uptime"`
+	// Intervals of the relay's status, clipped to the range
+	Intervals []types.RelayStatusInterval `json:"intervals"`
+}
+
+type UptimeRecord = map[types.PublicKey]*Uptime
+
+// `computeUptime` summarizes the status `intervals` of a relay clipped to the inclusive slot range
+func computeUptime(intervals []types.RelayStatusInterval, startSlot, endSlot types.Slot) *Uptime {
+	uptime := &Uptime{
+		Intervals: []types.RelayStatusInterval{},
+	}
+	for _, interval := range intervals {
+		if interval.EndSlot < startSlot || interval.StartSlot > endSlot {
+			continue
+		}
+		if interval.StartSlot < startSlot {
+			interval.StartSlot = startSlot
+		}
+		if interval.EndSlot > endSlot {
+			interval.EndSlot = endSlot
+		}
+		slots := interval.EndSlot - interval.StartSlot + 1
+		if interval.Up {
+			uptime.UpSlots += slots
+		} else {
+			uptime.DownSlots += slots
+		}
+		uptime.Intervals = append(uptime.Intervals, interval)
+	}
+	if total := uptime.UpSlots + uptime.DownSlots; total > 0 {
+		uptime.Uptime = float64(uptime.UpSlots) / float64(total)
+	}
+	return uptime
+}
+
+// `GetUptime` reports the status of the relay found by probing it over the inclusive slot range
+func (r *Reporter) GetUptime(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (*Uptime, error) {
+	intervals, err := r.store.GetRelayStatusIntervals(ctx, relay, startSlot, endSlot)
+	if err != nil {
+		return nil, err
+	}
+	return computeUptime(intervals, startSlot, endSlot), nil
+}
+
+// `uptimeScorer` scores the share of probed slots the relay was up
+type uptimeScorer struct {
+	store store.Storer
+}
+
+func newUptimeScorer(config *StrategyConfig, store store.Storer) Scorer {
+	return &uptimeScorer{store: store}
+}
+
+func (s *uptimeScorer) Score(ctx context.Context, relay *types.PublicKey, startSlot, endSlot types.Slot) (float64, error) {
+	intervals, err := s.store.GetRelayStatusIntervals(ctx, relay, startSlot, endSlot)
+	if err != nil {
+		return 0, err
+	}
+	return computeUptime(intervals, startSlot, endSlot).Uptime, nil
+}
@@ -0,0 +1,43 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestComputeUptime(t *testing.T) {
+	intervals := []types.RelayStatusInterval{
+		{Up: true, StartSlot: 10, EndSlot: 19},
+		{Up: false, StartSlot: 20, EndSlot: 24},
+		{Up: true, StartSlot: 25, EndSlot: 40},
+	}
+	for _, tc := range []struct {
+		startSlot types.Slot
+		endSlot   types.Slot
+		up        uint64
+		down      uint64
+		uptime    float64
+		intervals int
+	}{
+		{startSlot: 0, endSlot: 9, up: 0, down: 0, uptime: 0, intervals: 0},
+		{startSlot: 10, endSlot: 19, up: 10, down: 0, uptime: 1, intervals: 1},
+		{startSlot: 15, endSlot: 24, up: 5, down: 5, uptime: 0.5, intervals: 2},
+		{startSlot: 0, endSlot: 100, up: 26, down: 5, uptime: 26.0 / 31.0, intervals: 3},
+	} {
+		uptime := computeUptime(intervals, tc.startSlot, tc.endSlot)
+		if uptime.UpSlots != tc.up || uptime.DownSlots != tc.down {
+			t.Errorf("range [%d, %d]: expected %d up and %d down slots but got %d and %d", tc.startSlot, tc.endSlot, tc.up, tc.down, uptime.UpSlots, uptime.DownSlots)
+		}
+		if uptime.Uptime != tc.uptime {
+			t.Errorf("range [%d, %d]: expected uptime %f but got %f", tc.startSlot, tc.endSlot, tc.uptime, uptime.Uptime)
+		}
+		if len(uptime.Intervals) != tc.intervals {
+			t.Errorf("range [%d, %d]: expected %d intervals but got %d", tc.startSlot, tc.endSlot, tc.intervals, len(uptime.Intervals))
+		}
+	}
+	clipped := computeUptime(intervals, 15, 22)
+	if clipped.Intervals[0].StartSlot != 15 || clipped.Intervals[1].EndSlot != 22 {
+		t.Errorf("expected intervals clipped to the range but got %+v", clipped.Intervals)
+	}
+}
@@ -0,0 +1,88 @@
+package reporter
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `WinRate` summarizes the auctions won by a relay, out of the auctions observed by the monitor with a canonical winning bid,
+// and the share of the relay's observed bids which a proposer accepted
+type WinRate struct {
+	Auctions uint    `json:"auctions"`
+	Wins     uint    `json:"wins"`
+	WinRate  float64 `json:"win_rate"`
+	// Total value of the winning bids, in wei
+	DeliveredValue string  `json:"delivered_value"`
+	Bids           uint    `json:"bids"`
+	Accepted       uint    `json:"accepted"`
+	AcceptanceRate float64 `json:"acceptance_rate"`
+}
+
+type WinRateRecord = map[types.PublicKey]*WinRate
+
+func computeWinRates(relays []types.PublicKey, winningBids []types.WinningBid, bids map[types.PublicKey]uint, acceptedBids []types.BidContext) WinRateRecord {
+	auctions := make(map[types.Slot]struct{})
+	values := make(map[types.PublicKey]*big.Int)
+	record := make(WinRateRecord)
+	for _, relay := range relays {
+		record[relay] = &WinRate{}
+		values[relay] = new(big.Int)
+	}
+
+	for _, winningBid := range winningBids {
+		auctions[winningBid.Context.Slot] = struct{}{}
+		winRate, ok := record[winningBid.Context.RelayPublicKey]
+		if !ok {
+			continue
+		}
+		winRate.Wins += 1
+		value := values[winningBid.Context.RelayPublicKey]
+		value.Add(value, winningBid.Value.BigInt())
+	}
+
+	for _, bidCtx := range acceptedBids {
+		if winRate, ok := record[bidCtx.RelayPublicKey]; ok {
+			winRate.Accepted += 1
+		}
+	}
+
+	for relay, winRate := range record {
+		winRate.Auctions = uint(len(auctions))
+		if winRate.Auctions > 0 {
+			winRate.WinRate = float64(winRate.Wins) / float64(winRate.Auctions)
+		}
+		winRate.DeliveredValue = values[relay].String()
+		winRate.Bids = bids[relay]
+		if winRate.Bids > 0 {
+			winRate.AcceptanceRate = float64(winRate.Accepted) / float64(winRate.Bids)
+		}
+	}
+	return record
+}
+
+// `GetWinRates` computes the win rate, delivered value and acceptance rate of each relay over the inclusive slot range
+func (r *Reporter) GetWinRates(ctx context.Context, startSlot, endSlot types.Slot) (WinRateRecord, error) {
+	return shareReport(ctx, r, "win_rates", startSlot, endSlot, func(ctx context.Context) (WinRateRecord, error) {
+		winningBids, err := r.store.GetWinningBids(ctx, startSlot, endSlot)
+		if err != nil {
+			return nil, err
+		}
+		acceptedBids, err := r.store.GetAcceptedBids(ctx, startSlot, endSlot)
+		if err != nil {
+			return nil, err
+		}
+		relays := r.Relays()
+		bids := make(map[types.PublicKey]uint)
+		for _, relay := range relays {
+			relay := relay
+			values, err := r.store.GetBidValues(ctx, &relay, startSlot, endSlot)
+			if err != nil {
+				return nil, err
+			}
+			bids[relay] = uint(len(values))
+		}
+		return computeWinRates(relays, winningBids, bids, acceptedBids), nil
+	})
+}
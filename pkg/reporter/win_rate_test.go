@@ -0,0 +1,54 @@
+package reporter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestComputeWinRates(t *testing.T) {
+	relayA := types.PublicKey{0x01}
+	relayB := types.PublicKey{0x02}
+	unknownRelay := types.PublicKey{0x03}
+
+	winningBid := func(slot types.Slot, relay types.PublicKey, value int64) types.WinningBid {
+		winningBid := types.WinningBid{
+			Context: types.BidContext{
+				Slot:           slot,
+				RelayPublicKey: relay,
+			},
+		}
+		err := winningBid.Value.FromBig(big.NewInt(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return winningBid
+	}
+
+	// NOTE: the payload for slot 11 was delivered by two relays
+	record := computeWinRates([]types.PublicKey{relayA, relayB}, []types.WinningBid{
+		winningBid(10, relayA, 5),
+		winningBid(11, relayA, 7),
+		winningBid(11, relayB, 7),
+		winningBid(12, unknownRelay, 3),
+	}, map[types.PublicKey]uint{
+		relayA: 4,
+	}, []types.BidContext{
+		{Slot: 10, RelayPublicKey: relayA},
+		{Slot: 11, RelayPublicKey: relayA},
+		{Slot: 12, RelayPublicKey: unknownRelay},
+	})
+
+	a := record[relayA]
+	if a.Auctions != 3 || a.Wins != 2 || a.DeliveredValue != "12" || a.Accepted != 2 || a.AcceptanceRate != 0.5 {
+		t.Fatalf("unexpected win rate for relay a: %+v", a)
+	}
+	b := record[relayB]
+	if b.Auctions != 3 || b.Wins != 1 || b.DeliveredValue != "7" || b.Accepted != 0 || b.AcceptanceRate != 0 {
+		t.Fatalf("unexpected win rate for relay b: %+v", b)
+	}
+	if _, ok := record[unknownRelay]; ok {
+		t.Fatal("unexpected win rate for unconfigured relay")
+	}
+}
@@ -0,0 +1,42 @@
+package reports
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// Reports cover each UTC day
+	DailySchedule = "daily"
+	// Reports cover each week, starting Monday 00:00 UTC
+	WeeklySchedule = "weekly"
+
+	webhookTimeout = 10 * time.Second
+)
+
+type Config struct {
+	// Schedules to generate reports on, any of `daily` and `weekly`, defaulting to both
+	Schedules []string `yaml:"schedules"`
+	// If given, each report is written to this directory as JSON and Markdown
+	Directory string `yaml:"directory"`
+	// If given, each report is posted as JSON to this URL
+	Webhook string `yaml:"webhook"`
+}
+
+func (c *Config) schedules() []string {
+	if c == nil || len(c.Schedules) == 0 {
+		return []string{DailySchedule, WeeklySchedule}
+	}
+	return c.Schedules
+}
+
+func (c *Config) Validate() error {
+	for _, schedule := range c.schedules() {
+		switch schedule {
+		case DailySchedule, WeeklySchedule:
+		default:
+			return fmt.Errorf("unknown report schedule %s, expected one of %s or %s", schedule, DailySchedule, WeeklySchedule)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Package reports generates canonical periodic summaries of the faults and scores of the monitored relays
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"go.uber.org/zap"
+)
+
+var markdownTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(value float64) string {
+		return fmt.Sprintf("%.1f%%", 100*value)
+	},
+	"delta": func(current, previous float64) string {
+		return fmt.Sprintf("%+.3f", current-previous)
+	},
+	"title": func(schedule string) string {
+		if schedule == "" {
+			return schedule
+		}
+		return strings.ToUpper(schedule[:1]) + schedule[1:]
+	},
+}).Parse(`# {{ title .Schedule }} relay report for {{ .Network }}
+
+{{ .Start.UTC.Format "2006-01-02 15:04" }} to {{ .End.UTC.Format "2006-01-02 15:04" }} UTC, slots {{ .StartSlot }} to {{ .EndSlot }}.
+
+| Relay | Analyzed bids | Faults | Score | Change | Availability |
+| --- | --- | --- | --- | --- | --- |
+{{ range .Relays }}| ` + "`{{ .RelayPublicKey }}`" + ` | {{ .AnalyzedBids }} | {{ .TotalFaults }} | {{ printf "%.3f" .Score }} | {{ delta .Score .PreviousScore }} | {{ percent .Availability }} |
+{{ end }}{{ range .Relays }}{{ if .TotalFaults }}
+## ` + "`{{ .RelayPublicKey }}`" + `
+{{ range $category, $count := .Faults }}
+- {{ $category }}: {{ $count }}{{ end }}
+{{ if .Annotations }}
+Annotations of the operator:
+{{ range .Annotations }}
+- slot {{ .Context.Slot }} ({{ .Kind }}): {{ .Message }}{{ end }}
+{{ end }}{{ end }}{{ end }}`))
+
+// `Report` summarizes the faults and scores of each relay over the period of a schedule
+type Report struct {
+	Schedule string    `json:"schedule"`
+	Network  string    `json:"network"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	*reporter.Digest
+}
+
+// `Name` identifies the report by its schedule and the date its period starts, e.g. `daily-2023-01-02`
+func (r *Report) Name() string {
+	return fmt.Sprintf("%s-%s", r.Schedule, r.Start.UTC().Format("2006-01-02"))
+}
+
+// `RenderMarkdown` formats the report as Markdown
+func RenderMarkdown(report *Report) (string, error) {
+	var b strings.Builder
+	err := markdownTemplate.Execute(&b, report)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// `period` returns the bounds of the latest period of `schedule` to end at or before `now`
+func period(schedule string, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if schedule == WeeklySchedule {
+		// NOTE: weeks start on Monday
+		daysSinceMonday := (int(end.Weekday()) + 6) % 7
+		end = end.AddDate(0, 0, -daysSinceMonday)
+		return end.AddDate(0, 0, -7), end
+	}
+	return end.AddDate(0, 0, -1), end
+}
+
+// `nextRun` returns the time the period of `schedule` following the one containing `now` starts
+func nextRun(schedule string, now time.Time) time.Time {
+	_, end := period(schedule, now)
+	if schedule == WeeklySchedule {
+		return end.AddDate(0, 0, 7)
+	}
+	return end.AddDate(0, 0, 1)
+}
+
+// `Generator` renders a report at the end of the period of each schedule and publishes it
+type Generator struct {
+	config  *Config
+	logger  *zap.Logger
+	network string
+	client  http.Client
+
+	reporter *reporter.Reporter
+	clock    *consensus.Clock
+}
+
+func New(config *Config, zapLogger *zap.Logger, network string, reporter *reporter.Reporter, clock *consensus.Clock) *Generator {
+	return &Generator{
+		config:  config,
+		logger:  zapLogger,
+		network: network,
+		client: http.Client{
+			Timeout: webhookTimeout,
+		},
+		reporter: reporter,
+		clock:    clock,
+	}
+}
+
+// `Generate` computes the report of the latest period of `schedule` to end at or before `now`
+func (g *Generator) Generate(ctx context.Context, schedule string, now time.Time) (*Report, error) {
+	start, end := period(schedule, now)
+	startSlot := g.clock.CurrentSlot(start.Unix())
+	endSlot := g.clock.CurrentSlot(end.Unix())
+	if endSlot > startSlot {
+		// NOTE: the slot starting at `end` belongs to the next period
+		endSlot -= 1
+	}
+	digest, err := g.reporter.GetDigest(ctx, startSlot, endSlot)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute report: %v", err)
+	}
+	return &Report{
+		Schedule: schedule,
+		Network:  g.network,
+		Start:    start,
+		End:      end,
+		Digest:   digest,
+	}, nil
+}
+
+func (g *Generator) write(report *Report) error {
+	err := os.MkdirAll(g.config.Directory, 0o755)
+	if err != nil {
+		return err
+	}
+	encoded, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(g.config.Directory, report.Name())
+	err = os.WriteFile(path+".json", encoded, 0o644)
+	if err != nil {
+		return err
+	}
+	markdown, err := RenderMarkdown(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path+".md", []byte(markdown), 0o644)
+}
+
+func (g *Generator) post(ctx context.Context, report *Report) error {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.config.Webhook, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with HTTP status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (g *Generator) publish(ctx context.Context, schedule string, now time.Time) {
+	logger := g.logger.Sugar()
+
+	report, err := g.Generate(ctx, schedule, now)
+	if err != nil {
+		logger.Warnw("could not generate report", "error", err, "schedule", schedule)
+		return
+	}
+
+	if g.config.Directory == "" && g.config.Webhook == "" {
+		body, err := RenderMarkdown(report)
+		if err != nil {
+			logger.Warnw("could not render report", "error", err, "report", report.Name())
+			return
+		}
+		logger.Infof("report of the relay monitor:\n%s", body)
+		return
+	}
+	if g.config.Directory != "" {
+		err = g.write(report)
+		if err != nil {
+			logger.Warnw("could not write report", "error", err, "report", report.Name())
+		} else {
+			logger.Infow("wrote report", "report", report.Name(), "directory", g.config.Directory)
+		}
+	}
+	if g.config.Webhook != "" {
+		err = g.post(ctx, report)
+		if err != nil {
+			logger.Warnw("could not post report to webhook", "error", err, "report", report.Name())
+		} else {
+			logger.Infow("posted report to webhook", "report", report.Name())
+		}
+	}
+}
+
+func (g *Generator) Run(ctx context.Context) {
+	schedules := g.config.schedules()
+	next := make(map[string]time.Time)
+	for _, schedule := range schedules {
+		next[schedule] = nextRun(schedule, time.Now())
+	}
+	for {
+		var wake time.Time
+		for _, schedule := range schedules {
+			if wake.IsZero() || next[schedule].Before(wake) {
+				wake = next[schedule]
+			}
+		}
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case now := <-timer.C:
+			for _, schedule := range schedules {
+				if !now.Before(next[schedule]) {
+					g.publish(ctx, schedule, now)
+					next[schedule] = nextRun(schedule, now)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestPeriod(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2023, 3, 8, 13, 30, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		schedule string
+		start    time.Time
+		end      time.Time
+		next     time.Time
+	}{
+		{
+			schedule: DailySchedule,
+			start:    time.Date(2023, 3, 7, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2023, 3, 8, 0, 0, 0, 0, time.UTC),
+			next:     time.Date(2023, 3, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			schedule: WeeklySchedule,
+			start:    time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC),
+			next:     time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		start, end := period(tc.schedule, now)
+		if !start.Equal(tc.start) || !end.Equal(tc.end) {
+			t.Errorf("%s: expected period from %s to %s but got %s to %s", tc.schedule, tc.start, tc.end, start, end)
+		}
+		next := nextRun(tc.schedule, now)
+		if !next.Equal(tc.next) {
+			t.Errorf("%s: expected next run at %s but got %s", tc.schedule, tc.next, next)
+		}
+		// a run at the boundary reports the period which just ended
+		start, _ = period(tc.schedule, next)
+		if !start.Equal(tc.end) {
+			t.Errorf("%s: expected period at %s to start at %s but got %s", tc.schedule, next, tc.end, start)
+		}
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	report := &Report{
+		Schedule: DailySchedule,
+		Network:  "mainnet",
+		Start:    time.Date(2023, 3, 7, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2023, 3, 8, 0, 0, 0, 0, time.UTC),
+		Digest: &reporter.Digest{
+			StartSlot: 100,
+			EndSlot:   7299,
+			Relays: []reporter.RelayDigest{
+				{
+					RelayPublicKey: types.PublicKey{0x01},
+					AnalyzedBids:   40,
+					TotalFaults:    2,
+					Faults:         map[string]uint{"consensus_invalid": 2},
+					Score:          0.95,
+					PreviousScore:  0.99,
+					Availability:   0.8,
+					Annotations: []types.FaultAnnotation{
+						{
+							Context: types.BidContext{Slot: 200},
+							Kind:    types.DisputeAnnotationKind,
+							Message: "beacon node of monitor was syncing",
+						},
+					},
+				},
+				{
+					RelayPublicKey: types.PublicKey{0x02},
+					AnalyzedBids:   50,
+					Score:          1,
+					PreviousScore:  1,
+					Availability:   1,
+				},
+			},
+		},
+	}
+	if report.Name() != "daily-2023-03-07" {
+		t.Fatalf("unexpected report name %s", report.Name())
+	}
+	body, err := RenderMarkdown(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"# Daily relay report for mainnet",
+		"2023-03-07 00:00 to 2023-03-08 00:00 UTC, slots 100 to 7299.",
+		"| 40 | 2 | 0.950 | -0.040 | 80.0% |",
+		"| 50 | 0 | 1.000 | +0.000 | 100.0% |",
+		"- consensus_invalid: 2",
+		"- slot 200 (dispute): beacon node of monitor was syncing",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Fatalf("expected report to contain %q", expected)
+		}
+	}
+	if strings.Count(body, "## ") != 1 {
+		t.Fatalf("expected only relays with faults to be detailed:\n%s", body)
+	}
+}
@@ -0,0 +1,1463 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: pkg/rpc/pb/relay_monitor.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetFaultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartEpoch *uint64 `protobuf:"varint,1,opt,name=start_epoch,json=startEpoch,proto3,oneof" json:"start_epoch,omitempty"`
+	EndEpoch   *uint64 `protobuf:"varint,2,opt,name=end_epoch,json=endEpoch,proto3,oneof" json:"end_epoch,omitempty"`
+}
+
+func (x *GetFaultsRequest) Reset() {
+	*x = GetFaultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFaultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFaultsRequest) ProtoMessage() {}
+
+func (x *GetFaultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFaultsRequest.ProtoReflect.Descriptor instead.
+func (*GetFaultsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetFaultsRequest) GetStartEpoch() uint64 {
+	if x != nil && x.StartEpoch != nil {
+		return *x.StartEpoch
+	}
+	return 0
+}
+
+func (x *GetFaultsRequest) GetEndEpoch() uint64 {
+	if x != nil && x.EndEpoch != nil {
+		return *x.EndEpoch
+	}
+	return 0
+}
+
+type FaultStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalBids                uint64  `protobuf:"varint,1,opt,name=total_bids,json=totalBids,proto3" json:"total_bids,omitempty"`
+	ConsensusInvalidBids     uint64  `protobuf:"varint,2,opt,name=consensus_invalid_bids,json=consensusInvalidBids,proto3" json:"consensus_invalid_bids,omitempty"`
+	IgnoredPreferencesBids   uint64  `protobuf:"varint,3,opt,name=ignored_preferences_bids,json=ignoredPreferencesBids,proto3" json:"ignored_preferences_bids,omitempty"`
+	SkippedByPolicyBids      uint64  `protobuf:"varint,4,opt,name=skipped_by_policy_bids,json=skippedByPolicyBids,proto3" json:"skipped_by_policy_bids,omitempty"`
+	LateBids                 uint64  `protobuf:"varint,5,opt,name=late_bids,json=lateBids,proto3" json:"late_bids,omitempty"`
+	NoBids                   uint64  `protobuf:"varint,6,opt,name=no_bids,json=noBids,proto3" json:"no_bids,omitempty"`
+	PaymentInvalidBids       uint64  `protobuf:"varint,7,opt,name=payment_invalid_bids,json=paymentInvalidBids,proto3" json:"payment_invalid_bids,omitempty"`
+	MalformedPayloads        uint64  `protobuf:"varint,8,opt,name=malformed_payloads,json=malformedPayloads,proto3" json:"malformed_payloads,omitempty"`
+	ConsensusInvalidPayloads uint64  `protobuf:"varint,9,opt,name=consensus_invalid_payloads,json=consensusInvalidPayloads,proto3" json:"consensus_invalid_payloads,omitempty"`
+	UnavailablePayloads      uint64  `protobuf:"varint,10,opt,name=unavailable_payloads,json=unavailablePayloads,proto3" json:"unavailable_payloads,omitempty"`
+	RegistrationIgnored      uint64  `protobuf:"varint,11,opt,name=registration_ignored,json=registrationIgnored,proto3" json:"registration_ignored,omitempty"`
+	MissedSlots              uint64  `protobuf:"varint,12,opt,name=missed_slots,json=missedSlots,proto3" json:"missed_slots,omitempty"`
+	BidValueDivergences      uint64  `protobuf:"varint,13,opt,name=bid_value_divergences,json=bidValueDivergences,proto3" json:"bid_value_divergences,omitempty"`
+	ResampledBids            uint64  `protobuf:"varint,14,opt,name=resampled_bids,json=resampledBids,proto3" json:"resampled_bids,omitempty"`
+	Cancellations            uint64  `protobuf:"varint,15,opt,name=cancellations,proto3" json:"cancellations,omitempty"`
+	CancellationRate         float64 `protobuf:"fixed64,16,opt,name=cancellation_rate,json=cancellationRate,proto3" json:"cancellation_rate,omitempty"`
+	ValueOverstatedBids      uint64  `protobuf:"varint,17,opt,name=value_overstated_bids,json=valueOverstatedBids,proto3" json:"value_overstated_bids,omitempty"`
+	StaleParentBids          uint64  `protobuf:"varint,18,opt,name=stale_parent_bids,json=staleParentBids,proto3" json:"stale_parent_bids,omitempty"`
+	HeadLagSlots             uint64  `protobuf:"varint,19,opt,name=head_lag_slots,json=headLagSlots,proto3" json:"head_lag_slots,omitempty"`
+	StaleParentRate          float64 `protobuf:"fixed64,20,opt,name=stale_parent_rate,json=staleParentRate,proto3" json:"stale_parent_rate,omitempty"`
+	AverageHeadLag           float64 `protobuf:"fixed64,21,opt,name=average_head_lag,json=averageHeadLag,proto3" json:"average_head_lag,omitempty"`
+	StaleHead                bool    `protobuf:"varint,22,opt,name=stale_head,json=staleHead,proto3" json:"stale_head,omitempty"`
+	CensoredTransactions     uint64  `protobuf:"varint,23,opt,name=censored_transactions,json=censoredTransactions,proto3" json:"censored_transactions,omitempty"`
+}
+
+func (x *FaultStats) Reset() {
+	*x = FaultStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FaultStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultStats) ProtoMessage() {}
+
+func (x *FaultStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultStats.ProtoReflect.Descriptor instead.
+func (*FaultStats) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FaultStats) GetTotalBids() uint64 {
+	if x != nil {
+		return x.TotalBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetConsensusInvalidBids() uint64 {
+	if x != nil {
+		return x.ConsensusInvalidBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetIgnoredPreferencesBids() uint64 {
+	if x != nil {
+		return x.IgnoredPreferencesBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetSkippedByPolicyBids() uint64 {
+	if x != nil {
+		return x.SkippedByPolicyBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetLateBids() uint64 {
+	if x != nil {
+		return x.LateBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetNoBids() uint64 {
+	if x != nil {
+		return x.NoBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetPaymentInvalidBids() uint64 {
+	if x != nil {
+		return x.PaymentInvalidBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetMalformedPayloads() uint64 {
+	if x != nil {
+		return x.MalformedPayloads
+	}
+	return 0
+}
+
+func (x *FaultStats) GetConsensusInvalidPayloads() uint64 {
+	if x != nil {
+		return x.ConsensusInvalidPayloads
+	}
+	return 0
+}
+
+func (x *FaultStats) GetUnavailablePayloads() uint64 {
+	if x != nil {
+		return x.UnavailablePayloads
+	}
+	return 0
+}
+
+func (x *FaultStats) GetRegistrationIgnored() uint64 {
+	if x != nil {
+		return x.RegistrationIgnored
+	}
+	return 0
+}
+
+func (x *FaultStats) GetMissedSlots() uint64 {
+	if x != nil {
+		return x.MissedSlots
+	}
+	return 0
+}
+
+func (x *FaultStats) GetBidValueDivergences() uint64 {
+	if x != nil {
+		return x.BidValueDivergences
+	}
+	return 0
+}
+
+func (x *FaultStats) GetResampledBids() uint64 {
+	if x != nil {
+		return x.ResampledBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetCancellations() uint64 {
+	if x != nil {
+		return x.Cancellations
+	}
+	return 0
+}
+
+func (x *FaultStats) GetCancellationRate() float64 {
+	if x != nil {
+		return x.CancellationRate
+	}
+	return 0
+}
+
+func (x *FaultStats) GetValueOverstatedBids() uint64 {
+	if x != nil {
+		return x.ValueOverstatedBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetStaleParentBids() uint64 {
+	if x != nil {
+		return x.StaleParentBids
+	}
+	return 0
+}
+
+func (x *FaultStats) GetHeadLagSlots() uint64 {
+	if x != nil {
+		return x.HeadLagSlots
+	}
+	return 0
+}
+
+func (x *FaultStats) GetStaleParentRate() float64 {
+	if x != nil {
+		return x.StaleParentRate
+	}
+	return 0
+}
+
+func (x *FaultStats) GetAverageHeadLag() float64 {
+	if x != nil {
+		return x.AverageHeadLag
+	}
+	return 0
+}
+
+func (x *FaultStats) GetStaleHead() bool {
+	if x != nil {
+		return x.StaleHead
+	}
+	return false
+}
+
+func (x *FaultStats) GetCensoredTransactions() uint64 {
+	if x != nil {
+		return x.CensoredTransactions
+	}
+	return 0
+}
+
+type RelayFaults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RelayPublicKey string      `protobuf:"bytes,1,opt,name=relay_public_key,json=relayPublicKey,proto3" json:"relay_public_key,omitempty"`
+	Endpoint       string      `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Stats          *FaultStats `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *RelayFaults) Reset() {
+	*x = RelayFaults{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelayFaults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelayFaults) ProtoMessage() {}
+
+func (x *RelayFaults) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelayFaults.ProtoReflect.Descriptor instead.
+func (*RelayFaults) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RelayFaults) GetRelayPublicKey() string {
+	if x != nil {
+		return x.RelayPublicKey
+	}
+	return ""
+}
+
+func (x *RelayFaults) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *RelayFaults) GetStats() *FaultStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetFaultsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartEpoch uint64         `protobuf:"varint,1,opt,name=start_epoch,json=startEpoch,proto3" json:"start_epoch,omitempty"`
+	EndEpoch   uint64         `protobuf:"varint,2,opt,name=end_epoch,json=endEpoch,proto3" json:"end_epoch,omitempty"`
+	Relays     []*RelayFaults `protobuf:"bytes,3,rep,name=relays,proto3" json:"relays,omitempty"`
+}
+
+func (x *GetFaultsResponse) Reset() {
+	*x = GetFaultsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFaultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFaultsResponse) ProtoMessage() {}
+
+func (x *GetFaultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFaultsResponse.ProtoReflect.Descriptor instead.
+func (*GetFaultsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetFaultsResponse) GetStartEpoch() uint64 {
+	if x != nil {
+		return x.StartEpoch
+	}
+	return 0
+}
+
+func (x *GetFaultsResponse) GetEndEpoch() uint64 {
+	if x != nil {
+		return x.EndEpoch
+	}
+	return 0
+}
+
+func (x *GetFaultsResponse) GetRelays() []*RelayFaults {
+	if x != nil {
+		return x.Relays
+	}
+	return nil
+}
+
+type GetBidAnalysesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RelayPublicKey string  `protobuf:"bytes,1,opt,name=relay_public_key,json=relayPublicKey,proto3" json:"relay_public_key,omitempty"`
+	StartSlot      *uint64 `protobuf:"varint,2,opt,name=start_slot,json=startSlot,proto3,oneof" json:"start_slot,omitempty"`
+	EndSlot        *uint64 `protobuf:"varint,3,opt,name=end_slot,json=endSlot,proto3,oneof" json:"end_slot,omitempty"`
+}
+
+func (x *GetBidAnalysesRequest) Reset() {
+	*x = GetBidAnalysesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBidAnalysesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBidAnalysesRequest) ProtoMessage() {}
+
+func (x *GetBidAnalysesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBidAnalysesRequest.ProtoReflect.Descriptor instead.
+func (*GetBidAnalysesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetBidAnalysesRequest) GetRelayPublicKey() string {
+	if x != nil {
+		return x.RelayPublicKey
+	}
+	return ""
+}
+
+func (x *GetBidAnalysesRequest) GetStartSlot() uint64 {
+	if x != nil && x.StartSlot != nil {
+		return *x.StartSlot
+	}
+	return 0
+}
+
+func (x *GetBidAnalysesRequest) GetEndSlot() uint64 {
+	if x != nil && x.EndSlot != nil {
+		return *x.EndSlot
+	}
+	return 0
+}
+
+type BidAnalysis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Slot              uint64 `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	ParentHash        string `protobuf:"bytes,2,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	ProposerPublicKey string `protobuf:"bytes,3,opt,name=proposer_public_key,json=proposerPublicKey,proto3" json:"proposer_public_key,omitempty"`
+	RelayPublicKey    string `protobuf:"bytes,4,opt,name=relay_public_key,json=relayPublicKey,proto3" json:"relay_public_key,omitempty"`
+	// An empty `category` indicates a valid bid
+	Category        string   `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	Reason          string   `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	SkippedByPolicy []string `protobuf:"bytes,7,rep,name=skipped_by_policy,json=skippedByPolicy,proto3" json:"skipped_by_policy,omitempty"`
+}
+
+func (x *BidAnalysis) Reset() {
+	*x = BidAnalysis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BidAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BidAnalysis) ProtoMessage() {}
+
+func (x *BidAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BidAnalysis.ProtoReflect.Descriptor instead.
+func (*BidAnalysis) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BidAnalysis) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *BidAnalysis) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+	return ""
+}
+
+func (x *BidAnalysis) GetProposerPublicKey() string {
+	if x != nil {
+		return x.ProposerPublicKey
+	}
+	return ""
+}
+
+func (x *BidAnalysis) GetRelayPublicKey() string {
+	if x != nil {
+		return x.RelayPublicKey
+	}
+	return ""
+}
+
+func (x *BidAnalysis) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *BidAnalysis) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BidAnalysis) GetSkippedByPolicy() []string {
+	if x != nil {
+		return x.SkippedByPolicy
+	}
+	return nil
+}
+
+type GetBidAnalysesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartSlot uint64         `protobuf:"varint,1,opt,name=start_slot,json=startSlot,proto3" json:"start_slot,omitempty"`
+	EndSlot   uint64         `protobuf:"varint,2,opt,name=end_slot,json=endSlot,proto3" json:"end_slot,omitempty"`
+	Analyses  []*BidAnalysis `protobuf:"bytes,3,rep,name=analyses,proto3" json:"analyses,omitempty"`
+}
+
+func (x *GetBidAnalysesResponse) Reset() {
+	*x = GetBidAnalysesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBidAnalysesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBidAnalysesResponse) ProtoMessage() {}
+
+func (x *GetBidAnalysesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBidAnalysesResponse.ProtoReflect.Descriptor instead.
+func (*GetBidAnalysesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBidAnalysesResponse) GetStartSlot() uint64 {
+	if x != nil {
+		return x.StartSlot
+	}
+	return 0
+}
+
+func (x *GetBidAnalysesResponse) GetEndSlot() uint64 {
+	if x != nil {
+		return x.EndSlot
+	}
+	return 0
+}
+
+func (x *GetBidAnalysesResponse) GetAnalyses() []*BidAnalysis {
+	if x != nil {
+		return x.Analyses
+	}
+	return nil
+}
+
+type GetScoresRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// All relays are scored if unset
+	RelayPublicKey *string `protobuf:"bytes,1,opt,name=relay_public_key,json=relayPublicKey,proto3,oneof" json:"relay_public_key,omitempty"`
+	StartSlot      *uint64 `protobuf:"varint,2,opt,name=start_slot,json=startSlot,proto3,oneof" json:"start_slot,omitempty"`
+	EndSlot        *uint64 `protobuf:"varint,3,opt,name=end_slot,json=endSlot,proto3,oneof" json:"end_slot,omitempty"`
+}
+
+func (x *GetScoresRequest) Reset() {
+	*x = GetScoresRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetScoresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScoresRequest) ProtoMessage() {}
+
+func (x *GetScoresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScoresRequest.ProtoReflect.Descriptor instead.
+func (*GetScoresRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetScoresRequest) GetRelayPublicKey() string {
+	if x != nil && x.RelayPublicKey != nil {
+		return *x.RelayPublicKey
+	}
+	return ""
+}
+
+func (x *GetScoresRequest) GetStartSlot() uint64 {
+	if x != nil && x.StartSlot != nil {
+		return *x.StartSlot
+	}
+	return 0
+}
+
+func (x *GetScoresRequest) GetEndSlot() uint64 {
+	if x != nil && x.EndSlot != nil {
+		return *x.EndSlot
+	}
+	return 0
+}
+
+type LatencyScore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Samples uint64  `protobuf:"varint,1,opt,name=samples,proto3" json:"samples,omitempty"`
+	P50Ms   int64   `protobuf:"varint,2,opt,name=p50_ms,json=p50Ms,proto3" json:"p50_ms,omitempty"`
+	P95Ms   int64   `protobuf:"varint,3,opt,name=p95_ms,json=p95Ms,proto3" json:"p95_ms,omitempty"`
+	Score   float64 `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *LatencyScore) Reset() {
+	*x = LatencyScore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatencyScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyScore) ProtoMessage() {}
+
+func (x *LatencyScore) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyScore.ProtoReflect.Descriptor instead.
+func (*LatencyScore) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LatencyScore) GetSamples() uint64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *LatencyScore) GetP50Ms() int64 {
+	if x != nil {
+		return x.P50Ms
+	}
+	return 0
+}
+
+func (x *LatencyScore) GetP95Ms() int64 {
+	if x != nil {
+		return x.P95Ms
+	}
+	return 0
+}
+
+func (x *LatencyScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type OverallScore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Score      float64            `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	Components map[string]float64 `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+}
+
+func (x *OverallScore) Reset() {
+	*x = OverallScore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OverallScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverallScore) ProtoMessage() {}
+
+func (x *OverallScore) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverallScore.ProtoReflect.Descriptor instead.
+func (*OverallScore) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OverallScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *OverallScore) GetComponents() map[string]float64 {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type RelayScores struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RelayPublicKey string        `protobuf:"bytes,1,opt,name=relay_public_key,json=relayPublicKey,proto3" json:"relay_public_key,omitempty"`
+	Latency        *LatencyScore `protobuf:"bytes,2,opt,name=latency,proto3" json:"latency,omitempty"`
+	Overall        *OverallScore `protobuf:"bytes,3,opt,name=overall,proto3" json:"overall,omitempty"`
+}
+
+func (x *RelayScores) Reset() {
+	*x = RelayScores{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelayScores) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelayScores) ProtoMessage() {}
+
+func (x *RelayScores) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelayScores.ProtoReflect.Descriptor instead.
+func (*RelayScores) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RelayScores) GetRelayPublicKey() string {
+	if x != nil {
+		return x.RelayPublicKey
+	}
+	return ""
+}
+
+func (x *RelayScores) GetLatency() *LatencyScore {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *RelayScores) GetOverall() *OverallScore {
+	if x != nil {
+		return x.Overall
+	}
+	return nil
+}
+
+type GetScoresResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartSlot uint64         `protobuf:"varint,1,opt,name=start_slot,json=startSlot,proto3" json:"start_slot,omitempty"`
+	EndSlot   uint64         `protobuf:"varint,2,opt,name=end_slot,json=endSlot,proto3" json:"end_slot,omitempty"`
+	Relays    []*RelayScores `protobuf:"bytes,3,rep,name=relays,proto3" json:"relays,omitempty"`
+}
+
+func (x *GetScoresResponse) Reset() {
+	*x = GetScoresResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetScoresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScoresResponse) ProtoMessage() {}
+
+func (x *GetScoresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScoresResponse.ProtoReflect.Descriptor instead.
+func (*GetScoresResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetScoresResponse) GetStartSlot() uint64 {
+	if x != nil {
+		return x.StartSlot
+	}
+	return 0
+}
+
+func (x *GetScoresResponse) GetEndSlot() uint64 {
+	if x != nil {
+		return x.EndSlot
+	}
+	return 0
+}
+
+func (x *GetScoresResponse) GetRelays() []*RelayScores {
+	if x != nil {
+		return x.Relays
+	}
+	return nil
+}
+
+type StreamBidAnalysesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Analyses of all relays are streamed if empty
+	RelayPublicKeys []string `protobuf:"bytes,1,rep,name=relay_public_keys,json=relayPublicKeys,proto3" json:"relay_public_keys,omitempty"`
+}
+
+func (x *StreamBidAnalysesRequest) Reset() {
+	*x = StreamBidAnalysesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamBidAnalysesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBidAnalysesRequest) ProtoMessage() {}
+
+func (x *StreamBidAnalysesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_rpc_pb_relay_monitor_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBidAnalysesRequest.ProtoReflect.Descriptor instead.
+func (*StreamBidAnalysesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StreamBidAnalysesRequest) GetRelayPublicKeys() []string {
+	if x != nil {
+		return x.RelayPublicKeys
+	}
+	return nil
+}
+
+var File_pkg_rpc_pb_relay_monitor_proto protoreflect.FileDescriptor
+
+var file_pkg_rpc_pb_relay_monitor_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x70, 0x6b, 0x67, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x2f, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x5f, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x22, 0x78, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x65,
+	0x6e, 0x64, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01,
+	0x52, 0x08, 0x65, 0x6e, 0x64, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a,
+	0x0c, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x42, 0x0c, 0x0a,
+	0x0a, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x8c, 0x08, 0x0a, 0x0a,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x62, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x69, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x63, 0x6f, 0x6e,
+	0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x5f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x62,
+	0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x42, 0x69, 0x64, 0x73, 0x12,
+	0x38, 0x0a, 0x18, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x5f, 0x62, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x16, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x42, 0x69, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x73, 0x6b, 0x69,
+	0x70, 0x70, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x62,
+	0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x73, 0x6b, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x42, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x69, 0x64, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x62, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x65, 0x42, 0x69, 0x64, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6e,
+	0x6f, 0x5f, 0x62, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x6f,
+	0x42, 0x69, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x62, 0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x12, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x42, 0x69, 0x64, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x6d, 0x61, 0x6c, 0x66, 0x6f, 0x72,
+	0x6d, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x11, 0x6d, 0x61, 0x6c, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x50, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x3c, 0x0a, 0x1a, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73,
+	0x75, 0x73, 0x5f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x18, 0x63, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x75, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x13, 0x75, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x73,
+	0x73, 0x65, 0x64, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0b, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x15,
+	0x62, 0x69, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x64, 0x69, 0x76, 0x65, 0x72, 0x67,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x62, 0x69, 0x64,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x5f, 0x62, 0x69,
+	0x64, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x64, 0x42, 0x69, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d,
+	0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a,
+	0x11, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x61,
+	0x74, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x74, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62,
+	0x69, 0x64, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x4f, 0x76, 0x65, 0x72, 0x73, 0x74, 0x61, 0x74, 0x65, 0x64, 0x42, 0x69, 0x64, 0x73, 0x12, 0x2a,
+	0x0a, 0x11, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x62,
+	0x69, 0x64, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x6c, 0x65,
+	0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x69, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x68, 0x65,
+	0x61, 0x64, 0x5f, 0x6c, 0x61, 0x67, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x68, 0x65, 0x61, 0x64, 0x4c, 0x61, 0x67, 0x53, 0x6c, 0x6f, 0x74, 0x73,
+	0x12, 0x2a, 0x0a, 0x11, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x73, 0x74, 0x61,
+	0x6c, 0x65, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x10,
+	0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x6c, 0x61, 0x67,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x4c, 0x61, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x74, 0x61, 0x6c,
+	0x65, 0x48, 0x65, 0x61, 0x64, 0x12, 0x33, 0x0a, 0x15, 0x63, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x65,
+	0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x63, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x65, 0x64, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x0b, 0x52,
+	0x65, 0x6c, 0x61, 0x79, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x31, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e,
+	0x64, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65,
+	0x6e, 0x64, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x34, 0x0a, 0x06, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d,
+	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x46,
+	0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x06, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x73, 0x22, 0xa1, 0x01,
+	0x0a, 0x15, 0x47, 0x65, 0x74, 0x42, 0x69, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65,
+	0x79, 0x12, 0x22, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x6c,
+	0x6f, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x73, 0x6c, 0x6f,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x53, 0x6c,
+	0x6f, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x73, 0x6c, 0x6f, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x73, 0x6c, 0x6f,
+	0x74, 0x22, 0xfc, 0x01, 0x0a, 0x0b, 0x42, 0x69, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73,
+	0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x5f,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f,
+	0x62, 0x79, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0f, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x42, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x22, 0x8c, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x42, 0x69, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79,
+	0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e,
+	0x64, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x65, 0x6e,
+	0x64, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d,
+	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x41, 0x6e, 0x61,
+	0x6c, 0x79, 0x73, 0x69, 0x73, 0x52, 0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x22,
+	0xb6, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
+	0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x6c, 0x6f,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x53, 0x6c, 0x6f, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x73,
+	0x6c, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48, 0x02, 0x52, 0x07, 0x65, 0x6e, 0x64,
+	0x53, 0x6c, 0x6f, 0x74, 0x88, 0x01, 0x01, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x42, 0x0d, 0x0a, 0x0b,
+	0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f,
+	0x65, 0x6e, 0x64, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x22, 0x6c, 0x0a, 0x0c, 0x4c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x70, 0x35, 0x30, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x70, 0x35, 0x30, 0x4d, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x70, 0x39, 0x35,
+	0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x39, 0x35, 0x4d, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x0c, 0x4f, 0x76, 0x65, 0x72, 0x61,
+	0x6c, 0x6c, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x4d, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2d, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x3d, 0x0a, 0x0f,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa9, 0x01, 0x0a, 0x0b,
+	0x52, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f,
+	0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x37,
+	0x0a, 0x07, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x07,
+	0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x22, 0x83, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x34, 0x0a, 0x06, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d,
+	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x73, 0x52, 0x06, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x73, 0x22, 0x46, 0x0a,
+	0x18, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x69, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x73, 0x32, 0xf9, 0x02, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x4d,
+	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x52, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f,
+	0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x42, 0x69, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x42, 0x69, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69,
+	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x69, 0x64, 0x41, 0x6e, 0x61,
+	0x6c, 0x79, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a,
+	0x09, 0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x5e, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x69, 0x64, 0x41, 0x6e,
+	0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x12, 0x29, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f,
+	0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42,
+	0x69, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x30,
+	0x01, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x72, 0x61, 0x6c, 0x65, 0x78, 0x73, 0x74, 0x6f, 0x6b, 0x65, 0x73, 0x2f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x2d, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x72, 0x70,
+	0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_rpc_pb_relay_monitor_proto_rawDescOnce sync.Once
+	file_pkg_rpc_pb_relay_monitor_proto_rawDescData = file_pkg_rpc_pb_relay_monitor_proto_rawDesc
+)
+
+func file_pkg_rpc_pb_relay_monitor_proto_rawDescGZIP() []byte {
+	file_pkg_rpc_pb_relay_monitor_proto_rawDescOnce.Do(func() {
+		file_pkg_rpc_pb_relay_monitor_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_rpc_pb_relay_monitor_proto_rawDescData)
+	})
+	return file_pkg_rpc_pb_relay_monitor_proto_rawDescData
+}
+
+var file_pkg_rpc_pb_relay_monitor_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_pkg_rpc_pb_relay_monitor_proto_goTypes = []interface{}{
+	(*GetFaultsRequest)(nil),         // 0: relaymonitor.v1.GetFaultsRequest
+	(*FaultStats)(nil),               // 1: relaymonitor.v1.FaultStats
+	(*RelayFaults)(nil),              // 2: relaymonitor.v1.RelayFaults
+	(*GetFaultsResponse)(nil),        // 3: relaymonitor.v1.GetFaultsResponse
+	(*GetBidAnalysesRequest)(nil),    // 4: relaymonitor.v1.GetBidAnalysesRequest
+	(*BidAnalysis)(nil),              // 5: relaymonitor.v1.BidAnalysis
+	(*GetBidAnalysesResponse)(nil),   // 6: relaymonitor.v1.GetBidAnalysesResponse
+	(*GetScoresRequest)(nil),         // 7: relaymonitor.v1.GetScoresRequest
+	(*LatencyScore)(nil),             // 8: relaymonitor.v1.LatencyScore
+	(*OverallScore)(nil),             // 9: relaymonitor.v1.OverallScore
+	(*RelayScores)(nil),              // 10: relaymonitor.v1.RelayScores
+	(*GetScoresResponse)(nil),        // 11: relaymonitor.v1.GetScoresResponse
+	(*StreamBidAnalysesRequest)(nil), // 12: relaymonitor.v1.StreamBidAnalysesRequest
+	nil,                              // 13: relaymonitor.v1.OverallScore.ComponentsEntry
+}
+var file_pkg_rpc_pb_relay_monitor_proto_depIdxs = []int32{
+	1,  // 0: relaymonitor.v1.RelayFaults.stats:type_name -> relaymonitor.v1.FaultStats
+	2,  // 1: relaymonitor.v1.GetFaultsResponse.relays:type_name -> relaymonitor.v1.RelayFaults
+	5,  // 2: relaymonitor.v1.GetBidAnalysesResponse.analyses:type_name -> relaymonitor.v1.BidAnalysis
+	13, // 3: relaymonitor.v1.OverallScore.components:type_name -> relaymonitor.v1.OverallScore.ComponentsEntry
+	8,  // 4: relaymonitor.v1.RelayScores.latency:type_name -> relaymonitor.v1.LatencyScore
+	9,  // 5: relaymonitor.v1.RelayScores.overall:type_name -> relaymonitor.v1.OverallScore
+	10, // 6: relaymonitor.v1.GetScoresResponse.relays:type_name -> relaymonitor.v1.RelayScores
+	0,  // 7: relaymonitor.v1.RelayMonitor.GetFaults:input_type -> relaymonitor.v1.GetFaultsRequest
+	4,  // 8: relaymonitor.v1.RelayMonitor.GetBidAnalyses:input_type -> relaymonitor.v1.GetBidAnalysesRequest
+	7,  // 9: relaymonitor.v1.RelayMonitor.GetScores:input_type -> relaymonitor.v1.GetScoresRequest
+	12, // 10: relaymonitor.v1.RelayMonitor.StreamBidAnalyses:input_type -> relaymonitor.v1.StreamBidAnalysesRequest
+	3,  // 11: relaymonitor.v1.RelayMonitor.GetFaults:output_type -> relaymonitor.v1.GetFaultsResponse
+	6,  // 12: relaymonitor.v1.RelayMonitor.GetBidAnalyses:output_type -> relaymonitor.v1.GetBidAnalysesResponse
+	11, // 13: relaymonitor.v1.RelayMonitor.GetScores:output_type -> relaymonitor.v1.GetScoresResponse
+	5,  // 14: relaymonitor.v1.RelayMonitor.StreamBidAnalyses:output_type -> relaymonitor.v1.BidAnalysis
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_pkg_rpc_pb_relay_monitor_proto_init() }
+func file_pkg_rpc_pb_relay_monitor_proto_init() {
+	if File_pkg_rpc_pb_relay_monitor_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFaultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FaultStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelayFaults); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFaultsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBidAnalysesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BidAnalysis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBidAnalysesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetScoresRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatencyScore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OverallScore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelayScores); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetScoresResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_rpc_pb_relay_monitor_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamBidAnalysesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_pkg_rpc_pb_relay_monitor_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_pkg_rpc_pb_relay_monitor_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_pkg_rpc_pb_relay_monitor_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_rpc_pb_relay_monitor_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_rpc_pb_relay_monitor_proto_goTypes,
+		DependencyIndexes: file_pkg_rpc_pb_relay_monitor_proto_depIdxs,
+		MessageInfos:      file_pkg_rpc_pb_relay_monitor_proto_msgTypes,
+	}.Build()
+	File_pkg_rpc_pb_relay_monitor_proto = out.File
+	file_pkg_rpc_pb_relay_monitor_proto_rawDesc = nil
+	file_pkg_rpc_pb_relay_monitor_proto_goTypes = nil
+	file_pkg_rpc_pb_relay_monitor_proto_depIdxs = nil
+}
@@ -0,0 +1,256 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: pkg/rpc/pb/relay_monitor.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RelayMonitor_GetFaults_FullMethodName         = "/relaymonitor.v1.RelayMonitor/GetFaults"
+	RelayMonitor_GetBidAnalyses_FullMethodName    = "/relaymonitor.v1.RelayMonitor/GetBidAnalyses"
+	RelayMonitor_GetScores_FullMethodName         = "/relaymonitor.v1.RelayMonitor/GetScores"
+	RelayMonitor_StreamBidAnalyses_FullMethodName = "/relaymonitor.v1.RelayMonitor/StreamBidAnalyses"
+)
+
+// RelayMonitorClient is the client API for RelayMonitor service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RelayMonitorClient interface {
+	// Fault stats of each relay over a span of epochs
+	GetFaults(ctx context.Context, in *GetFaultsRequest, opts ...grpc.CallOption) (*GetFaultsResponse, error)
+	// Analysis records of the bids of a relay over a span of slots
+	GetBidAnalyses(ctx context.Context, in *GetBidAnalysesRequest, opts ...grpc.CallOption) (*GetBidAnalysesResponse, error)
+	// Latency and overall scores of each relay over a span of slots
+	GetScores(ctx context.Context, in *GetScoresRequest, opts ...grpc.CallOption) (*GetScoresResponse, error)
+	// Feed of analysis records as bids are analyzed by the monitor
+	StreamBidAnalyses(ctx context.Context, in *StreamBidAnalysesRequest, opts ...grpc.CallOption) (RelayMonitor_StreamBidAnalysesClient, error)
+}
+
+type relayMonitorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRelayMonitorClient(cc grpc.ClientConnInterface) RelayMonitorClient {
+	return &relayMonitorClient{cc}
+}
+
+func (c *relayMonitorClient) GetFaults(ctx context.Context, in *GetFaultsRequest, opts ...grpc.CallOption) (*GetFaultsResponse, error) {
+	out := new(GetFaultsResponse)
+	err := c.cc.Invoke(ctx, RelayMonitor_GetFaults_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relayMonitorClient) GetBidAnalyses(ctx context.Context, in *GetBidAnalysesRequest, opts ...grpc.CallOption) (*GetBidAnalysesResponse, error) {
+	out := new(GetBidAnalysesResponse)
+	err := c.cc.Invoke(ctx, RelayMonitor_GetBidAnalyses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relayMonitorClient) GetScores(ctx context.Context, in *GetScoresRequest, opts ...grpc.CallOption) (*GetScoresResponse, error) {
+	out := new(GetScoresResponse)
+	err := c.cc.Invoke(ctx, RelayMonitor_GetScores_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relayMonitorClient) StreamBidAnalyses(ctx context.Context, in *StreamBidAnalysesRequest, opts ...grpc.CallOption) (RelayMonitor_StreamBidAnalysesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RelayMonitor_ServiceDesc.Streams[0], RelayMonitor_StreamBidAnalyses_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &relayMonitorStreamBidAnalysesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RelayMonitor_StreamBidAnalysesClient interface {
+	Recv() (*BidAnalysis, error)
+	grpc.ClientStream
+}
+
+type relayMonitorStreamBidAnalysesClient struct {
+	grpc.ClientStream
+}
+
+func (x *relayMonitorStreamBidAnalysesClient) Recv() (*BidAnalysis, error) {
+	m := new(BidAnalysis)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RelayMonitorServer is the server API for RelayMonitor service.
+// All implementations must embed UnimplementedRelayMonitorServer
+// for forward compatibility
+type RelayMonitorServer interface {
+	// Fault stats of each relay over a span of epochs
+	GetFaults(context.Context, *GetFaultsRequest) (*GetFaultsResponse, error)
+	// Analysis records of the bids of a relay over a span of slots
+	GetBidAnalyses(context.Context, *GetBidAnalysesRequest) (*GetBidAnalysesResponse, error)
+	// Latency and overall scores of each relay over a span of slots
+	GetScores(context.Context, *GetScoresRequest) (*GetScoresResponse, error)
+	// Feed of analysis records as bids are analyzed by the monitor
+	StreamBidAnalyses(*StreamBidAnalysesRequest, RelayMonitor_StreamBidAnalysesServer) error
+	mustEmbedUnimplementedRelayMonitorServer()
+}
+
+// UnimplementedRelayMonitorServer must be embedded to have forward compatible implementations.
+type UnimplementedRelayMonitorServer struct {
+}
+
+func (UnimplementedRelayMonitorServer) GetFaults(context.Context, *GetFaultsRequest) (*GetFaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFaults not implemented")
+}
+func (UnimplementedRelayMonitorServer) GetBidAnalyses(context.Context, *GetBidAnalysesRequest) (*GetBidAnalysesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBidAnalyses not implemented")
+}
+func (UnimplementedRelayMonitorServer) GetScores(context.Context, *GetScoresRequest) (*GetScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScores not implemented")
+}
+func (UnimplementedRelayMonitorServer) StreamBidAnalyses(*StreamBidAnalysesRequest, RelayMonitor_StreamBidAnalysesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBidAnalyses not implemented")
+}
+func (UnimplementedRelayMonitorServer) mustEmbedUnimplementedRelayMonitorServer() {}
+
+// UnsafeRelayMonitorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RelayMonitorServer will
+// result in compilation errors.
+type UnsafeRelayMonitorServer interface {
+	mustEmbedUnimplementedRelayMonitorServer()
+}
+
+func RegisterRelayMonitorServer(s grpc.ServiceRegistrar, srv RelayMonitorServer) {
+	s.RegisterService(&RelayMonitor_ServiceDesc, srv)
+}
+
+func _RelayMonitor_GetFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelayMonitorServer).GetFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelayMonitor_GetFaults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelayMonitorServer).GetFaults(ctx, req.(*GetFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelayMonitor_GetBidAnalyses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBidAnalysesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelayMonitorServer).GetBidAnalyses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelayMonitor_GetBidAnalyses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelayMonitorServer).GetBidAnalyses(ctx, req.(*GetBidAnalysesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelayMonitor_GetScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelayMonitorServer).GetScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelayMonitor_GetScores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelayMonitorServer).GetScores(ctx, req.(*GetScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelayMonitor_StreamBidAnalyses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBidAnalysesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RelayMonitorServer).StreamBidAnalyses(m, &relayMonitorStreamBidAnalysesServer{stream})
+}
+
+type RelayMonitor_StreamBidAnalysesServer interface {
+	Send(*BidAnalysis) error
+	grpc.ServerStream
+}
+
+type relayMonitorStreamBidAnalysesServer struct {
+	grpc.ServerStream
+}
+
+func (x *relayMonitorStreamBidAnalysesServer) Send(m *BidAnalysis) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RelayMonitor_ServiceDesc is the grpc.ServiceDesc for RelayMonitor service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RelayMonitor_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "relaymonitor.v1.RelayMonitor",
+	HandlerType: (*RelayMonitorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFaults",
+			Handler:    _RelayMonitor_GetFaults_Handler,
+		},
+		{
+			MethodName: "GetBidAnalyses",
+			Handler:    _RelayMonitor_GetBidAnalyses_Handler,
+		},
+		{
+			MethodName: "GetScores",
+			Handler:    _RelayMonitor_GetScores_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBidAnalyses",
+			Handler:       _RelayMonitor_StreamBidAnalyses_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/rpc/pb/relay_monitor.proto",
+}
@@ -0,0 +1,269 @@
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative pb/relay_monitor.proto
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/api"
+	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+	"github.com/ralexstokes/relay-monitor/pkg/reporter"
+	"github.com/ralexstokes/relay-monitor/pkg/rpc/pb"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Config struct {
+	Host string `yaml:"host"`
+	Port uint16 `yaml:"port"`
+}
+
+// `Server` serves the data of the monitor over gRPC alongside the REST API
+type Server struct {
+	pb.UnimplementedRelayMonitorServer
+
+	config *Config
+	logger *zap.Logger
+
+	// bounds of the spans requests can cover, shared with the REST API
+	spans *api.SpanConfig
+
+	analyzer *analysis.Analyzer
+	reporter *reporter.Reporter
+	clock    *consensus.Clock
+	store    store.Storer
+}
+
+func New(config *Config, logger *zap.Logger, spans *api.SpanConfig, analyzer *analysis.Analyzer, reporter *reporter.Reporter, clock *consensus.Clock, store store.Storer) *Server {
+	return &Server{
+		config:   config,
+		logger:   logger,
+		spans:    spans,
+		analyzer: analyzer,
+		reporter: reporter,
+		clock:    clock,
+		store:    store,
+	}
+}
+
+func (s *Server) currentSlot() types.Slot {
+	return s.clock.CurrentSlot(time.Now().Unix())
+}
+
+// `computeSlotSpan` defaults any missing bound of the requested span of slots as the REST API does
+func (s *Server) computeSlotSpan(start, end *uint64) (types.Slot, types.Slot, error) {
+	startSlot, endSlot := api.ComputeSpanFromRequest(start, end, s.spans.SlotWindow(), s.currentSlot())
+	if endSlot < startSlot {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid span: end slot %d is before start slot %d", endSlot, startSlot)
+	}
+	if endSlot-startSlot > s.spans.MaxSlots() {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid span: requested span of %d slots exceeds the maximum of %d slots", endSlot-startSlot, s.spans.MaxSlots())
+	}
+	return startSlot, endSlot, nil
+}
+
+func parsePublicKey(value string) (*types.PublicKey, error) {
+	var publicKey types.PublicKey
+	err := publicKey.UnmarshalText([]byte(value))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid public key %s: %v", value, err)
+	}
+	return &publicKey, nil
+}
+
+func toFaultStats(stats *analysis.FaultStats) *pb.FaultStats {
+	return &pb.FaultStats{
+		TotalBids:                uint64(stats.TotalBids),
+		ConsensusInvalidBids:     uint64(stats.ConsensusInvalidBids),
+		IgnoredPreferencesBids:   uint64(stats.IgnoredPreferencesBids),
+		SkippedByPolicyBids:      uint64(stats.SkippedByPolicyBids),
+		LateBids:                 uint64(stats.LateBids),
+		NoBids:                   uint64(stats.NoBids),
+		PaymentInvalidBids:       uint64(stats.PaymentInvalidBids),
+		MalformedPayloads:        uint64(stats.MalformedPayloads),
+		ConsensusInvalidPayloads: uint64(stats.ConsensusInvalidPayloads),
+		UnavailablePayloads:      uint64(stats.UnavailablePayloads),
+		RegistrationIgnored:      uint64(stats.RegistrationsIgnored),
+		MissedSlots:              uint64(stats.MissedSlots),
+		BidValueDivergences:      uint64(stats.BidValueDivergences),
+		ResampledBids:            uint64(stats.ResampledBids),
+		Cancellations:            uint64(stats.Cancellations),
+		CancellationRate:         stats.CancellationRate,
+		ValueOverstatedBids:      uint64(stats.ValueOverstatedBids),
+		StaleParentBids:          uint64(stats.StaleParentBids),
+		HeadLagSlots:             uint64(stats.HeadLagSlots),
+		StaleParentRate:          stats.StaleParentRate,
+		AverageHeadLag:           stats.AverageHeadLag,
+		StaleHead:                stats.StaleHead,
+		CensoredTransactions:     uint64(stats.CensoredTransactions),
+	}
+}
+
+func toBidAnalysis(analysis *types.BidAnalysis) *pb.BidAnalysis {
+	return &pb.BidAnalysis{
+		Slot:              analysis.Context.Slot,
+		ParentHash:        analysis.Context.ParentHash.String(),
+		ProposerPublicKey: analysis.Context.ProposerPublicKey.String(),
+		RelayPublicKey:    analysis.Context.RelayPublicKey.String(),
+		Category:          analysis.Category,
+		Reason:            analysis.Reason,
+		SkippedByPolicy:   analysis.SkippedByPolicy,
+	}
+}
+
+func (s *Server) GetFaults(ctx context.Context, req *pb.GetFaultsRequest) (*pb.GetFaultsResponse, error) {
+	currentEpoch := s.clock.EpochForSlot(s.currentSlot())
+	startEpoch, endEpoch := api.ComputeSpanFromRequest(req.StartEpoch, req.EndEpoch, s.spans.EpochWindow(), currentEpoch)
+	if endEpoch < startEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid span: end epoch %d is before start epoch %d", endEpoch, startEpoch)
+	}
+
+	faults := s.analyzer.GetFaults(startEpoch, endEpoch)
+	response := &pb.GetFaultsResponse{
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+	}
+	for relay, relayFaults := range faults {
+		response.Relays = append(response.Relays, &pb.RelayFaults{
+			RelayPublicKey: relay.String(),
+			Endpoint:       relayFaults.Meta.Endpoint,
+			Stats:          toFaultStats(relayFaults.Stats),
+		})
+	}
+	return response, nil
+}
+
+func (s *Server) GetBidAnalyses(ctx context.Context, req *pb.GetBidAnalysesRequest) (*pb.GetBidAnalysesResponse, error) {
+	relay, err := parsePublicKey(req.RelayPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	startSlot, endSlot, err := s.computeSlotSpan(req.StartSlot, req.EndSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	analyses, err := s.store.GetBidAnalyses(ctx, relay, startSlot, endSlot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get bid analyses: %v", err)
+	}
+	response := &pb.GetBidAnalysesResponse{
+		StartSlot: startSlot,
+		EndSlot:   endSlot,
+	}
+	for i := range analyses {
+		response.Analyses = append(response.Analyses, toBidAnalysis(&analyses[i]))
+	}
+	return response, nil
+}
+
+func (s *Server) GetScores(ctx context.Context, req *pb.GetScoresRequest) (*pb.GetScoresResponse, error) {
+	relays := s.reporter.Relays()
+	if req.RelayPublicKey != nil {
+		relay, err := parsePublicKey(*req.RelayPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		relays = []types.PublicKey{*relay}
+	}
+	startSlot, endSlot, err := s.computeSlotSpan(req.StartSlot, req.EndSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GetScoresResponse{
+		StartSlot: startSlot,
+		EndSlot:   endSlot,
+	}
+	for _, relay := range relays {
+		relay := relay
+		latency, err := s.reporter.GetLatencyScore(ctx, &relay, startSlot, endSlot)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not compute latency score for relay %s: %v", relay, err)
+		}
+		overall, err := s.reporter.GetOverallScore(ctx, &relay, startSlot, endSlot)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not compute overall score for relay %s: %v", relay, err)
+		}
+		response.Relays = append(response.Relays, &pb.RelayScores{
+			RelayPublicKey: relay.String(),
+			Latency: &pb.LatencyScore{
+				Samples: uint64(latency.Samples),
+				P50Ms:   latency.P50,
+				P95Ms:   latency.P95,
+				Score:   latency.Score,
+			},
+			Overall: &pb.OverallScore{
+				Score:      overall.Score,
+				Components: overall.Components,
+			},
+		})
+	}
+	return response, nil
+}
+
+// `StreamBidAnalyses` sends each bid analysis of the requested relays to the client.
+// A client which does not keep up with the feed applies backpressure to this stream only and misses analyses
+// once the buffer of its subscription is full.
+func (s *Server) StreamBidAnalyses(req *pb.StreamBidAnalysesRequest, stream pb.RelayMonitor_StreamBidAnalysesServer) error {
+	relays := make(map[types.PublicKey]struct{})
+	for _, value := range req.RelayPublicKeys {
+		relay, err := parsePublicKey(value)
+		if err != nil {
+			return err
+		}
+		relays[*relay] = struct{}{}
+	}
+
+	feed, cancel := s.analyzer.SubscribeBidAnalyses()
+	defer cancel()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case analysis, ok := <-feed:
+			if !ok {
+				return nil
+			}
+			if len(relays) > 0 {
+				if _, ok := relays[analysis.Context.RelayPublicKey]; !ok {
+					continue
+				}
+			}
+			err := stream.Send(toBidAnalysis(&analysis))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	logger := s.logger.Sugar()
+	host := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+
+	listener, err := net.Listen("tcp", host)
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer()
+	pb.RegisterRelayMonitorServer(server, s)
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	logger.Infof("gRPC server listening on %s", host)
+	return server.Serve(listener)
+}
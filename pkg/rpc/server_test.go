@@ -0,0 +1,228 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/ralexstokes/relay-monitor/pkg/analysis"
+	"github.com/ralexstokes/relay-monitor/pkg/api"
+	"github.com/ralexstokes/relay-monitor/pkg/builder"
+	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+	"github.com/ralexstokes/relay-monitor/pkg/data"
+	"github.com/ralexstokes/relay-monitor/pkg/rpc/pb"
+	"github.com/ralexstokes/relay-monitor/pkg/store"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var testRelay = types.PublicKey{0x01}
+
+// `testServer` serves the gRPC API in-process over an analyzer fed bids through `events`
+type testServer struct {
+	client pb.RelayMonitorClient
+	events chan data.Event
+	clock  *consensus.Clock
+}
+
+func newTestServer(t *testing.T) *testServer {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	relay, err := builder.NewClientFromConfig(&builder.Config{
+		Endpoint: fmt.Sprintf("https://%s@relay.example.com", testRelay),
+		// NOTE: the checks depending on a consensus client are disabled
+		DisabledChecks: []string{analysis.CategoryConsensusInvalid, analysis.CategoryIgnoredPreferences},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: a clock starting now keeps the analyzer from attributing missed slots within the test
+	clock := consensus.NewClock(uint64(time.Now().Unix()), 12, 32)
+	events := make(chan data.Event, 16)
+	memoryStore := store.NewMemoryStore()
+	analyzer := analysis.NewAnalyzer(&analysis.Config{}, zap.NewNop(), []*builder.Client{relay}, events, memoryStore, nil, nil, clock, nil)
+	go func() {
+		_ = analyzer.Run(ctx)
+	}()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterRelayMonitorServer(server, New(&Config{}, zap.NewNop(), &api.SpanConfig{}, analyzer, nil, clock, memoryStore))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return &testServer{
+		client: pb.NewRelayMonitorClient(conn),
+		events: events,
+		clock:  clock,
+	}
+}
+
+func (s *testServer) sendBid(slot types.Slot, sample uint) {
+	s.events <- s.bidEvent(slot, sample)
+}
+
+func (s *testServer) bidEvent(slot types.Slot, sample uint) data.Event {
+	return data.Event{Payload: &data.BidEvent{
+		Context: &types.BidContext{Slot: slot, RelayPublicKey: testRelay},
+		Bid: &types.Bid{
+			Message: &boostTypes.BuilderBid{
+				Header: &boostTypes.ExecutionPayloadHeader{GasLimit: 30_000_000},
+			},
+		},
+		Sample:     sample,
+		Latency:    100 * time.Millisecond,
+		ReceivedAt: time.Unix(s.clock.SlotInSeconds(slot), 0),
+	}}
+}
+
+func uint64Ptr(value uint64) *uint64 {
+	return &value
+}
+
+func TestGetFaultsSpan(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	// NOTE: the bid of slot 64 falls into epoch 2
+	s.sendBid(64, 0)
+	var relayFaults *pb.RelayFaults
+	for i := 0; i < 100; i++ {
+		response, err := s.client.GetFaults(ctx, &pb.GetFaultsRequest{StartEpoch: uint64Ptr(2), EndEpoch: uint64Ptr(2)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(response.Relays) == 1 && response.Relays[0].Stats.TotalBids == 1 {
+			relayFaults = response.Relays[0]
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if relayFaults == nil {
+		t.Fatal("bid was not counted in the faults of epoch 2")
+	}
+	if relayFaults.RelayPublicKey != testRelay.String() || relayFaults.Endpoint != "relay.example.com" {
+		t.Fatalf("unexpected relay %s at %s", relayFaults.RelayPublicKey, relayFaults.Endpoint)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		request    *pb.GetFaultsRequest
+		startEpoch uint64
+		endEpoch   uint64
+		totalBids  uint64
+	}{
+		{
+			name:       "default span ends at the current epoch",
+			request:    &pb.GetFaultsRequest{},
+			startEpoch: 0,
+			endEpoch:   0,
+			totalBids:  0,
+		},
+		{
+			name:       "span before the bid",
+			request:    &pb.GetFaultsRequest{StartEpoch: uint64Ptr(0), EndEpoch: uint64Ptr(1)},
+			startEpoch: 0,
+			endEpoch:   1,
+			totalBids:  0,
+		},
+		{
+			name:       "missing end covers the default window from the start",
+			request:    &pb.GetFaultsRequest{StartEpoch: uint64Ptr(1)},
+			startEpoch: 1,
+			endEpoch:   1 + api.DefaultEpochSpanForFaultsWindow,
+			totalBids:  1,
+		},
+		{
+			name:       "missing start covers the default window up to the end",
+			request:    &pb.GetFaultsRequest{EndEpoch: uint64Ptr(3)},
+			startEpoch: 0,
+			endEpoch:   3,
+			totalBids:  1,
+		},
+	} {
+		response, err := s.client.GetFaults(ctx, tc.request)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if response.StartEpoch != tc.startEpoch || response.EndEpoch != tc.endEpoch {
+			t.Errorf("%s: expected span [%d, %d] but got [%d, %d]", tc.name, tc.startEpoch, tc.endEpoch, response.StartEpoch, response.EndEpoch)
+		}
+		if len(response.Relays) != 1 || response.Relays[0].Stats.TotalBids != tc.totalBids {
+			t.Errorf("%s: expected %d bids but got %+v", tc.name, tc.totalBids, response.Relays)
+		}
+	}
+
+	_, err := s.client.GetFaults(ctx, &pb.GetFaultsRequest{StartEpoch: uint64Ptr(2), EndEpoch: uint64Ptr(1)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument for span ending before its start but got %v", err)
+	}
+}
+
+func TestStreamBidAnalyses(t *testing.T) {
+	s := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := s.client.StreamBidAnalyses(ctx, &pb.StreamBidAnalysesRequest{RelayPublicKeys: []string{testRelay.String()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: analyses published before the server subscribes to the feed are missed, so bids are sent until one is received
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for sample := uint(0); ; sample++ {
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+			select {
+			case <-done:
+				return
+			case s.events <- s.bidEvent(10, sample):
+			}
+		}
+	}()
+
+	analysis, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Slot != 10 || analysis.RelayPublicKey != testRelay.String() {
+		t.Fatalf("unexpected analysis %+v", analysis)
+	}
+	if analysis.Category != "" {
+		t.Fatalf("expected valid bid but got category %s: %s", analysis.Category, analysis.Reason)
+	}
+	if len(analysis.SkippedByPolicy) != 2 {
+		t.Fatalf("expected the disabled checks to be skipped by policy but got %v", analysis.SkippedByPolicy)
+	}
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `compareBidContexts` orders bid contexts by slot, relay, proposer and parent hash
+func compareBidContexts(a, b *types.BidContext) int {
+	if a.Slot != b.Slot {
+		if a.Slot < b.Slot {
+			return -1
+		}
+		return 1
+	}
+	if c := bytes.Compare(a.RelayPublicKey[:], b.RelayPublicKey[:]); c != 0 {
+		return c
+	}
+	if c := bytes.Compare(a.ProposerPublicKey[:], b.ProposerPublicKey[:]); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.ParentHash[:], b.ParentHash[:])
+}
+
+// `matchesContext` reports whether `bidCtx` matches the filters of `query` on the context of a bid
+func matchesContext(query *types.BidQuery, bidCtx *types.BidContext) bool {
+	if bidCtx.Slot < query.StartSlot || bidCtx.Slot > query.EndSlot {
+		return false
+	}
+	if query.Relay != nil && bidCtx.RelayPublicKey != *query.Relay {
+		return false
+	}
+	if query.Proposer != nil && bidCtx.ProposerPublicKey != *query.Proposer {
+		return false
+	}
+	if query.After != nil && compareBidContexts(bidCtx, query.After) <= 0 {
+		return false
+	}
+	return true
+}
+
+// `matchesValue` reports whether `value` is within the bounds of `query`
+func matchesValue(query *types.BidQuery, value *types.U256Str) bool {
+	if query.MinValue != nil && value.BigInt().Cmp(query.MinValue.BigInt()) < 0 {
+		return false
+	}
+	if query.MaxValue != nil && value.BigInt().Cmp(query.MaxValue.BigInt()) > 0 {
+		return false
+	}
+	return true
+}
+
+// `builders` returns the builder of each block delivered in the inclusive slot range
+// NOTE: must be called with `lock` held
+func (s *MemoryStore) builders(startSlot, endSlot types.Slot) map[types.Hash]types.PublicKey {
+	builders := make(map[types.Hash]types.PublicKey)
+	for builder, payloads := range s.deliveredPayloads {
+		for _, payload := range payloads {
+			trace := payload.BidTrace
+			if trace.Slot < startSlot || trace.Slot > endSlot {
+				continue
+			}
+			builders[trace.BlockHash] = builder
+		}
+	}
+	return builders
+}
+
+func (s *MemoryStore) GetBids(ctx context.Context, query *types.BidQuery) ([]types.ArchivedBid, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var contexts []types.BidContext
+	for _, bidCtx := range s.bidContexts() {
+		bidCtx := bidCtx
+		if matchesContext(query, &bidCtx) {
+			contexts = append(contexts, bidCtx)
+		}
+	}
+	sort.Slice(contexts, func(i, j int) bool {
+		return compareBidContexts(&contexts[i], &contexts[j]) < 0
+	})
+
+	builders := s.builders(query.StartSlot, query.EndSlot)
+	bids := []types.ArchivedBid{}
+	for _, bidCtx := range contexts {
+		bidCtx := bidCtx
+		if query.Limit > 0 && len(bids) == query.Limit {
+			break
+		}
+		bid, err := s.getBid(&bidCtx)
+		if err != nil {
+			return nil, err
+		}
+		// NOTE: the absence of a bid is recorded but not archived as a bid
+		if bid == nil || !matchesValue(query, &bid.Message.Value) {
+			continue
+		}
+		archivedBid := types.ArchivedBid{
+			Context:   bidCtx,
+			Value:     bid.Message.Value,
+			BlockHash: bid.Message.Header.BlockHash,
+			Bid:       bid,
+		}
+		if builder, ok := builders[archivedBid.BlockHash]; ok {
+			archivedBid.Builder = &builder
+		}
+		if query.Builder != nil && (archivedBid.Builder == nil || *archivedBid.Builder != *query.Builder) {
+			continue
+		}
+		if analysis, ok := s.analyses[bidCtx]; ok {
+			archivedBid.Analysis = &analysis
+		}
+		if _, ok := s.acceptances[bidCtx]; ok {
+			archivedBid.Accepted = true
+		}
+		bids = append(bids, archivedBid)
+	}
+	return bids, nil
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestGetBids(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	relayA, relayB := types.PublicKey{1}, types.PublicKey{2}
+	builder := types.PublicKey{3}
+	for _, bidCtx := range []types.BidContext{
+		{Slot: 10, RelayPublicKey: relayB},
+		{Slot: 10, RelayPublicKey: relayA},
+		{Slot: 11, RelayPublicKey: relayA},
+		{Slot: 12, RelayPublicKey: relayA},
+	} {
+		bidCtx := bidCtx
+		bid := &types.Bid{
+			Message: &boostTypes.BuilderBid{
+				Header: &boostTypes.ExecutionPayloadHeader{},
+			},
+		}
+		bid.Message.Value[0] = uint8(bidCtx.Slot)
+		bid.Message.Header.BlockHash[0] = uint8(bidCtx.Slot)
+		err := store.PutBid(ctx, &bidCtx, bid)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := store.PutBid(ctx, &types.BidContext{Slot: 13, RelayPublicKey: relayA}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.PutDeliveredPayload(ctx, &types.DeliveredPayload{
+		RelayPublicKey: relayA,
+		BidTrace:       types.BidTrace{Slot: 11, BlockHash: types.Hash{11}, BuilderPubkey: builder},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bids, err := store.GetBids(ctx, &types.BidQuery{StartSlot: 0, EndSlot: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bids) != 4 || bids[0].Context.RelayPublicKey != relayA || bids[1].Context.RelayPublicKey != relayB || bids[3].Context.Slot != 12 {
+		t.Fatalf("expected every bid ordered by slot and relay but got %+v", bids)
+	}
+
+	page, err := store.GetBids(ctx, &types.BidQuery{StartSlot: 0, EndSlot: 20, Limit: 2, After: &bids[0].Context})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 2 || page[0].Context != bids[1].Context || page[1].Context != bids[2].Context {
+		t.Fatalf("expected the page after the first bid but got %+v", page)
+	}
+
+	minValue, maxValue := types.U256Str{11}, types.U256Str{12}
+	bids, err = store.GetBids(ctx, &types.BidQuery{StartSlot: 0, EndSlot: 20, Relay: &relayA, MinValue: &minValue, MaxValue: &maxValue})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bids) != 2 || bids[0].Context.Slot != 11 || bids[1].Context.Slot != 12 {
+		t.Fatalf("expected the bids of the relay within the value range but got %+v", bids)
+	}
+
+	bids, err = store.GetBids(ctx, &types.BidQuery{StartSlot: 0, EndSlot: 20, Builder: &builder})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bids) != 1 || bids[0].Context.Slot != 11 || *bids[0].Builder != builder {
+		t.Fatalf("expected the bid of the builder but got %+v", bids)
+	}
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+type builderRelay struct {
+	builder types.PublicKey
+	relay   types.PublicKey
+}
+
+func (s *MemoryStore) PutBuilderSubmission(ctx context.Context, builderPublicKey, relayPublicKey *types.PublicKey, slot types.Slot) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := builderRelay{builder: *builderPublicKey, relay: *relayPublicKey}
+	association, ok := s.builderRelays[key]
+	if !ok {
+		s.builderRelays[key] = &types.BuilderRelayAssociation{
+			RelayPublicKey: *relayPublicKey,
+			FirstSeenSlot:  slot,
+			LastSeenSlot:   slot,
+			Count:          1,
+		}
+		return nil
+	}
+	if slot < association.FirstSeenSlot {
+		association.FirstSeenSlot = slot
+	}
+	if slot > association.LastSeenSlot {
+		association.LastSeenSlot = slot
+	}
+	association.Count += 1
+	return nil
+}
+
+func (s *MemoryStore) GetBuilderRelays(ctx context.Context, builderPublicKey *types.PublicKey) ([]types.BuilderRelayAssociation, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var associations []types.BuilderRelayAssociation
+	for key, association := range s.builderRelays {
+		if key.builder != *builderPublicKey {
+			continue
+		}
+		associations = append(associations, *association)
+	}
+	return associations, nil
+}
+
+func (s *MemoryStore) PutDeliveredPayload(ctx context.Context, payload *types.DeliveredPayload) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	builder := payload.BidTrace.BuilderPubkey
+	s.deliveredPayloads[builder] = append(s.deliveredPayloads[builder], *payload)
+	return nil
+}
+
+func (s *MemoryStore) GetDeliveredPayloads(ctx context.Context, builderPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.DeliveredPayload, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var payloads []types.DeliveredPayload
+	for _, payload := range s.deliveredPayloads[*builderPublicKey] {
+		if payload.BidTrace.Slot < startSlot || payload.BidTrace.Slot > endSlot {
+			continue
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
@@ -0,0 +1,17 @@
+package store
+
+const (
+	// Bids are kept as decoded objects
+	ObjectBidEncoding = "object"
+	// Bids are kept SSZ-encoded, preserving their exact signing bytes at a fraction of the size
+	SSZBidEncoding = "ssz"
+)
+
+type Config struct {
+	// One of `object` (the default) or `ssz`
+	BidEncoding string `yaml:"bid_encoding"`
+	// If given, periodically delete data older than the configured retention
+	Retention *RetentionConfig `yaml:"retention"`
+	// If given, the bid analyses in this newline-delimited JSON file are loaded into the store on start
+	ReplayFile string `yaml:"replay_file"`
+}
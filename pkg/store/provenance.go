@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func (s *MemoryStore) PutBidProvenance(ctx context.Context, provenance *types.BidProvenance) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.provenances[provenance.Context] = append(s.provenances[provenance.Context], *provenance)
+	return nil
+}
+
+func (s *MemoryStore) GetBidProvenances(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidProvenance, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var provenances []types.BidProvenance
+	for bidCtx, contextProvenances := range s.provenances {
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		provenances = append(provenances, contextProvenances...)
+	}
+	sort.Slice(provenances, func(i, j int) bool {
+		a, b := provenances[i], provenances[j]
+		if a.Context.Slot != b.Context.Slot {
+			return a.Context.Slot < b.Context.Slot
+		}
+		if a.Context.RelayPublicKey != b.Context.RelayPublicKey {
+			return a.Context.RelayPublicKey.String() < b.Context.RelayPublicKey.String()
+		}
+		return a.Sample < b.Sample
+	})
+	return provenances, nil
+}
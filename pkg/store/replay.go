@@ -0,0 +1,46 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// `ReplayBidAnalyses` puts each newline-delimited JSON bid analysis read from `r` (as streamed by `/monitor/v1/tail`)
+// into the store and returns the number of analyses replayed
+func ReplayBidAnalyses(ctx context.Context, store Storer, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	count := 0
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var analysis types.BidAnalysis
+		err := json.Unmarshal(scanner.Bytes(), &analysis)
+		if err != nil {
+			return count, fmt.Errorf("could not decode bid analysis on line %d: %v", line, err)
+		}
+		err = store.PutBidAnalysis(ctx, &analysis)
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, scanner.Err()
+}
+
+// `ReplayBidAnalysesFromFile` replays the bid analyses in the file at `path` into the store
+func ReplayBidAnalysesFromFile(ctx context.Context, store Storer, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return ReplayBidAnalyses(ctx, store, f)
+}
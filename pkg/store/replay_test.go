@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestReplayBidAnalyses(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	lines := `{"context":{"slot":1}}
+
+{"context":{"slot":2},"category":"consensus_invalid","reason":"invalid signature"}
+`
+	count, err := ReplayBidAnalyses(ctx, store, strings.NewReader(lines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 analyses to be replayed, got %d", count)
+	}
+
+	analysis, err := store.GetBidAnalysis(ctx, &types.BidContext{Slot: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis == nil || analysis.Category != "consensus_invalid" {
+		t.Fatalf("expected replayed fault for slot 2, got %+v", analysis)
+	}
+
+	_, err = ReplayBidAnalyses(ctx, store, strings.NewReader("{\"context\":"))
+	if err == nil {
+		t.Fatal("expected error replaying truncated line")
+	}
+}
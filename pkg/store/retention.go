@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ralexstokes/relay-monitor/pkg/metrics"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultPruneInterval  = 10 * time.Minute
+	DefaultPruneBatchSize = 10000
+)
+
+type RetentionConfig struct {
+	// How long to keep bids along with their latencies, provenances, acceptances and winning bids, bids are kept forever if zero
+	Bids time.Duration `yaml:"bids"`
+	// How long to keep the analyses of bids, analyses are kept forever if zero
+	Analyses time.Duration `yaml:"analyses"`
+	// Time between runs of the pruner
+	Interval time.Duration `yaml:"interval"`
+	// Maximum number of entries deleted while holding the store lock, so pruning does not stall writes
+	BatchSize int `yaml:"batch_size"`
+}
+
+func (c *RetentionConfig) interval() time.Duration {
+	if c.Interval == 0 {
+		return DefaultPruneInterval
+	}
+	return c.Interval
+}
+
+func (c *RetentionConfig) batchSize() int {
+	if c.BatchSize == 0 {
+		return DefaultPruneBatchSize
+	}
+	return c.BatchSize
+}
+
+// `expiredKeys` returns the keys of the entries of `entries` from before `slot`
+func expiredKeys[V any](entries map[types.BidContext]V, slot types.Slot) []types.BidContext {
+	var keys []types.BidContext
+	for bidCtx := range entries {
+		if bidCtx.Slot < slot {
+			keys = append(keys, bidCtx)
+		}
+	}
+	return keys
+}
+
+// `prune` collects the entries of `entries` from before `slot` once under the read lock of `s`, then deletes them
+// in batches of up to `batchSize` entries under the write lock, and returns the number deleted from `table`
+func prune[V any](s *MemoryStore, entries map[types.BidContext]V, slot types.Slot, batchSize int, table string) int {
+	s.lock.RLock()
+	keys := expiredKeys(entries, slot)
+	s.lock.RUnlock()
+
+	total := 0
+	for len(keys) > 0 {
+		batch := keys
+		if batchSize > 0 && len(batch) > batchSize {
+			batch = keys[:batchSize]
+		}
+		keys = keys[len(batch):]
+
+		s.lock.Lock()
+		for _, bidCtx := range batch {
+			if _, ok := entries[bidCtx]; ok {
+				delete(entries, bidCtx)
+				total += 1
+			}
+		}
+		s.lock.Unlock()
+	}
+	metrics.RowsPruned.WithLabelValues(table).Add(float64(total))
+	return total
+}
+
+// `PruneBids` deletes the bids, bid latencies, bid samples, bid cancellations, bid provenances, acceptances and winning bids from before `slot`
+func (s *MemoryStore) PruneBids(slot types.Slot, batchSize int) int {
+	count := 0
+	if s.encodeBids {
+		count += prune(s, s.encodedBids, slot, batchSize, "bids")
+	} else {
+		count += prune(s, s.bids, slot, batchSize, "bids")
+	}
+	count += prune(s, s.bidLatencies, slot, batchSize, "bid_latencies")
+	count += prune(s, s.bidSamples, slot, batchSize, "bid_samples")
+	count += prune(s, s.cancellations, slot, batchSize, "bid_cancellations")
+	count += prune(s, s.provenances, slot, batchSize, "bid_provenances")
+	count += prune(s, s.acceptances, slot, batchSize, "acceptances")
+	count += prune(s, s.winningBids, slot, batchSize, "winning_bids")
+	return count
+}
+
+// `PruneAnalyses` deletes the analyses of bids and auction transcripts, along with the annotations of their faults, from before `slot`
+func (s *MemoryStore) PruneAnalyses(slot types.Slot, batchSize int) int {
+	count := prune(s, s.analyses, slot, batchSize, "bid_analyses")
+	// NOTE: the versions of the analyses are not counted as they include the analyses deleted above
+	prune(s, s.analysisVersions, slot, batchSize, "bid_analysis_versions")
+	count += prune(s, s.transcriptAnalyses, slot, batchSize, "transcript_analyses")
+	count += prune(s, s.faultAnnotations, slot, batchSize, "fault_annotations")
+	return count
+}
+
+// `RunPruner` periodically deletes the data of `s` which is older than its retention until `ctx` is done,
+// where `slotAt` gives the slot at the given unix time
+func RunPruner(ctx context.Context, s Pruner, config *RetentionConfig, zapLogger *zap.Logger, slotAt func(int64) types.Slot) {
+	logger := zapLogger.Sugar()
+
+	ticker := time.NewTicker(config.interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if config.Bids > 0 {
+				slot := slotAt(now.Add(-config.Bids).Unix())
+				count := s.PruneBids(slot, config.batchSize())
+				logger.Debugf("pruned %d bid entries from before slot %d", count, slot)
+			}
+			if config.Analyses > 0 {
+				slot := slotAt(now.Add(-config.Analyses).Unix())
+				count := s.PruneAnalyses(slot, config.batchSize())
+				logger.Debugf("pruned %d bid analyses from before slot %d", count, slot)
+			}
+		}
+	}
+}
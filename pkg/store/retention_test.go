@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestPruneBids(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	for slot := types.Slot(0); slot < 10; slot++ {
+		bidCtx := &types.BidContext{Slot: slot}
+		err := store.PutBid(ctx, bidCtx, &types.Bid{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = store.PutBidAnalysis(ctx, &types.BidAnalysis{Context: *bidCtx})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// NOTE: a batch size smaller than the number of entries to prune exercises the batching
+	count := store.PruneBids(4, 3)
+	if count != 4 {
+		t.Fatalf("expected 4 bid entries to be pruned, got %d", count)
+	}
+	_, err := store.GetBid(ctx, &types.BidContext{Slot: 3})
+	if err == nil {
+		t.Fatal("expected bid before the retention to be pruned")
+	}
+	_, err = store.GetBid(ctx, &types.BidContext{Slot: 4})
+	if err != nil {
+		t.Fatal("expected bid within the retention to be kept")
+	}
+
+	analysis, err := store.GetBidAnalysis(ctx, &types.BidContext{Slot: 3})
+	if err != nil || analysis == nil {
+		t.Fatal("expected analyses to be kept when pruning bids")
+	}
+	count = store.PruneAnalyses(4, 3)
+	if count != 4 {
+		t.Fatalf("expected 4 analyses to be pruned, got %d", count)
+	}
+}
@@ -3,48 +3,490 @@ package store
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/ralexstokes/relay-monitor/pkg/types"
 )
 
 type Storer interface {
 	PutBid(context.Context, *types.BidContext, *types.Bid) error
+	// `PutBidLatency` records the round-trip time of the `getHeader` request made for the given context.
+	PutBidLatency(context.Context, *types.BidContext, time.Duration) error
+	// `PutBidSample` records one of the bids requested for the context within its slot.
+	PutBidSample(context.Context, *types.BidSample) error
+	// `PutBidCancellation` records a sample where the relay withdrew or lowered its bid.
+	PutBidCancellation(context.Context, *types.BidCancellation) error
+	// `PutBidProvenance` records the raw response of the relay to a `getHeader` request made for the context.
+	PutBidProvenance(context.Context, *types.BidProvenance) error
 	PutValidatorRegistration(context.Context, *types.SignedValidatorRegistration) error
 	PutAcceptance(context.Context, *types.BidContext, *types.SignedBlindedBeaconBlock) error
+	PutBidAnalysis(context.Context, *types.BidAnalysis) error
+	// `PutTranscriptAnalysis` records an analysis of an accepted bid derived from its auction transcript.
+	PutTranscriptAnalysis(context.Context, *types.BidAnalysis) error
+	// `PutBuilderSubmission` records that the builder's block was delivered through the relay in the given slot.
+	PutBuilderSubmission(ctx context.Context, builderPublicKey, relayPublicKey *types.PublicKey, slot types.Slot) error
+	PutDeliveredPayload(context.Context, *types.DeliveredPayload) error
+	// `PutWinningBid` records that the accepted bid with the given context won its auction as its block is canonical.
+	PutWinningBid(context.Context, *types.WinningBid) error
+	// `PutRelayTags` replaces the tags of the relay.
+	PutRelayTags(ctx context.Context, relayPublicKey *types.PublicKey, tags []string) error
+	// `PutRelaySettings` replaces the collection settings of the relay.
+	PutRelaySettings(ctx context.Context, relayPublicKey *types.PublicKey, settings *types.RelaySettings) error
+	// `PutRelayMetadata` replaces the metadata of the relay.
+	PutRelayMetadata(ctx context.Context, relayPublicKey *types.PublicKey, metadata *types.RelayMetadata) error
+	// `PutRelayStatus` records the outcome of a probe of the relay's status in `slot`.
+	// Only the first probe of a slot is recorded and the status of the relay persists until a probe finds it changed.
+	PutRelayStatus(ctx context.Context, relayPublicKey *types.PublicKey, slot types.Slot, up bool) error
+	// `PutFaultAnnotation` attaches the annotation to the fault of the bid analysis with the annotation's context.
+	PutFaultAnnotation(context.Context, *types.FaultAnnotation) error
 
 	GetBid(context.Context, *types.BidContext) (*types.Bid, error)
 	// `GetValidatorRegistrations` returns all known registrations for the validator's public key, sorted by timestamp (increasing).
 	GetValidatorRegistrations(context.Context, *types.PublicKey) ([]types.SignedValidatorRegistration, error)
+	// `GetRegisteredValidators` returns the public keys of all validators with at least one known registration.
+	GetRegisteredValidators(context.Context) ([]types.PublicKey, error)
+	// `GetBidLatencies` returns the recorded `getHeader` round-trip times for the relay in the inclusive slot range.
+	GetBidLatencies(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]time.Duration, error)
+	// `GetBidAnalyses` returns the analyses of the relay's bids in the inclusive slot range.
+	GetBidAnalyses(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.BidAnalysis, error)
+	// `GetRulesetBidAnalyses` returns the analyses of the relay's bids in the inclusive slot range by `ruleset`, omitting bids it did not analyze.
+	GetRulesetBidAnalyses(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot, ruleset string) ([]types.BidAnalysis, error)
+	// `GetBidAnalysisVersions` returns the analyses of the bid with the given context by each ruleset which analyzed it, in the order the rulesets first analyzed it.
+	GetBidAnalysisVersions(ctx context.Context, bidCtx *types.BidContext) ([]types.BidAnalysis, error)
+	// `GetBidValues` returns the values of the relay's bids in the inclusive slot range.
+	GetBidValues(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.U256Str, error)
+	// `GetSlotBidValues` returns the values of the bids of all relays in the inclusive slot range.
+	GetSlotBidValues(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidValue, error)
+	// `GetProposerBids` returns the bids, and any analysis of them, made by all relays to the proposer in the inclusive slot range, ordered by slot.
+	GetProposerBids(ctx context.Context, proposerPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.ProposerBid, error)
+	// `GetBidSamples` returns the samples of the bids of all relays in the inclusive slot range, ordered by slot, relay and sample.
+	GetBidSamples(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidSample, error)
+	// `GetBidProvenances` returns the raw responses to the `getHeader` requests made to all relays in the inclusive slot range, ordered by slot, relay and sample.
+	GetBidProvenances(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidProvenance, error)
+	// `GetBidCancellations` returns the cancellations of bids of all relays in the inclusive slot range, ordered by slot.
+	GetBidCancellations(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidCancellation, error)
+	// `GetTranscriptAnalyses` returns the analyses derived from the auction transcripts of all relays in the inclusive slot range, ordered by slot.
+	GetTranscriptAnalyses(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidAnalysis, error)
+	// `GetSlotBids` returns the bids, and any analysis of them, made by all relays in the slot.
+	GetSlotBids(ctx context.Context, slot types.Slot) ([]types.ProposerBid, error)
+	// `GetBidLatency` returns the round-trip time of the `getHeader` request for the given context or `nil` if none was recorded.
+	GetBidLatency(context.Context, *types.BidContext) (*time.Duration, error)
+	// `GetBidAnalysis` returns the analysis of the bid with the given context or `nil` if the bid was not analyzed.
+	GetBidAnalysis(context.Context, *types.BidContext) (*types.BidAnalysis, error)
+	// `GetAcceptances` returns all known acceptances for the given slot.
+	GetAcceptances(ctx context.Context, slot types.Slot) ([]types.Acceptance, error)
+	// `GetProposerAcceptances` returns the acceptances of the proposer in the inclusive slot range, ordered by slot.
+	GetProposerAcceptances(ctx context.Context, proposerPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.Acceptance, error)
+	// `GetAcceptedBids` returns the contexts of the stored bids which a proposer accepted, in the inclusive slot range.
+	GetAcceptedBids(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidContext, error)
+	// `GetBuilderRelays` returns the relays the builder has been seen submitting through.
+	GetBuilderRelays(ctx context.Context, builderPublicKey *types.PublicKey) ([]types.BuilderRelayAssociation, error)
+	// `GetDeliveredPayloads` returns the payloads of the builder delivered by any relay in the inclusive slot range.
+	GetDeliveredPayloads(ctx context.Context, builderPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.DeliveredPayload, error)
+	// `GetWinningBids` returns the winning bids of all relays in the inclusive slot range.
+	GetWinningBids(ctx context.Context, startSlot, endSlot types.Slot) ([]types.WinningBid, error)
+	// `GetRelayTags` returns the tags of all relays with at least one tag.
+	GetRelayTags(ctx context.Context) ([]types.RelayTags, error)
+	// `GetRelaySettings` returns the collection settings of the relay or `nil` if none are known.
+	GetRelaySettings(ctx context.Context, relayPublicKey *types.PublicKey) (*types.RelaySettings, error)
+	// `GetRelayMetadata` returns the metadata of the relay or `nil` if none is known.
+	GetRelayMetadata(ctx context.Context, relayPublicKey *types.PublicKey) (*types.RelayMetadata, error)
+	// `GetRelayStatusIntervals` returns the intervals of the relay's status overlapping the inclusive slot range, ordered by slot.
+	GetRelayStatusIntervals(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.RelayStatusInterval, error)
+	// `GetBids` returns the stored bids matching the query, along with any analysis of them, ordered by slot, relay, proposer and parent hash.
+	GetBids(ctx context.Context, query *types.BidQuery) ([]types.ArchivedBid, error)
+	// `GetFaultAnnotations` returns the annotations of the faults of the relay in the inclusive slot range, ordered by slot and then by creation,
+	// or of the faults of all relays if `relayPublicKey` is `nil`.
+	GetFaultAnnotations(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.FaultAnnotation, error)
 }
 
+// `Pruner` is implemented by stores which support deleting old data to bound their size
+type Pruner interface {
+	// `PruneBids` deletes the bids and the data recorded alongside them from before `slot`, in batches of at most `batchSize`, and returns the number of deleted entries.
+	PruneBids(slot types.Slot, batchSize int) int
+	// `PruneAnalyses` deletes the bid and transcript analyses, along with the annotations of their faults, from before `slot`, in batches of at most `batchSize`, and returns the number of deleted entries.
+	PruneAnalyses(slot types.Slot, batchSize int) int
+}
+
+var (
+	_ Storer = (*MemoryStore)(nil)
+	_ Pruner = (*MemoryStore)(nil)
+)
+
 type MemoryStore struct {
-	bids          map[types.BidContext]*types.Bid
+	lock sync.RWMutex
+
+	bids map[types.BidContext]*types.Bid
+	// SSZ-encoding of each bid if bids are archived, where an empty encoding indicates the absence of a bid
+	encodedBids   map[types.BidContext][]byte
+	encodeBids    bool
+	bidLatencies  map[types.BidContext]time.Duration
+	bidSamples    map[types.BidContext][]types.BidSample
+	cancellations map[types.BidContext][]types.BidCancellation
+	// raw responses to the `getHeader` requests of each context
+	provenances   map[types.BidContext][]types.BidProvenance
 	registrations map[types.PublicKey][]types.SignedValidatorRegistration
 	acceptances   map[types.BidContext]types.SignedBlindedBeaconBlock
+	analyses      map[types.BidContext]types.BidAnalysis
+	// analyses of each bid by each ruleset, where `analyses` holds the latest
+	analysisVersions map[types.BidContext][]types.BidAnalysis
+	// analyses of accepted bids derived from their auction transcripts
+	transcriptAnalyses map[types.BidContext][]types.BidAnalysis
+	builderRelays      map[builderRelay]*types.BuilderRelayAssociation
+	// builder -> payloads delivered by relays
+	deliveredPayloads map[types.PublicKey][]types.DeliveredPayload
+	winningBids       map[types.BidContext]types.WinningBid
+	relayTags         map[types.PublicKey][]string
+	relaySettings     map[types.PublicKey]types.RelaySettings
+	relayMetadata     map[types.PublicKey]types.RelayMetadata
+	// relay -> intervals of the relay's status, ordered by slot
+	relayStatus map[types.PublicKey][]types.RelayStatusInterval
+	// annotations of the faults of bid and transcript analyses, in the order they were made
+	faultAnnotations map[types.BidContext][]types.FaultAnnotation
 }
 
 func NewMemoryStore() *MemoryStore {
-	return &MemoryStore{
-		bids:          make(map[types.BidContext]*types.Bid),
-		registrations: make(map[types.PublicKey][]types.SignedValidatorRegistration),
-		acceptances:   make(map[types.BidContext]types.SignedBlindedBeaconBlock),
+	store, _ := NewMemoryStoreFromConfig(nil)
+	return store
+}
+
+func NewMemoryStoreFromConfig(config *Config) (*MemoryStore, error) {
+	encodeBids := false
+	if config != nil {
+		switch config.BidEncoding {
+		case "", ObjectBidEncoding:
+		case SSZBidEncoding:
+			encodeBids = true
+		default:
+			return nil, fmt.Errorf("unknown bid encoding %s", config.BidEncoding)
+		}
 	}
+	return &MemoryStore{
+		bids:               make(map[types.BidContext]*types.Bid),
+		encodedBids:        make(map[types.BidContext][]byte),
+		encodeBids:         encodeBids,
+		bidLatencies:       make(map[types.BidContext]time.Duration),
+		bidSamples:         make(map[types.BidContext][]types.BidSample),
+		cancellations:      make(map[types.BidContext][]types.BidCancellation),
+		provenances:        make(map[types.BidContext][]types.BidProvenance),
+		registrations:      make(map[types.PublicKey][]types.SignedValidatorRegistration),
+		acceptances:        make(map[types.BidContext]types.SignedBlindedBeaconBlock),
+		analyses:           make(map[types.BidContext]types.BidAnalysis),
+		analysisVersions:   make(map[types.BidContext][]types.BidAnalysis),
+		transcriptAnalyses: make(map[types.BidContext][]types.BidAnalysis),
+		builderRelays:      make(map[builderRelay]*types.BuilderRelayAssociation),
+		deliveredPayloads:  make(map[types.PublicKey][]types.DeliveredPayload),
+		winningBids:        make(map[types.BidContext]types.WinningBid),
+		relayTags:          make(map[types.PublicKey][]string),
+		relaySettings:      make(map[types.PublicKey]types.RelaySettings),
+		relayMetadata:      make(map[types.PublicKey]types.RelayMetadata),
+		relayStatus:        make(map[types.PublicKey][]types.RelayStatusInterval),
+		faultAnnotations:   make(map[types.BidContext][]types.FaultAnnotation),
+	}, nil
 }
 
 func (s *MemoryStore) PutBid(ctx context.Context, bidCtx *types.BidContext, bid *types.Bid) error {
-	s.bids[*bidCtx] = bid
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.encodeBids {
+		s.bids[*bidCtx] = bid
+		return nil
+	}
+
+	var encodedBid []byte
+	if bid != nil {
+		var err error
+		encodedBid, err = bid.MarshalSSZ()
+		if err != nil {
+			return fmt.Errorf("could not encode bid for %+v: %v", bidCtx, err)
+		}
+	}
+	s.encodedBids[*bidCtx] = encodedBid
+	return nil
+}
+
+func (s *MemoryStore) PutBidLatency(ctx context.Context, bidCtx *types.BidContext, latency time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.bidLatencies[*bidCtx] = latency
 	return nil
 }
 
+func (s *MemoryStore) PutBidSample(ctx context.Context, sample *types.BidSample) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.bidSamples[sample.Context] = append(s.bidSamples[sample.Context], *sample)
+	return nil
+}
+
+func (s *MemoryStore) GetBidSamples(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidSample, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var samples []types.BidSample
+	for bidCtx, contextSamples := range s.bidSamples {
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		samples = append(samples, contextSamples...)
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		a, b := samples[i], samples[j]
+		if a.Context.Slot != b.Context.Slot {
+			return a.Context.Slot < b.Context.Slot
+		}
+		if a.Context.RelayPublicKey != b.Context.RelayPublicKey {
+			return a.Context.RelayPublicKey.String() < b.Context.RelayPublicKey.String()
+		}
+		return a.Sample < b.Sample
+	})
+	return samples, nil
+}
+
+func (s *MemoryStore) PutBidCancellation(ctx context.Context, cancellation *types.BidCancellation) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cancellations[cancellation.Context] = append(s.cancellations[cancellation.Context], *cancellation)
+	return nil
+}
+
+func (s *MemoryStore) GetBidCancellations(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidCancellation, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var cancellations []types.BidCancellation
+	for bidCtx, contextCancellations := range s.cancellations {
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		cancellations = append(cancellations, contextCancellations...)
+	}
+	sort.Slice(cancellations, func(i, j int) bool {
+		a, b := cancellations[i], cancellations[j]
+		if a.Context.Slot != b.Context.Slot {
+			return a.Context.Slot < b.Context.Slot
+		}
+		return a.Sample < b.Sample
+	})
+	return cancellations, nil
+}
+
+func (s *MemoryStore) PutTranscriptAnalysis(ctx context.Context, analysis *types.BidAnalysis) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.transcriptAnalyses[analysis.Context] = append(s.transcriptAnalyses[analysis.Context], *analysis)
+	return nil
+}
+
+func (s *MemoryStore) GetTranscriptAnalyses(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidAnalysis, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var analyses []types.BidAnalysis
+	for bidCtx, contextAnalyses := range s.transcriptAnalyses {
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		analyses = append(analyses, contextAnalyses...)
+	}
+	sort.SliceStable(analyses, func(i, j int) bool {
+		a, b := analyses[i], analyses[j]
+		if a.Context.Slot != b.Context.Slot {
+			return a.Context.Slot < b.Context.Slot
+		}
+		return a.Context.RelayPublicKey.String() < b.Context.RelayPublicKey.String()
+	})
+	return analyses, nil
+}
+
+func (s *MemoryStore) GetBidLatencies(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]time.Duration, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var latencies []time.Duration
+	for bidCtx, latency := range s.bidLatencies {
+		if bidCtx.RelayPublicKey != *relayPublicKey {
+			continue
+		}
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		latencies = append(latencies, latency)
+	}
+	return latencies, nil
+}
+
 func (s *MemoryStore) GetBid(ctx context.Context, bidCtx *types.BidContext) (*types.Bid, error) {
-	bid, ok := s.bids[*bidCtx]
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.getBid(bidCtx)
+}
+
+// `getBid` decodes the bid for `bidCtx` if required, the caller must hold the lock
+func (s *MemoryStore) getBid(bidCtx *types.BidContext) (*types.Bid, error) {
+	if !s.encodeBids {
+		bid, ok := s.bids[*bidCtx]
+		if !ok {
+			return nil, fmt.Errorf("could not find bid for %+v", bidCtx)
+		}
+		return bid, nil
+	}
+
+	encodedBid, ok := s.encodedBids[*bidCtx]
 	if !ok {
 		return nil, fmt.Errorf("could not find bid for %+v", bidCtx)
 	}
+	if len(encodedBid) == 0 {
+		return nil, nil
+	}
+	bid := &types.Bid{}
+	err := bid.UnmarshalSSZ(encodedBid)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode bid for %+v: %v", bidCtx, err)
+	}
 	return bid, nil
 }
 
+// `bidContexts` returns the contexts of all known bids, the caller must hold the lock
+func (s *MemoryStore) bidContexts() []types.BidContext {
+	var contexts []types.BidContext
+	if s.encodeBids {
+		for bidCtx := range s.encodedBids {
+			contexts = append(contexts, bidCtx)
+		}
+	} else {
+		for bidCtx := range s.bids {
+			contexts = append(contexts, bidCtx)
+		}
+	}
+	return contexts
+}
+
+func (s *MemoryStore) GetBidValues(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.U256Str, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	contexts := s.bidContexts()
+
+	var values []types.U256Str
+	for _, bidCtx := range contexts {
+		bidCtx := bidCtx
+		if bidCtx.RelayPublicKey != *relayPublicKey {
+			continue
+		}
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		bid, err := s.getBid(&bidCtx)
+		if err != nil {
+			return nil, err
+		}
+		if bid == nil {
+			continue
+		}
+		values = append(values, bid.Message.Value)
+	}
+	return values, nil
+}
+
+func (s *MemoryStore) GetSlotBidValues(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidValue, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var values []types.BidValue
+	for _, bidCtx := range s.bidContexts() {
+		bidCtx := bidCtx
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		bid, err := s.getBid(&bidCtx)
+		if err != nil {
+			return nil, err
+		}
+		if bid == nil {
+			continue
+		}
+		values = append(values, types.BidValue{
+			Context:   bidCtx,
+			Value:     bid.Message.Value,
+			BlockHash: bid.Message.Header.BlockHash,
+		})
+	}
+	return values, nil
+}
+
+func (s *MemoryStore) GetProposerBids(ctx context.Context, proposerPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.ProposerBid, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.getBidsWhere(func(bidCtx *types.BidContext) bool {
+		return bidCtx.ProposerPublicKey == *proposerPublicKey && bidCtx.Slot >= startSlot && bidCtx.Slot <= endSlot
+	})
+}
+
+func (s *MemoryStore) GetSlotBids(ctx context.Context, slot types.Slot) ([]types.ProposerBid, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.getBidsWhere(func(bidCtx *types.BidContext) bool {
+		return bidCtx.Slot == slot
+	})
+}
+
+// `getBidsWhere` returns the bids whose context matches `filter` along with their analyses, ordered by slot,
+// the caller must hold the lock
+func (s *MemoryStore) getBidsWhere(filter func(*types.BidContext) bool) ([]types.ProposerBid, error) {
+	var bids []types.ProposerBid
+	for _, bidCtx := range s.bidContexts() {
+		bidCtx := bidCtx
+		if !filter(&bidCtx) {
+			continue
+		}
+		bid, err := s.getBid(&bidCtx)
+		if err != nil {
+			return nil, err
+		}
+		proposerBid := types.ProposerBid{
+			Context: bidCtx,
+			Bid:     bid,
+		}
+		if analysis, ok := s.analyses[bidCtx]; ok {
+			proposerBid.Analysis = &analysis
+		}
+		if _, ok := s.acceptances[bidCtx]; ok && bid != nil {
+			proposerBid.Accepted = true
+		}
+		bids = append(bids, proposerBid)
+	}
+	sort.Slice(bids, func(i, j int) bool {
+		return bids[i].Context.Slot < bids[j].Context.Slot
+	})
+	return bids, nil
+}
+
+func (s *MemoryStore) GetBidLatency(ctx context.Context, bidCtx *types.BidContext) (*time.Duration, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	latency, ok := s.bidLatencies[*bidCtx]
+	if !ok {
+		return nil, nil
+	}
+	return &latency, nil
+}
+
 func (s *MemoryStore) PutValidatorRegistration(ctx context.Context, registration *types.SignedValidatorRegistration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	publicKey := registration.Message.Pubkey
 	registrations := s.registrations[publicKey]
 	registrations = append(registrations, *registration)
@@ -53,10 +495,314 @@ func (s *MemoryStore) PutValidatorRegistration(ctx context.Context, registration
 }
 
 func (s *MemoryStore) PutAcceptance(ctx context.Context, bidCtx *types.BidContext, acceptance *types.SignedBlindedBeaconBlock) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	s.acceptances[*bidCtx] = *acceptance
 	return nil
 }
 
+func (s *MemoryStore) GetAcceptances(ctx context.Context, slot types.Slot) ([]types.Acceptance, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var acceptances []types.Acceptance
+	for bidCtx, acceptance := range s.acceptances {
+		if bidCtx.Slot != slot {
+			continue
+		}
+		acceptances = append(acceptances, types.Acceptance{
+			Context:                  bidCtx,
+			SignedBlindedBeaconBlock: acceptance,
+		})
+	}
+	return acceptances, nil
+}
+
+func (s *MemoryStore) GetProposerAcceptances(ctx context.Context, proposerPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.Acceptance, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var acceptances []types.Acceptance
+	for bidCtx, acceptance := range s.acceptances {
+		if bidCtx.ProposerPublicKey != *proposerPublicKey || bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		acceptances = append(acceptances, types.Acceptance{
+			Context:                  bidCtx,
+			SignedBlindedBeaconBlock: acceptance,
+		})
+	}
+	sort.Slice(acceptances, func(i, j int) bool {
+		return compareBidContexts(&acceptances[i].Context, &acceptances[j].Context) < 0
+	})
+	return acceptances, nil
+}
+
+func (s *MemoryStore) GetAcceptedBids(ctx context.Context, startSlot, endSlot types.Slot) ([]types.BidContext, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var contexts []types.BidContext
+	for bidCtx := range s.acceptances {
+		bidCtx := bidCtx
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		// NOTE: only count acceptances of bids the monitor observed
+		bid, err := s.getBid(&bidCtx)
+		if err != nil || bid == nil {
+			continue
+		}
+		contexts = append(contexts, bidCtx)
+	}
+	return contexts, nil
+}
+
+func (s *MemoryStore) PutWinningBid(ctx context.Context, winningBid *types.WinningBid) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.winningBids[winningBid.Context] = *winningBid
+	return nil
+}
+
+func (s *MemoryStore) GetWinningBids(ctx context.Context, startSlot, endSlot types.Slot) ([]types.WinningBid, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var winningBids []types.WinningBid
+	for bidCtx, winningBid := range s.winningBids {
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		winningBids = append(winningBids, winningBid)
+	}
+	return winningBids, nil
+}
+
 func (s *MemoryStore) GetValidatorRegistrations(ctx context.Context, publicKey *types.PublicKey) ([]types.SignedValidatorRegistration, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
 	return s.registrations[*publicKey], nil
 }
+
+func (s *MemoryStore) GetRegisteredValidators(ctx context.Context) ([]types.PublicKey, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	publicKeys := make([]types.PublicKey, 0, len(s.registrations))
+	for publicKey := range s.registrations {
+		publicKeys = append(publicKeys, publicKey)
+	}
+	return publicKeys, nil
+}
+
+func (s *MemoryStore) PutBidAnalysis(ctx context.Context, analysis *types.BidAnalysis) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.analyses[analysis.Context] = *analysis
+
+	versions := s.analysisVersions[analysis.Context]
+	for i := range versions {
+		if versions[i].Ruleset == analysis.Ruleset {
+			versions[i] = *analysis
+			return nil
+		}
+	}
+	s.analysisVersions[analysis.Context] = append(versions, *analysis)
+	return nil
+}
+
+func (s *MemoryStore) GetRulesetBidAnalyses(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot, ruleset string) ([]types.BidAnalysis, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var analyses []types.BidAnalysis
+	for bidCtx, versions := range s.analysisVersions {
+		if bidCtx.RelayPublicKey != *relayPublicKey {
+			continue
+		}
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		for _, analysis := range versions {
+			if analysis.Ruleset == ruleset {
+				analyses = append(analyses, analysis)
+			}
+		}
+	}
+	return analyses, nil
+}
+
+func (s *MemoryStore) GetBidAnalysisVersions(ctx context.Context, bidCtx *types.BidContext) ([]types.BidAnalysis, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	versions := s.analysisVersions[*bidCtx]
+	return append([]types.BidAnalysis(nil), versions...), nil
+}
+
+func (s *MemoryStore) GetBidAnalyses(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.BidAnalysis, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var analyses []types.BidAnalysis
+	for bidCtx, analysis := range s.analyses {
+		if bidCtx.RelayPublicKey != *relayPublicKey {
+			continue
+		}
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		analyses = append(analyses, analysis)
+	}
+	return analyses, nil
+}
+
+func (s *MemoryStore) GetBidAnalysis(ctx context.Context, bidCtx *types.BidContext) (*types.BidAnalysis, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	analysis, ok := s.analyses[*bidCtx]
+	if !ok {
+		return nil, nil
+	}
+	return &analysis, nil
+}
+
+func (s *MemoryStore) PutRelayTags(ctx context.Context, relayPublicKey *types.PublicKey, tags []string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(tags) == 0 {
+		delete(s.relayTags, *relayPublicKey)
+		return nil
+	}
+	s.relayTags[*relayPublicKey] = append([]string(nil), tags...)
+	return nil
+}
+
+func (s *MemoryStore) GetRelayTags(ctx context.Context) ([]types.RelayTags, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var relayTags []types.RelayTags
+	for relay, tags := range s.relayTags {
+		relayTags = append(relayTags, types.RelayTags{
+			RelayPublicKey: relay,
+			Tags:           append([]string(nil), tags...),
+		})
+	}
+	return relayTags, nil
+}
+
+func (s *MemoryStore) PutRelaySettings(ctx context.Context, relayPublicKey *types.PublicKey, settings *types.RelaySettings) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.relaySettings[*relayPublicKey] = *settings
+	return nil
+}
+
+func (s *MemoryStore) GetRelaySettings(ctx context.Context, relayPublicKey *types.PublicKey) (*types.RelaySettings, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	settings, ok := s.relaySettings[*relayPublicKey]
+	if !ok {
+		return nil, nil
+	}
+	return &settings, nil
+}
+
+func (s *MemoryStore) PutRelayMetadata(ctx context.Context, relayPublicKey *types.PublicKey, metadata *types.RelayMetadata) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.relayMetadata[*relayPublicKey] = *metadata
+	return nil
+}
+
+func (s *MemoryStore) GetRelayMetadata(ctx context.Context, relayPublicKey *types.PublicKey) (*types.RelayMetadata, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	metadata, ok := s.relayMetadata[*relayPublicKey]
+	if !ok {
+		return nil, nil
+	}
+	return &metadata, nil
+}
+
+func (s *MemoryStore) PutRelayStatus(ctx context.Context, relayPublicKey *types.PublicKey, slot types.Slot, up bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	intervals := s.relayStatus[*relayPublicKey]
+	if len(intervals) > 0 {
+		last := &intervals[len(intervals)-1]
+		if slot <= last.EndSlot {
+			return nil
+		}
+		if last.Up == up {
+			last.EndSlot = slot
+			return nil
+		}
+		// the previous status persisted until the slot before the change was found
+		last.EndSlot = slot - 1
+	}
+	s.relayStatus[*relayPublicKey] = append(intervals, types.RelayStatusInterval{
+		Up:        up,
+		StartSlot: slot,
+		EndSlot:   slot,
+	})
+	return nil
+}
+
+func (s *MemoryStore) GetRelayStatusIntervals(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.RelayStatusInterval, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var intervals []types.RelayStatusInterval
+	for _, interval := range s.relayStatus[*relayPublicKey] {
+		if interval.EndSlot < startSlot || interval.StartSlot > endSlot {
+			continue
+		}
+		intervals = append(intervals, interval)
+	}
+	return intervals, nil
+}
+
+func (s *MemoryStore) PutFaultAnnotation(ctx context.Context, annotation *types.FaultAnnotation) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.faultAnnotations[annotation.Context] = append(s.faultAnnotations[annotation.Context], *annotation)
+	return nil
+}
+
+func (s *MemoryStore) GetFaultAnnotations(ctx context.Context, relayPublicKey *types.PublicKey, startSlot, endSlot types.Slot) ([]types.FaultAnnotation, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var annotations []types.FaultAnnotation
+	for bidCtx, entries := range s.faultAnnotations {
+		if bidCtx.Slot < startSlot || bidCtx.Slot > endSlot {
+			continue
+		}
+		if relayPublicKey != nil && bidCtx.RelayPublicKey != *relayPublicKey {
+			continue
+		}
+		annotations = append(annotations, entries...)
+	}
+	sort.SliceStable(annotations, func(i, j int) bool {
+		if annotations[i].Context.Slot != annotations[j].Context.Slot {
+			return annotations[i].Context.Slot < annotations[j].Context.Slot
+		}
+		return annotations[i].CreatedAt.Before(annotations[j].CreatedAt)
+	})
+	return annotations, nil
+}
@@ -0,0 +1,290 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	boostTypes "github.com/flashbots/go-boost-utils/types"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+func TestSSZBidEncoding(t *testing.T) {
+	store, err := NewMemoryStoreFromConfig(&Config{BidEncoding: SSZBidEncoding})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	bid := &types.Bid{
+		Message: &boostTypes.BuilderBid{
+			Header: &boostTypes.ExecutionPayloadHeader{
+				BlockNumber: 1000,
+				GasLimit:    30_000_000,
+			},
+		},
+	}
+	bid.Message.Header.ExtraData = []byte("relay-monitor")
+	bid.Message.Value[0] = 1
+	bidCtx := &types.BidContext{Slot: 10}
+	err = store.PutBid(ctx, bidCtx, bid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	absentCtx := &types.BidContext{Slot: 11}
+	err = store.PutBid(ctx, absentCtx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedBid, err := store.GetBid(ctx, bidCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := bid.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := storedBid.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(expected) != string(actual) {
+		t.Fatal("bid did not round trip through the SSZ encoding")
+	}
+
+	absentBid, err := store.GetBid(ctx, absentCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if absentBid != nil {
+		t.Fatal("expected absence of bid to be preserved")
+	}
+
+	_, err = store.GetBid(ctx, &types.BidContext{Slot: 12})
+	if err == nil {
+		t.Fatal("expected error for unknown bid")
+	}
+}
+
+func newBenchmarkBid() *types.Bid {
+	bid := &types.Bid{
+		Message: &boostTypes.BuilderBid{
+			Header: &boostTypes.ExecutionPayloadHeader{
+				BlockNumber: 15_820_000,
+				GasLimit:    30_000_000,
+				GasUsed:     12_947_889,
+				Timestamp:   1_666_641_635,
+			},
+		},
+	}
+	bid.Message.Header.ExtraData = []byte("relay-monitor")
+	bid.Message.Value[0] = 1
+	return bid
+}
+
+func BenchmarkBidDecoding(b *testing.B) {
+	bid := newBenchmarkBid()
+
+	b.Run("json", func(b *testing.B) {
+		data, err := json.Marshal(bid)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var decoded types.Bid
+			err := json.Unmarshal(data, &decoded)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ssz", func(b *testing.B) {
+		data, err := bid.MarshalSSZ()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var decoded types.Bid
+			err := decoded.UnmarshalSSZ(data)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkPutBid(b *testing.B) {
+	for _, encoding := range []string{ObjectBidEncoding, SSZBidEncoding} {
+		b.Run(encoding, func(b *testing.B) {
+			store, err := NewMemoryStoreFromConfig(&Config{BidEncoding: encoding})
+			if err != nil {
+				b.Fatal(err)
+			}
+			ctx := context.Background()
+			bid := newBenchmarkBid()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				err := store.PutBid(ctx, &types.BidContext{Slot: types.Slot(i)}, bid)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPutBidAnalysis(b *testing.B) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analysis := &types.BidAnalysis{
+			Context: types.BidContext{Slot: types.Slot(i)},
+		}
+		if i%10 == 0 {
+			analysis.Category = "consensus_invalid"
+			analysis.Reason = fmt.Sprintf("invalid bid %d", i)
+		}
+		err := store.PutBidAnalysis(ctx, analysis)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestGetBidSamples(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	value := types.U256Str{1}
+	for _, sample := range []types.BidSample{
+		{Context: types.BidContext{Slot: 11}, Sample: 0, Value: &value},
+		{Context: types.BidContext{Slot: 10}, Sample: 1},
+		{Context: types.BidContext{Slot: 10}, Sample: 0, Value: &value},
+		{Context: types.BidContext{Slot: 12}, Sample: 0, Value: &value},
+	} {
+		sample := sample
+		err := store.PutBidSample(ctx, &sample)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	samples, err := store.GetBidSamples(ctx, 10, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 3 {
+		t.Fatalf("expected 3 samples in range, got %d", len(samples))
+	}
+	for i, expected := range []struct {
+		slot   types.Slot
+		sample uint
+	}{{10, 0}, {10, 1}, {11, 0}} {
+		if samples[i].Context.Slot != expected.slot || samples[i].Sample != expected.sample {
+			t.Fatalf("expected sample %d of slot %d at %d, got %+v", expected.sample, expected.slot, i, samples[i])
+		}
+	}
+	if samples[1].Value != nil {
+		t.Fatal("expected absent bid in sample to be preserved")
+	}
+}
+
+func TestBidAnalysisVersions(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	bidCtx := types.BidContext{Slot: 10}
+	for _, analysis := range []types.BidAnalysis{
+		{Context: bidCtx, Category: "consensus_invalid", Ruleset: "v1"},
+		{Context: bidCtx, Ruleset: "v2"},
+		{Context: bidCtx, Category: "consensus_invalid", Ruleset: "v1"},
+	} {
+		analysis := analysis
+		err := store.PutBidAnalysis(ctx, &analysis)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	latest, err := store.GetBidAnalysis(ctx, &bidCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Ruleset != "v1" {
+		t.Fatalf("expected latest analysis of ruleset v1, got %+v", latest)
+	}
+	versions, err := store.GetBidAnalysisVersions(ctx, &bidCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 || versions[0].Ruleset != "v1" || versions[1].Ruleset != "v2" {
+		t.Fatalf("expected one analysis of each ruleset in the order they first analyzed the bid, got %+v", versions)
+	}
+
+	analyses, err := store.GetRulesetBidAnalyses(ctx, &bidCtx.RelayPublicKey, 0, 10, "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analyses) != 1 || analyses[0].Category != "" {
+		t.Fatalf("expected the valid analysis of ruleset v2, got %+v", analyses)
+	}
+	analyses, err = store.GetRulesetBidAnalyses(ctx, &bidCtx.RelayPublicKey, 0, 10, "v3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analyses) != 0 {
+		t.Fatalf("expected no analyses of an unknown ruleset, got %+v", analyses)
+	}
+}
+
+func TestRelayStatusIntervals(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	relay := &types.PublicKey{1}
+
+	for _, probe := range []struct {
+		slot types.Slot
+		up   bool
+	}{
+		{slot: 10, up: true},
+		{slot: 11, up: true},
+		// only the first probe of a slot is recorded
+		{slot: 11, up: false},
+		{slot: 14, up: false},
+		{slot: 15, up: false},
+		{slot: 20, up: true},
+	} {
+		err := store.PutRelayStatus(ctx, relay, probe.slot, probe.up)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	intervals, err := store.GetRelayStatusIntervals(ctx, relay, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []types.RelayStatusInterval{
+		{Up: true, StartSlot: 10, EndSlot: 13},
+		{Up: false, StartSlot: 14, EndSlot: 19},
+		{Up: true, StartSlot: 20, EndSlot: 20},
+	}
+	if fmt.Sprint(intervals) != fmt.Sprint(expected) {
+		t.Fatalf("expected intervals %+v but got %+v", expected, intervals)
+	}
+
+	intervals, err = store.GetRelayStatusIntervals(ctx, relay, 15, 19)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(intervals) != 1 || intervals[0].Up {
+		t.Fatalf("expected only the down interval but got %+v", intervals)
+	}
+}
@@ -0,0 +1,16 @@
+package tracing
+
+import "context"
+
+type requestIDKey struct{}
+
+// `WithRequestID` returns a context carrying the ID of the API request it serves, so work done for the request can be attributed to it
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// `RequestID` returns the ID of the API request `ctx` serves, if any
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,71 @@
+// Package tracing exports traces of the processing of each bid by the monitor to an OTLP endpoint
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	DefaultServiceName = "relay-monitor"
+	DefaultSampleRatio = 1.0
+
+	instrumentationName = "github.com/ralexstokes/relay-monitor"
+)
+
+type Config struct {
+	// Address of the OTLP gRPC endpoint, e.g. `localhost:4317`
+	Endpoint string `yaml:"endpoint"`
+	Insecure bool   `yaml:"insecure"`
+	// Fraction of bids to trace
+	SampleRatio *float64 `yaml:"sample_ratio"`
+	ServiceName string   `yaml:"service_name"`
+}
+
+func (c *Config) sampleRatio() float64 {
+	if c == nil || c.SampleRatio == nil {
+		return DefaultSampleRatio
+	}
+	return *c.SampleRatio
+}
+
+func (c *Config) serviceName() string {
+	if c == nil || c.ServiceName == "" {
+		return DefaultServiceName
+	}
+	return c.ServiceName
+}
+
+// `Start` installs a tracer provider exporting to the configured endpoint and returns a function to flush and stop it.
+// Until `Start` is called, spans are not recorded.
+func Start(ctx context.Context, config *Config) (func(context.Context) error, error) {
+	options := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(config.Endpoint),
+	}
+	if config.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("could not instantiate trace exporter: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.sampleRatio()))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(config.serviceName()))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/flashbots/go-boost-utils/types"
 	"github.com/holiman/uint256"
 )
@@ -17,6 +19,10 @@ type (
 	ValidatorIndex              = uint64
 	SignedValidatorRegistration = types.SignedValidatorRegistration
 	SignedBlindedBeaconBlock    = types.SignedBlindedBeaconBlock
+	ExecutionPayload            = types.ExecutionPayload
+	BidTrace                    = types.BidTrace
+	Address                     = types.Address
+	U256Str                     = types.U256Str
 )
 
 type Coordinate struct {
@@ -35,3 +41,203 @@ type BidContext struct {
 	ProposerPublicKey PublicKey `json:"proposer_public_key"`
 	RelayPublicKey    PublicKey `json:"relay_public_key"`
 }
+
+// `BidAnalysis` records the outcome of the analysis of a bid
+type BidAnalysis struct {
+	Context BidContext `json:"context"`
+	// An empty `Category` indicates a valid bid
+	Category string `json:"category,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	// Categories of analysis which were not run for the bid as they are disabled for the relay
+	SkippedByPolicy []string `json:"skipped_by_policy,omitempty"`
+	// Validation rule the bid fails, if the fault is of a configurable rule
+	Rule string `json:"rule,omitempty"`
+	// Severity of the fault: `critical`, `major` or `minor`
+	Severity string `json:"severity,omitempty"`
+	// Identifier of the rules of bid validation which produced the analysis
+	Ruleset string `json:"ruleset,omitempty"`
+}
+
+const (
+	// The operator of the relay disputes the fault
+	DisputeAnnotationKind = "dispute"
+	// The operator of the relay gives context for the fault without disputing it
+	NoteAnnotationKind = "annotation"
+)
+
+// `FaultAnnotation` is context attached by the operator of a relay to the fault of the bid analysis with the given context
+type FaultAnnotation struct {
+	Context BidContext `json:"context"`
+	// Either `dispute` or `annotation`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	// Time the monitor received the annotation
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// `Acceptance` is the signed blinded beacon block a proposer returned to accept the bid with the given context
+type Acceptance struct {
+	Context                  BidContext               `json:"context"`
+	SignedBlindedBeaconBlock SignedBlindedBeaconBlock `json:"signed_blinded_beacon_block"`
+}
+
+// `BuilderRelayAssociation` summarizes the blocks of a builder delivered through a relay
+type BuilderRelayAssociation struct {
+	RelayPublicKey PublicKey `json:"relay_public_key"`
+	FirstSeenSlot  Slot      `json:"first_seen_slot,string"`
+	LastSeenSlot   Slot      `json:"last_seen_slot,string"`
+	Count          uint      `json:"count"`
+}
+
+// `DeliveredPayload` is the trace of a payload the relay reports it delivered
+type DeliveredPayload struct {
+	RelayPublicKey PublicKey `json:"relay_public_key"`
+	BidTrace       BidTrace  `json:"bid_trace"`
+}
+
+// `ProposerBid` is a bid made to a proposer together with its analysis, if any
+type ProposerBid struct {
+	Context BidContext `json:"context"`
+	// A `nil` `Bid` indicates the relay had no bid for the `Context`
+	Bid      *Bid         `json:"bid"`
+	Analysis *BidAnalysis `json:"analysis,omitempty"`
+	// Whether a proposer accepted the bid, as reported with an auction transcript
+	Accepted bool `json:"accepted"`
+}
+
+// `BidQuery` selects bids from the archive of stored bids, where unset filters match every bid
+type BidQuery struct {
+	StartSlot Slot
+	EndSlot   Slot
+	Relay     *PublicKey
+	// Builder of the block of the bid, as given by the payloads delivered by relays
+	Builder  *PublicKey
+	Proposer *PublicKey
+	// Inclusive bounds of the value of the bid, in wei
+	MinValue *U256Str
+	MaxValue *U256Str
+	// If given, only bids ordered after the bid with this context are returned
+	After *BidContext
+	// Maximum number of bids returned, every matching bid if zero
+	Limit int
+}
+
+// `ArchivedBid` is a stored bid together with its analysis, if any
+type ArchivedBid struct {
+	Context   BidContext `json:"context"`
+	Value     U256Str    `json:"value"`
+	BlockHash Hash       `json:"block_hash"`
+	// Builder of the block of the bid, if a relay delivered its payload
+	Builder  *PublicKey   `json:"builder_public_key,omitempty"`
+	Analysis *BidAnalysis `json:"analysis,omitempty"`
+	// Whether a proposer accepted the bid, as reported with an auction transcript
+	Accepted bool `json:"accepted"`
+	Bid      *Bid `json:"bid,omitempty"`
+}
+
+// `BidValue` is the value of the bid with the given context
+type BidValue struct {
+	Context BidContext `json:"context"`
+	Value   U256Str    `json:"value"`
+	// Hash of the execution block of the bid
+	BlockHash Hash `json:"block_hash"`
+}
+
+// `BidSample` is one of the bids requested from a relay in a slot, where the bid stored for the context is the last sample
+type BidSample struct {
+	Context BidContext `json:"context"`
+	// Index of the sample in the slot, starting from 0
+	Sample uint `json:"sample"`
+	// Time into the slot the response was received, in milliseconds
+	OffsetMs int64 `json:"offset_ms"`
+	// A `nil` `Value` indicates the relay had no bid in the sample
+	Value     *U256Str `json:"value"`
+	BlockHash *Hash    `json:"block_hash,omitempty"`
+}
+
+// `BidCancellation` records a sample where a relay withdrew its bid or offered a lower value than in its previous sample of the slot
+type BidCancellation struct {
+	Context BidContext `json:"context"`
+	// Index of the sample cancelling the bid
+	Sample uint `json:"sample"`
+	// Time into the slot the cancelling response was received, in milliseconds
+	OffsetMs      int64   `json:"offset_ms"`
+	PreviousValue U256Str `json:"previous_value"`
+	// A `nil` `Value` indicates the relay withdrew its bid
+	Value *U256Str `json:"value"`
+}
+
+// `BidProvenance` is the raw response of a relay to a `getHeader` request, kept so a disputed analysis of the bid
+// can be replayed and verified byte-for-byte
+type BidProvenance struct {
+	Context BidContext `json:"context"`
+	// Index of the sample of the response in the slot, starting from 0
+	Sample      uint      `json:"sample"`
+	RequestedAt time.Time `json:"requested_at"`
+	ReceivedAt  time.Time `json:"received_at"`
+	StatusCode  int       `json:"status_code"`
+	// Headers of the response, as served by the relay
+	Header map[string][]string `json:"header"`
+	// Body of the response, as served by the relay
+	Body []byte `json:"body"`
+}
+
+// `WinningBid` is an accepted bid whose block became canonical, along with the value of the bid as collected by the monitor
+type WinningBid struct {
+	Context BidContext `json:"context"`
+	Value   U256Str    `json:"value"`
+}
+
+// `UpcomingSlot` annotates an upcoming slot with its proposer and the relays expected to serve it
+type UpcomingSlot struct {
+	Slot              Slot      `json:"slot,string"`
+	ProposerPublicKey PublicKey `json:"proposer_public_key"`
+	// Whether the monitor knows a validator registration for the proposer
+	Registered bool `json:"registered"`
+	// Relays which report a validator registration for the proposer
+	Relays []PublicKey `json:"relays"`
+}
+
+// `RelaySettings` are the settings the monitor collects data from a relay with, without any credentials
+type RelaySettings struct {
+	// Hostname of the relay
+	Endpoint string `json:"endpoint"`
+	// Endpoint of the relay as given to mev-boost, e.g. `https://0x...@relay.example.com`
+	URL       string `json:"url"`
+	TimeoutMs int64  `json:"timeout_ms"`
+	// Number of bids requested from the relay in each sampled slot, where `0` indicates the collector's default
+	Samples        uint     `json:"samples"`
+	DisabledChecks []string `json:"disabled_checks"`
+	// Names of the additional headers sent to the relay
+	Headers []string `json:"headers"`
+	// Whether basic auth credentials are sent to the relay
+	BasicAuth bool `json:"basic_auth"`
+	// Whether bids are requested SSZ-encoded from the relay
+	SSZ bool `json:"ssz"`
+	// Whether data is collected from the relay, where relays delisted from the registry are inactive
+	Active bool `json:"active"`
+}
+
+// `RelayMetadata` describes the operator of a relay beyond its endpoint, where any field may be empty
+type RelayMetadata struct {
+	// Name of the operator of the relay
+	Operator string `json:"operator,omitempty"`
+	// Network the relay serves, e.g. `mainnet`
+	Network     string `json:"network,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Geographic region the relay operates in, e.g. `eu`
+	Region string `json:"region,omitempty"`
+}
+
+// `RelayStatusInterval` is an inclusive range of slots over which probes of the relay's `status` endpoint found it up or down
+type RelayStatusInterval struct {
+	Up        bool `json:"up"`
+	StartSlot Slot `json:"start_slot,string"`
+	EndSlot   Slot `json:"end_slot,string"`
+}
+
+// `RelayTags` are the free-form tags attached to a relay
+type RelayTags struct {
+	RelayPublicKey PublicKey `json:"relay_public_key"`
+	Tags           []string  `json:"tags"`
+}